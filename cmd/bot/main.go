@@ -18,6 +18,11 @@ func main() {
 	registerCmds := flag.Bool("register", false, "Register slash commands with Discord and exit")
 	removeCmds := flag.Bool("remove-commands", false, "Remove all slash commands from Discord and exit")
 	showInvite := flag.Bool("invite", false, "Show the bot invite URL and exit")
+	runMigrations := flag.Bool("migrate", false, "Apply pending database migrations and exit")
+	migrateStatus := flag.Bool("migrate-status", false, "Show database migration status and exit")
+	rollbackSteps := flag.Int("rollback", 0, "Roll back the given number of database migrations and exit")
+	reconcilePoints := flag.Bool("reconcile-points", false, "Reset each member's total points to their points ledger and exit")
+	recomputeStreaks := flag.Bool("recompute-streaks", false, "Rebuild each member's standup streak from their standups and exit")
 	flag.Parse()
 
 	// Load configuration
@@ -26,18 +31,76 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	// Ensure data directory exists
-	dbDir := filepath.Dir(cfg.DatabasePath)
-	if err := os.MkdirAll(dbDir, 0755); err != nil {
-		log.Fatalf("Failed to create data directory: %v", err)
+	// Ensure data directory exists for SQLite
+	if cfg.DatabaseDriver == database.DriverSQLite {
+		dbDir := filepath.Dir(cfg.DatabasePath)
+		if err := os.MkdirAll(dbDir, 0755); err != nil {
+			log.Fatalf("Failed to create data directory: %v", err)
+		}
+	}
+
+	// Handle migration commands
+	if *migrateStatus || *rollbackSteps > 0 {
+		store, err := database.Open(cfg.DatabaseDriver, cfg.DatabaseDSN())
+		if err != nil {
+			log.Fatalf("Failed to open database: %v", err)
+		}
+		defer store.Close()
+
+		if *rollbackSteps > 0 {
+			if err := database.Rollback(store.DB(), *rollbackSteps); err != nil {
+				log.Fatalf("Failed to roll back migrations: %v", err)
+			}
+			log.Printf("Rolled back %d migration(s)", *rollbackSteps)
+		}
+
+		printMigrationStatus(store)
+		return
+	}
+
+	if *runMigrations {
+		store, err := database.Initialize(cfg.DatabaseDriver, cfg.DatabaseDSN())
+		if err != nil {
+			log.Fatalf("Failed to initialize database: %v", err)
+		}
+		defer store.Close()
+
+		printMigrationStatus(store)
+		log.Println("Migrations applied successfully!")
+		return
 	}
 
-	// Initialize database
-	if err := database.Initialize(cfg.DatabasePath); err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+	if *reconcilePoints {
+		store, err := database.Initialize(cfg.DatabaseDriver, cfg.DatabaseDSN())
+		if err != nil {
+			log.Fatalf("Failed to initialize database: %v", err)
+		}
+		defer store.Close()
+
+		corrected, err := store.ReconcilePoints()
+		if err != nil {
+			log.Fatalf("Failed to reconcile points: %v", err)
+		}
+		log.Printf("Reconciled points with the ledger (%d member(s) corrected)", corrected)
+		return
 	}
 
-	// Create bot instance
+	if *recomputeStreaks {
+		store, err := database.Initialize(cfg.DatabaseDriver, cfg.DatabaseDSN())
+		if err != nil {
+			log.Fatalf("Failed to initialize database: %v", err)
+		}
+		defer store.Close()
+
+		corrected, err := store.RecomputeStreaks()
+		if err != nil {
+			log.Fatalf("Failed to recompute streaks: %v", err)
+		}
+		log.Printf("Rebuilt streaks from standups (%d streak(s) corrected)", corrected)
+		return
+	}
+
+	// Create bot instance (opens and migrates the database)
 	b, err := bot.New(cfg)
 	if err != nil {
 		log.Fatalf("Failed to create bot: %v", err)
@@ -50,13 +113,14 @@ func main() {
 		return
 	}
 
-	// Start the bot to establish connection
-	if err := b.Start(); err != nil {
-		log.Fatalf("Failed to start bot: %v", err)
+	// Handle command registration/removal over a bare connection, so no jobs start
+	if *registerCmds || *removeCmds {
+		if err := b.Connect(); err != nil {
+			log.Fatalf("Failed to connect bot: %v", err)
+		}
+		defer b.Stop()
 	}
-	defer b.Stop()
 
-	// Handle command registration/removal
 	if *registerCmds {
 		if err := b.RegisterCommands(); err != nil {
 			log.Fatalf("Failed to register commands: %v", err)
@@ -73,6 +137,12 @@ func main() {
 		return
 	}
 
+	// Start the bot with its scheduler and vote processor
+	if err := b.Start(); err != nil {
+		log.Fatalf("Failed to start bot: %v", err)
+	}
+	defer b.Stop()
+
 	// Print invite URL on startup
 	log.Println("")
 	log.Println("===========================================")
@@ -92,3 +162,20 @@ func main() {
 
 	log.Println("Gracefully shutting down...")
 }
+
+// printMigrationStatus logs every known migration and whether it has been applied
+func printMigrationStatus(store *database.Store) {
+	states, err := database.GetMigrationStatus(store.DB())
+	if err != nil {
+		log.Fatalf("Failed to fetch migration status: %v", err)
+	}
+
+	log.Println("Database migrations:")
+	for _, state := range states {
+		if state.Applied {
+			log.Printf("  [x] %03d %s (applied %s)", state.Version, state.Description, state.AppliedAt.Format("2006-01-02 15:04:05"))
+		} else {
+			log.Printf("  [ ] %03d %s", state.Version, state.Description)
+		}
+	}
+}
@@ -3,39 +3,79 @@ package bot
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/commands"
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/config"
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
-	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/openai"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/scheduler"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/summary"
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/voter"
 	"github.com/bwmarrin/discordgo"
 )
 
 // Bot represents the Discord bot instance
 type Bot struct {
-	Session      *discordgo.Session
-	Config       *config.Config
-	Scheduler    *scheduler.Scheduler
-	Voter        *voter.Voter
-	OpenAIClient *openai.Client
+	Session   *discordgo.Session
+	Config    *config.Config
+	Store     *database.Store
+	Scheduler *scheduler.Scheduler
+	Voter     *voter.Voter
+	Estimator estimator.Estimator
+	// Summarizer condenses each member's week of standups for the weekly digest
+	Summarizer summary.Summarizer
 }
 
-// New creates a new Bot instance
+// New creates a new Bot instance, opening and migrating the configured database
 func New(cfg *config.Config) (*Bot, error) {
 	session, err := discordgo.New("Bot " + cfg.BotToken)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Discord session: %w", err)
 	}
 
-	// Initialize OpenAI client (may be nil if no API key)
-	openaiClient := openai.New(cfg.OpenAIAPIKey)
+	store, err := database.Initialize(cfg.DatabaseDriver, cfg.DatabaseDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	// Pick the goal point estimator; without an API key this is the offline heuristic
+	pointsEstimator, err := estimator.New(estimator.Config{
+		Provider: cfg.EstimatorProvider,
+		APIKey:   cfg.OpenAIAPIKey,
+		BaseURL:  cfg.OpenAIBaseURL,
+		Model:    cfg.OpenAIModel,
+		Cache:    store,
+		Limits: estimator.Limits{
+			Timeout:        cfg.EstimatorTimeout,
+			Retries:        cfg.EstimatorRetries,
+			Backoff:        time.Second,
+			GuildPerMinute: cfg.EstimatorGuildPerMinute,
+			GuildPerDay:    cfg.EstimatorGuildPerDay,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up point estimation: %w", err)
+	}
+
+	// Pick the standup digest summarizer; unless an operator opts in this is plain concatenation
+	standupSummarizer, err := summary.New(summary.Config{
+		Provider: cfg.SummaryProvider,
+		APIKey:   cfg.OpenAIAPIKey,
+		BaseURL:  cfg.OpenAIBaseURL,
+		Model:    cfg.OpenAIModel,
+		Timeout:  cfg.EstimatorTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up standup summaries: %w", err)
+	}
 
 	bot := &Bot{
-		Session:      session,
-		Config:       cfg,
-		OpenAIClient: openaiClient,
+		Session:    session,
+		Config:     cfg,
+		Store:      store,
+		Estimator:  pointsEstimator,
+		Summarizer: standupSummarizer,
 	}
 
 	// Register the interaction handler
@@ -48,29 +88,39 @@ func New(cfg *config.Config) (*Bot, error) {
 	session.AddHandler(bot.handleMessageReactionAdd)
 	session.AddHandler(bot.handleMessageReactionRemove)
 
+	// Register the message handler for replies in standup threads
+	session.AddHandler(bot.handleMessageCreate)
+
 	return bot, nil
 }
 
-// Start opens the Discord connection and starts listening
-func (b *Bot) Start() error {
-	// Set intents - we need guilds for slash commands and reactions for resource voting
-	b.Session.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessageReactions
+// Connect opens the Discord connection without starting any background work,
+// for one-off tasks like registering commands
+func (b *Bot) Connect() error {
+	// Set intents - we need guilds for slash commands, reactions for resource voting
+	// and message content for replies in standup threads
+	b.Session.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessageReactions |
+		discordgo.IntentsGuildMessages | discordgo.IntentsMessageContent
 
 	err := b.Session.Open()
 	if err != nil {
 		return fmt.Errorf("failed to open Discord connection: %w", err)
 	}
+	return nil
+}
 
-	// Start the reminder scheduler if a channel is configured
-	if b.Config.ReminderChannelID != "" {
-		b.Scheduler = scheduler.New(b.Session, b.Config.ReminderChannelID)
-		b.Scheduler.Start()
-	} else {
-		log.Println("No reminder channel configured, scheduler not started")
+// Start opens the Discord connection and starts listening
+func (b *Bot) Start() error {
+	if err := b.Connect(); err != nil {
+		return err
 	}
 
+	// Start the reminder scheduler; guilds without their own reminder channel use the env fallback
+	b.Scheduler = scheduler.New(b.Session, b.Store, b.Config.ReminderChannelID, b.Summarizer)
+	b.Scheduler.Start()
+
 	// Start the vote processor
-	b.Voter = voter.New(b.Session)
+	b.Voter = voter.New(b.Session, b.Store)
 	b.Voter.Start()
 
 	log.Println("Bootstrap Hub Bot is now running!")
@@ -91,7 +141,10 @@ func (b *Bot) Stop() error {
 		b.Voter.Stop()
 	}
 
-	return b.Session.Close()
+	if err := b.Session.Close(); err != nil {
+		return err
+	}
+	return b.Store.Close()
 }
 
 // RegisterCommands registers all slash commands with Discord
@@ -150,7 +203,7 @@ func (b *Bot) handleReady(s *discordgo.Session, r *discordgo.Ready) {
 func (b *Bot) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	switch i.Type {
 	case discordgo.InteractionApplicationCommand:
-		handlers := commands.GetHandlers(b.OpenAIClient)
+		handlers := commands.GetHandlers(b.Store, b.Estimator)
 		cmdName := i.ApplicationCommandData().Name
 
 		if handler, ok := handlers[cmdName]; ok {
@@ -161,8 +214,27 @@ func (b *Bot) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCr
 
 	case discordgo.InteractionMessageComponent:
 		// Handle button/select menu interactions
+		if commands.IsFocusComponent(i.MessageComponentData().CustomID) {
+			commands.HandleFocusComponent(s, i, b.Store)
+			return
+		}
 		commands.HandleHelpComponent(s, i)
+
+	case discordgo.InteractionModalSubmit:
+		if commands.IsStandupModal(i.ModalSubmitData().CustomID) {
+			commands.HandleStandupModal(s, i, b.Store)
+		}
+	}
+}
+
+// handleMessageCreate posts replies in standup threads as standups
+func (b *Bot) handleMessageCreate(s *discordgo.Session, m *discordgo.MessageCreate) {
+	// Ignore the bot's own messages
+	if m.Author == nil || m.Author.ID == s.State.User.ID {
+		return
 	}
+
+	commands.HandleStandupReply(s, m, b.Store)
 }
 
 // GetInviteURL generates the bot invite URL with necessary permissions
@@ -193,7 +265,7 @@ func (b *Bot) handleMessageReactionAdd(s *discordgo.Session, r *discordgo.Messag
 	}
 
 	// Look up resource by message ID
-	resource, err := database.GetPublicResourceByVoteMessageID(r.MessageID)
+	resource, err := b.Store.GetPublicResourceByVoteMessageID(r.MessageID)
 	if err != nil {
 		log.Printf("Error fetching resource by message ID: %v", err)
 		return
@@ -206,11 +278,11 @@ func (b *Bot) handleMessageReactionAdd(s *discordgo.Session, r *discordgo.Messag
 
 	// Only count valid emoji reactions
 	if r.Emoji.Name == "👍" {
-		if err := database.IncrementUsefulVotes(resource.ID); err != nil {
+		if err := b.Store.IncrementUsefulVotes(resource.ID); err != nil {
 			log.Printf("Error incrementing useful votes: %v", err)
 		}
 	} else if r.Emoji.Name == "👎" {
-		if err := database.IncrementNotUsefulVotes(resource.ID); err != nil {
+		if err := b.Store.IncrementNotUsefulVotes(resource.ID); err != nil {
 			log.Printf("Error incrementing not useful votes: %v", err)
 		}
 	}
@@ -224,7 +296,7 @@ func (b *Bot) handleMessageReactionRemove(s *discordgo.Session, r *discordgo.Mes
 	}
 
 	// Look up resource by message ID
-	resource, err := database.GetPublicResourceByVoteMessageID(r.MessageID)
+	resource, err := b.Store.GetPublicResourceByVoteMessageID(r.MessageID)
 	if err != nil {
 		log.Printf("Error fetching resource by message ID: %v", err)
 		return
@@ -237,11 +309,11 @@ func (b *Bot) handleMessageReactionRemove(s *discordgo.Session, r *discordgo.Mes
 
 	// Only count valid emoji reactions
 	if r.Emoji.Name == "👍" {
-		if err := database.DecrementUsefulVotes(resource.ID); err != nil {
+		if err := b.Store.DecrementUsefulVotes(resource.ID); err != nil {
 			log.Printf("Error decrementing useful votes: %v", err)
 		}
 	} else if r.Emoji.Name == "👎" {
-		if err := database.DecrementNotUsefulVotes(resource.ID); err != nil {
+		if err := b.Store.DecrementNotUsefulVotes(resource.ID); err != nil {
 			log.Printf("Error decrementing not useful votes: %v", err)
 		}
 	}
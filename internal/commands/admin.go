@@ -4,13 +4,15 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
 	"github.com/bwmarrin/discordgo"
 )
 
 // configCommand creates the /config command for admins
-func configCommand() *Command {
+func configCommand(store *database.Store) *Command {
 	return &Command{
 		Definition: &discordgo.ApplicationCommand{
 			Name:        "config",
@@ -68,22 +70,365 @@ func configCommand() *Command {
 						},
 					},
 				},
+				{
+					Name:        "help-channel",
+					Description: "Set the channel new blockers are posted to for help (leave empty to turn off)",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "channel",
+							Description: "The channel to post blockers in",
+							Type:        discordgo.ApplicationCommandOptionChannel,
+							Required:    false,
+							ChannelTypes: []discordgo.ChannelType{
+								discordgo.ChannelTypeGuildText,
+							},
+						},
+					},
+				},
+				{
+					Name:        "timezone",
+					Description: "Set the default timezone for members who haven't picked their own",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "zone",
+							Description: "IANA timezone such as Europe/Berlin",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Required:    true,
+						},
+					},
+				},
+				{
+					Name:        "reminders",
+					Description: "Configure where and when reminders are posted",
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "channel",
+							Description: "Set the channel for reminder posts",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "channel",
+									Description: "The channel to post reminders in",
+									Type:        discordgo.ApplicationCommandOptionChannel,
+									Required:    true,
+									ChannelTypes: []discordgo.ChannelType{
+										discordgo.ChannelTypeGuildText,
+									},
+								},
+							},
+						},
+						{
+							Name:        "time",
+							Description: "Set the hour reminders are sent, in each member's timezone",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "hour",
+									Description: "Hour of the day (0-23)",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    true,
+									MinValue:    floatPtr(0),
+									MaxValue:    23,
+								},
+							},
+						},
+						{
+							Name:        "toggle",
+							Description: "Turn reminders for a feature on or off",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "feature",
+									Description: "Which reminders to change",
+									Type:        discordgo.ApplicationCommandOptionString,
+									Required:    true,
+									Choices: []*discordgo.ApplicationCommandOptionChoice{
+										{Name: "Focus Periods", Value: string(database.ReminderFeatureFocus)},
+										{Name: "Standups", Value: string(database.ReminderFeatureStandup)},
+										{Name: "Challenges", Value: string(database.ReminderFeatureChallenge)},
+										{Name: "MRR", Value: string(database.ReminderFeatureMRR)},
+									},
+								},
+								{
+									Name:        "enabled",
+									Description: "Whether these reminders are sent",
+									Type:        discordgo.ApplicationCommandOptionBoolean,
+									Required:    true,
+								},
+							},
+						},
+						{
+							Name:        "show",
+							Description: "Show the current reminder settings",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+						},
+					},
+				},
+				{
+					Name:        "focus",
+					Description: "Configure Focus Period length, reminder days and step points",
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "length",
+							Description: "Set how many days new Focus Periods run",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "days",
+									Description: fmt.Sprintf("Length in days (%d-%d)", database.MinFocusPeriodDays, database.MaxFocusPeriodDays),
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    true,
+									MinValue:    floatPtr(database.MinFocusPeriodDays),
+									MaxValue:    database.MaxFocusPeriodDays,
+								},
+							},
+						},
+						{
+							Name:        "reminders",
+							Description: "Set how far through a Focus Period reminders are sent",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "points",
+									Description: "Comma-separated percentages such as 25,50,90, or \"default\"",
+									Type:        discordgo.ApplicationCommandOptionString,
+									Required:    true,
+								},
+							},
+						},
+						{
+							Name:        "step-points",
+							Description: "Award a share of a goal's points as its steps are checked off",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "enabled",
+									Description: "Whether checking off steps earns partial points",
+									Type:        discordgo.ApplicationCommandOptionBoolean,
+									Required:    true,
+								},
+							},
+						},
+						{
+							Name:        "show",
+							Description: "Show the current Focus Period settings",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+						},
+					},
+				},
+				{
+					Name:        "sprints",
+					Description: "Run guild-wide cohort sprints that everyone's Focus Periods join",
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "mode",
+							Description: "Turn cohort mode on or off",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "enabled",
+									Description: "Whether /focus start joins the current cohort sprint",
+									Type:        discordgo.ApplicationCommandOptionBoolean,
+									Required:    true,
+								},
+							},
+						},
+						{
+							Name:        "create",
+							Description: "Schedule a cohort sprint",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "name",
+									Description: "Name of the sprint, e.g. March Sprint",
+									Type:        discordgo.ApplicationCommandOptionString,
+									Required:    true,
+								},
+								{
+									Name:        "start",
+									Description: "Start date as YYYY-MM-DD, in the server's timezone",
+									Type:        discordgo.ApplicationCommandOptionString,
+									Required:    true,
+								},
+								{
+									Name:        "days",
+									Description: "Length in days (defaults to the Focus Period length)",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    false,
+									MinValue:    floatPtr(database.MinFocusPeriodDays),
+									MaxValue:    database.MaxFocusPeriodDays,
+								},
+							},
+						},
+						{
+							Name:        "list",
+							Description: "Show the running and upcoming cohort sprints",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+						},
+						{
+							Name:        "delete",
+							Description: "Delete a cohort sprint that hasn't started",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "id",
+									Description: "Sprint ID from /config sprints list",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    true,
+								},
+							},
+						},
+					},
+				},
+				{
+					Name:        "guardrails",
+					Description: "Guard against members farming Focus Period points",
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "settings",
+							Description: "Change the guardrails, or show them when no options are given",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "min-minutes",
+									Description: "Hold points for goals completed sooner than this after being added (0 for no minimum)",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    false,
+									MinValue:    floatPtr(0),
+									MaxValue:    database.MaxMinCompleteMinutes,
+								},
+								{
+									Name:        "point-cap",
+									Description: "Most goal points a member can earn per Focus Period (0 for no cap)",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    false,
+									MinValue:    floatPtr(0),
+									MaxValue:    database.MaxPeriodPointCap,
+								},
+								{
+									Name:        "duplicates",
+									Description: "Hold points for goals matching one the member already completed",
+									Type:        discordgo.ApplicationCommandOptionBoolean,
+									Required:    false,
+								},
+							},
+						},
+						{
+							Name:        "queue",
+							Description: "List completions held for review",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+						},
+						{
+							Name:        "review",
+							Description: "Approve or reject a completion held for review",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "id",
+									Description: "ID from /config guardrails queue",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    true,
+								},
+								{
+									Name:        "decision",
+									Description: "Whether to award the held points",
+									Type:        discordgo.ApplicationCommandOptionString,
+									Required:    true,
+									Choices: []*discordgo.ApplicationCommandOptionChoice{
+										{Name: "Approve - award the points", Value: "approve"},
+										{Name: "Reject - keep them withheld", Value: "reject"},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Name:        "standup",
+					Description: "Configure daily standups",
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "threads",
+							Description: "Open a daily standup thread members can reply to (leave empty to turn off)",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "channel",
+									Description: "The channel to open standup threads in",
+									Type:        discordgo.ApplicationCommandOptionChannel,
+									Required:    false,
+									ChannelTypes: []discordgo.ChannelType{
+										discordgo.ChannelTypeGuildText,
+									},
+								},
+							},
+						},
+						{
+							Name:        "rest-days",
+							Description: "Set weekdays that don't count against standup streaks",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "days",
+									Description: "Comma-separated weekdays such as sat,sun, or \"none\"",
+									Type:        discordgo.ApplicationCommandOptionString,
+									Required:    true,
+								},
+							},
+						},
+						{
+							Name:        "digest",
+							Description: "Set the channel for the weekly standup digest (leave empty for the reminder channel)",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "channel",
+									Description: "The channel to post weekly standup digests in",
+									Type:        discordgo.ApplicationCommandOptionChannel,
+									Required:    false,
+									ChannelTypes: []discordgo.ChannelType{
+										discordgo.ChannelTypeGuildText,
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Name:        "jobs",
+					Description: "Show scheduled jobs and when they last ran",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+				},
 			},
 		},
-		Handler: handleConfigCommand,
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleConfigCommand(s, i, store)
+		},
 	}
 }
 
 // hasAdminRole checks if the user has one of the required admin roles
-func hasAdminRole(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+func hasAdminRole(s discord.Session, i *discordgo.InteractionCreate) bool {
 	// Ensure we're in a guild context
 	if i.GuildID == "" || i.Member == nil {
 		return false
 	}
 
 	// Get all roles in the guild
-	guild, err := s.State.Guild(i.GuildID)
-	if err != nil {
+	var guild *discordgo.Guild
+	var err error
+	if live, ok := s.(*discordgo.Session); ok {
+		// Prefer the gateway cache on a live session
+		guild, err = live.State.Guild(i.GuildID)
+	}
+	if guild == nil || err != nil {
 		// Try fetching from API if not in state
 		guild, err = s.Guild(i.GuildID)
 		if err != nil {
@@ -113,7 +458,7 @@ func hasAdminRole(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
 	return false
 }
 
-func handleConfigCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func handleConfigCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
 	// Check for required role
 	if !hasAdminRole(s, i) {
 		embed := &discordgo.MessageEmbed{
@@ -140,21 +485,41 @@ func handleConfigCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 	switch subCommand {
 	case "leaderboard-channel":
-		channelID := options[0].Options[0].ChannelValue(s).ID
-		handleConfigLeaderboardChannel(s, i, guildID, channelID)
+		channelID := optionChannel(s, i, options[0].Options[0]).ID
+		handleConfigLeaderboardChannel(s, i, store, guildID, channelID)
 	case "wins-channel":
-		channelID := options[0].Options[0].ChannelValue(s).ID
-		handleConfigWinsChannel(s, i, guildID, channelID)
+		channelID := optionChannel(s, i, options[0].Options[0]).ID
+		handleConfigWinsChannel(s, i, store, guildID, channelID)
 	case "mrr-channel":
-		channelID := options[0].Options[0].ChannelValue(s).ID
-		handleConfigMRRChannel(s, i, guildID, channelID)
+		channelID := optionChannel(s, i, options[0].Options[0]).ID
+		handleConfigMRRChannel(s, i, store, guildID, channelID)
+	case "help-channel":
+		var channelID string
+		if len(options[0].Options) > 0 {
+			channelID = optionChannel(s, i, options[0].Options[0]).ID
+		}
+		handleConfigHelpChannel(s, i, store, guildID, channelID)
+	case "reminders":
+		handleConfigReminders(s, i, store, guildID, options[0].Options)
+	case "focus":
+		handleConfigFocus(s, i, store, guildID, options[0].Options)
+	case "sprints":
+		handleConfigSprints(s, i, store, guildID, options[0].Options)
+	case "guardrails":
+		handleConfigGuardrails(s, i, store, guildID, options[0].Options)
+	case "standup":
+		handleConfigStandup(s, i, store, guildID, options[0].Options)
+	case "timezone":
+		handleConfigTimezone(s, i, store, guildID, options[0].Options[0].StringValue())
+	case "jobs":
+		handleConfigJobs(s, i, store)
 	default:
 		respondWithError(s, i, "Unknown subcommand")
 	}
 }
 
-func handleConfigLeaderboardChannel(s *discordgo.Session, i *discordgo.InteractionCreate, guildID, channelID string) {
-	err := database.UpdateLeaderboardChannel(guildID, channelID)
+func handleConfigLeaderboardChannel(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID, channelID string) {
+	err := store.UpdateLeaderboardChannel(guildID, channelID)
 	if err != nil {
 		log.Printf("Error updating leaderboard channel: %v", err)
 		respondWithError(s, i, "Failed to update leaderboard channel.")
@@ -163,7 +528,7 @@ func handleConfigLeaderboardChannel(s *discordgo.Session, i *discordgo.Interacti
 
 	embed := &discordgo.MessageEmbed{
 		Title:       "Configuration Updated",
-		Description: fmt.Sprintf("Leaderboard channel set to <#%s>\n\nBi-weekly leaderboards will now be automatically posted to this channel when Focus Periods end.", channelID),
+		Description: fmt.Sprintf("Leaderboard channel set to <#%s>\n\nSprint leaderboards will now be automatically posted to this channel when Focus Periods end.", channelID),
 		Color:       0x00FF00, // Green
 		Footer: &discordgo.MessageEmbedFooter{
 			Text: "Bootstrap Hub Bot Configuration",
@@ -173,8 +538,8 @@ func handleConfigLeaderboardChannel(s *discordgo.Session, i *discordgo.Interacti
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleConfigWinsChannel(s *discordgo.Session, i *discordgo.InteractionCreate, guildID, channelID string) {
-	err := database.UpdateWinsChannel(guildID, channelID)
+func handleConfigWinsChannel(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID, channelID string) {
+	err := store.UpdateWinsChannel(guildID, channelID)
 	if err != nil {
 		log.Printf("Error updating wins channel: %v", err)
 		respondWithError(s, i, "Failed to update wins channel.")
@@ -193,8 +558,8 @@ func handleConfigWinsChannel(s *discordgo.Session, i *discordgo.InteractionCreat
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleConfigMRRChannel(s *discordgo.Session, i *discordgo.InteractionCreate, guildID, channelID string) {
-	err := database.UpdateMRRChannel(guildID, channelID)
+func handleConfigMRRChannel(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID, channelID string) {
+	err := store.UpdateMRRChannel(guildID, channelID)
 	if err != nil {
 		log.Printf("Error updating MRR channel: %v", err)
 		respondWithError(s, i, "Failed to update MRR channel.")
@@ -212,3 +577,989 @@ func handleConfigMRRChannel(s *discordgo.Session, i *discordgo.InteractionCreate
 
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
+
+func handleConfigHelpChannel(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID, channelID string) {
+	err := store.UpdateHelpChannel(guildID, channelID)
+	if err != nil {
+		log.Printf("Error updating help channel: %v", err)
+		respondWithError(s, i, "Failed to update help channel.")
+		return
+	}
+
+	description := "Blockers will no longer be posted for help.\n\nMembers can still see them with `/blocker list`."
+	if channelID != "" {
+		description = fmt.Sprintf("Help channel set to <#%s>\n\nBlockers members mention in their standups will now be posted here so others can help, and a weekly digest of unresolved blockers goes here too.", channelID)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Configuration Updated",
+		Description: description,
+		Color:       0x00FF00, // Green
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Bootstrap Hub Bot Configuration",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleConfigReminders(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if len(options) == 0 {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	var err error
+	subCommand := options[0]
+	switch subCommand.Name {
+	case "channel":
+		err = store.UpdateReminderChannel(guildID, optionChannel(s, i, subCommand.Options[0]).ID)
+	case "time":
+		err = store.UpdateReminderHour(guildID, int(subCommand.Options[0].IntValue()))
+	case "toggle":
+		var feature database.ReminderFeature
+		var enabled bool
+		for _, opt := range subCommand.Options {
+			switch opt.Name {
+			case "feature":
+				feature = database.ReminderFeature(opt.StringValue())
+			case "enabled":
+				enabled = opt.BoolValue()
+			}
+		}
+		err = store.SetReminderEnabled(guildID, feature, enabled)
+	case "show":
+		// Nothing to change, just report the settings
+	default:
+		respondWithError(s, i, "Unknown subcommand")
+		return
+	}
+
+	if err != nil {
+		log.Printf("Error updating reminder settings: %v", err)
+		respondWithError(s, i, "Failed to update reminder settings.")
+		return
+	}
+
+	config, err := store.GetGuildConfig(guildID)
+	if err != nil {
+		log.Printf("Error fetching reminder settings: %v", err)
+		respondWithError(s, i, "Failed to fetch reminder settings.")
+		return
+	}
+
+	title := "Configuration Updated"
+	if subCommand.Name == "show" {
+		title = "Reminder Settings"
+	}
+
+	channel := "Not set - using the bot's default channel"
+	if config.ReminderChannel != "" {
+		channel = fmt.Sprintf("<#%s>", config.ReminderChannel)
+	}
+
+	var toggles strings.Builder
+	for _, feature := range database.ReminderFeatures {
+		status := "❌ Off"
+		if config.ReminderEnabled(feature) {
+			status = "✅ On"
+		}
+		toggles.WriteString(fmt.Sprintf("**%s:** %s\n", reminderTypeLabels[feature], status))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: title,
+		Color: 0x00FF00, // Green
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Channel",
+				Value:  channel,
+				Inline: true,
+			},
+			{
+				Name:   "Time",
+				Value:  fmt.Sprintf("%02d:00 in each member's timezone", config.ReminderHour),
+				Inline: true,
+			},
+			{
+				Name:   "Reminders",
+				Value:  toggles.String(),
+				Inline: false,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Bootstrap Hub Bot Configuration",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleConfigFocus(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if len(options) == 0 {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	var err error
+	subCommand := options[0]
+	switch subCommand.Name {
+	case "length":
+		err = store.UpdateFocusPeriodLength(guildID, int(subCommand.Options[0].IntValue()))
+	case "reminders":
+		value := strings.TrimSpace(subCommand.Options[0].StringValue())
+		var points []int
+		if !strings.EqualFold(value, "default") {
+			points, err = database.ParseReminderPoints(value)
+			if err != nil || len(points) == 0 {
+				respondWithError(s, i, fmt.Sprintf("Invalid reminder points `%s`. Use comma-separated percentages between 1 and 100, e.g. `25,50,90`, or `default`.", value))
+				return
+			}
+		}
+		err = store.UpdateFocusReminderPoints(guildID, points)
+	case "step-points":
+		err = store.SetStepPointsEnabled(guildID, subCommand.Options[0].BoolValue())
+	case "show":
+		// Nothing to change, just report the settings
+	default:
+		respondWithError(s, i, "Unknown subcommand")
+		return
+	}
+
+	if err != nil {
+		log.Printf("Error updating focus period settings: %v", err)
+		respondWithError(s, i, "Failed to update Focus Period settings.")
+		return
+	}
+
+	config, err := store.GetGuildConfig(guildID)
+	if err != nil {
+		log.Printf("Error fetching focus period settings: %v", err)
+		respondWithError(s, i, "Failed to fetch Focus Period settings.")
+		return
+	}
+
+	title := "Configuration Updated"
+	if subCommand.Name == "show" {
+		title = "Focus Period Settings"
+	}
+
+	length := config.FocusLength()
+	points := config.ReminderPoints()
+	percents := make([]string, 0, len(points))
+	for _, point := range points {
+		percents = append(percents, fmt.Sprintf("%d%%", point))
+	}
+	days := database.ReminderDaysFor(length, points)
+	dayLabels := make([]string, 0, len(days))
+	for _, day := range days {
+		dayLabels = append(dayLabels, fmt.Sprintf("%d", day))
+	}
+	stepPoints := "❌ Off"
+	if config.StepPointsEnabled {
+		stepPoints = "✅ On"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: title,
+		Color: 0x00FF00, // Green
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Length",
+				Value:  fmt.Sprintf("%d days", length),
+				Inline: true,
+			},
+			{
+				Name:   "Reminder Points",
+				Value:  strings.Join(percents, ", "),
+				Inline: true,
+			},
+			{
+				Name:   "Reminder Days",
+				Value:  fmt.Sprintf("Days %s of %d", strings.Join(dayLabels, ", "), length),
+				Inline: false,
+			},
+			{
+				Name:   "Step Points",
+				Value:  stepPoints,
+				Inline: false,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Focus Periods that already started keep their length",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleConfigSprints(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if len(options) == 0 {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	var err error
+	description := ""
+	subCommand := options[0]
+	switch subCommand.Name {
+	case "mode":
+		enabled := subCommand.Options[0].BoolValue()
+		err = store.SetCohortSprints(guildID, enabled)
+		description = "Cohort mode is off. `/focus start` begins a personal Focus Period."
+		if enabled {
+			description = "Cohort mode is on. `/focus start` joins the current sprint, and one leaderboard and recap is posted when each sprint ends."
+		}
+	case "create":
+		var name, start string
+		config, err := store.GetGuildConfig(guildID)
+		if err != nil {
+			log.Printf("Error fetching guild config: %v", err)
+			respondWithError(s, i, "Failed to create the sprint.")
+			return
+		}
+		days := config.FocusLength()
+		for _, opt := range subCommand.Options {
+			switch opt.Name {
+			case "name":
+				name = strings.TrimSpace(opt.StringValue())
+			case "start":
+				start = strings.TrimSpace(opt.StringValue())
+			case "days":
+				days = int(opt.IntValue())
+			}
+		}
+
+		startDate, err := time.ParseInLocation("2006-01-02", start, store.GuildLocation(guildID))
+		if err != nil {
+			respondWithError(s, i, fmt.Sprintf("Invalid start date `%s`. Use the format YYYY-MM-DD, e.g. `2026-03-02`.", start))
+			return
+		}
+
+		sprint, err := store.CreateCohortSprint(guildID, name, startDate, days)
+		if err != nil {
+			respondWithError(s, i, fmt.Sprintf("Couldn't create the sprint: %v", err))
+			return
+		}
+		description = fmt.Sprintf("**%s** (ID %d) is scheduled for %d days from %s.", sprint.Name, sprint.ID, days, startDate.Format("Jan 2, 2006"))
+	case "list":
+		// Nothing to change, just report the sprints
+	case "delete":
+		sprintID := uint(subCommand.Options[0].IntValue())
+		if err := store.DeleteCohortSprint(guildID, sprintID); err != nil {
+			respondWithError(s, i, fmt.Sprintf("Couldn't delete the sprint: %v", err))
+			return
+		}
+		description = fmt.Sprintf("Sprint %d was deleted.", sprintID)
+	default:
+		respondWithError(s, i, "Unknown subcommand")
+		return
+	}
+
+	if err != nil {
+		log.Printf("Error updating cohort sprints: %v", err)
+		respondWithError(s, i, "Failed to update cohort sprints.")
+		return
+	}
+
+	config, err := store.GetGuildConfig(guildID)
+	if err != nil {
+		log.Printf("Error fetching guild config: %v", err)
+		respondWithError(s, i, "Failed to fetch cohort sprints.")
+		return
+	}
+
+	sprints, err := store.GetUpcomingCohortSprints(guildID)
+	if err != nil {
+		log.Printf("Error fetching cohort sprints: %v", err)
+		respondWithError(s, i, "Failed to fetch cohort sprints.")
+		return
+	}
+
+	title := "Configuration Updated"
+	if subCommand.Name == "list" {
+		title = "Cohort Sprints"
+	}
+
+	mode := "❌ Off"
+	if config.CohortSprints {
+		mode = "✅ On"
+	}
+
+	loc := store.GuildLocation(guildID)
+	var schedule strings.Builder
+	for _, sprint := range sprints {
+		schedule.WriteString(fmt.Sprintf("`%d` **%s** - %s to %s\n", sprint.ID, sprint.Name,
+			sprint.StartDate.In(loc).Format("Jan 2"), sprint.EndDate.In(loc).AddDate(0, 0, -1).Format("Jan 2, 2006")))
+	}
+	if len(sprints) == 0 {
+		schedule.WriteString("No sprints scheduled. Use `/config sprints create` to add one.")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: description,
+		Color:       0x00FF00, // Green
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Cohort Mode",
+				Value:  mode,
+				Inline: false,
+			},
+			{
+				Name:   "Sprints",
+				Value:  schedule.String(),
+				Inline: false,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Bootstrap Hub Bot Configuration",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleConfigGuardrails(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if len(options) == 0 {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	subCommand := options[0]
+	switch subCommand.Name {
+	case "settings":
+		handleConfigGuardrailSettings(s, i, store, guildID, subCommand.Options)
+	case "queue":
+		handleConfigGuardrailQueue(s, i, store, guildID)
+	case "review":
+		var flagID uint
+		var decision string
+		for _, opt := range subCommand.Options {
+			switch opt.Name {
+			case "id":
+				flagID = uint(opt.IntValue())
+			case "decision":
+				decision = opt.StringValue()
+			}
+		}
+		handleConfigGuardrailReview(s, i, store, guildID, flagID, decision == "approve")
+	default:
+		respondWithError(s, i, "Unknown subcommand")
+	}
+}
+
+func handleConfigGuardrailSettings(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	config, err := store.GetGuildConfig(guildID)
+	if err != nil {
+		log.Printf("Error fetching guild config: %v", err)
+		respondWithError(s, i, "Failed to fetch the guardrails.")
+		return
+	}
+
+	// Options that aren't given keep their current values
+	minMinutes, pointCap, duplicates := config.MinCompleteMinutes, config.PeriodPointCap, config.DuplicateGoalCheck
+	for _, opt := range options {
+		switch opt.Name {
+		case "min-minutes":
+			minMinutes = int(opt.IntValue())
+		case "point-cap":
+			pointCap = int(opt.IntValue())
+		case "duplicates":
+			duplicates = opt.BoolValue()
+		}
+	}
+
+	title := "Guardrails"
+	if len(options) > 0 {
+		if err := store.UpdateGuardrails(guildID, minMinutes, pointCap, duplicates); err != nil {
+			log.Printf("Error updating guardrails: %v", err)
+			respondWithError(s, i, "Failed to update the guardrails.")
+			return
+		}
+		title = "Configuration Updated"
+	}
+
+	minimum := "Off"
+	if minMinutes > 0 {
+		minimum = fmt.Sprintf("%d minutes", minMinutes)
+	}
+	limit := "No cap"
+	if pointCap > 0 {
+		limit = fmt.Sprintf("%d points", pointCap)
+	}
+	duplicateCheck := "❌ Off"
+	if duplicates {
+		duplicateCheck = "✅ On"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: "Completions that trip a guardrail have their points held, including any earned for steps, until an admin reviews them with `/config guardrails queue`.",
+		Color:       0x00FF00, // Green
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Minimum Time to Complete",
+				Value:  minimum,
+				Inline: true,
+			},
+			{
+				Name:   "Points per Focus Period",
+				Value:  limit,
+				Inline: true,
+			},
+			{
+				Name:   "Duplicate Goal Check",
+				Value:  duplicateCheck,
+				Inline: true,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Bootstrap Hub Bot Configuration",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleConfigGuardrailQueue(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string) {
+	flags, err := store.GetPendingFlaggedCompletions(guildID)
+	if err != nil {
+		log.Printf("Error fetching flagged completions: %v", err)
+		respondWithError(s, i, "Failed to fetch the review queue.")
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Completions Held for Review",
+		Description: "Nothing to review. 🎉",
+		Color:       0xFFA500, // Orange
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Use /config guardrails review <id> to decide",
+		},
+	}
+
+	// Embeds hold at most 25 fields
+	const maxShown = 25
+	for _, flag := range flags {
+		if len(embed.Fields) == maxShown {
+			break
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: fmt.Sprintf("ID %d - %s: %s", flag.ID, flag.User.Username, flag.Task.Title),
+			Value: fmt.Sprintf("%d points held · completed <t:%d:R>\n%s",
+				flag.HeldPoints, flag.CreatedAt.Unix(), flag.Reasons),
+			Inline: false,
+		})
+	}
+	if len(flags) > 0 {
+		embed.Description = fmt.Sprintf("%d completion(s) waiting for review, oldest first.", len(flags))
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleConfigGuardrailReview(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string, flagID uint, approve bool) {
+	reviewer, err := store.GetOrCreateUser(i.Member.User.ID, guildID, i.Member.User.Username)
+	if err != nil {
+		log.Printf("Error getting/creating user: %v", err)
+		respondWithError(s, i, "Failed to process your request. Please try again.")
+		return
+	}
+
+	flag, credited, capped, err := store.ReviewFlaggedCompletion(guildID, flagID, reviewer.ID, approve)
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	description := fmt.Sprintf("%s's completion of **%s** was rejected; its %d points stay withheld.", flag.User.Username, flag.Task.Title, flag.HeldPoints)
+	if approve {
+		description = fmt.Sprintf("%s's completion of **%s** was approved; +%d points awarded.", flag.User.Username, flag.Task.Title, credited)
+		if capped > 0 {
+			description += fmt.Sprintf(" %d points weren't awarded because their Focus Period has reached the server's point cap.", capped)
+		}
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Completion Reviewed",
+		Description: description,
+		Color:       0x00FF00, // Green
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Bootstrap Hub Bot Configuration",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleConfigStandup(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if len(options) == 0 {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	subCommand := options[0]
+	switch subCommand.Name {
+	case "threads":
+		var channelID string
+		for _, opt := range subCommand.Options {
+			if opt.Name == "channel" {
+				channelID = optionChannel(s, i, opt).ID
+			}
+		}
+		handleConfigStandupThreads(s, i, store, guildID, channelID)
+	case "rest-days":
+		handleConfigStandupRestDays(s, i, store, guildID, subCommand.Options[0].StringValue())
+	case "digest":
+		var channelID string
+		for _, opt := range subCommand.Options {
+			if opt.Name == "channel" {
+				channelID = optionChannel(s, i, opt).ID
+			}
+		}
+		handleConfigStandupDigest(s, i, store, guildID, channelID)
+	default:
+		respondWithError(s, i, "Unknown subcommand")
+	}
+}
+
+func handleConfigStandupThreads(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID, channelID string) {
+	if err := store.UpdateStandupThreadChannel(guildID, channelID); err != nil {
+		log.Printf("Error updating standup thread channel: %v", err)
+		respondWithError(s, i, "Failed to update standup threads.")
+		return
+	}
+
+	description := "Daily standup threads turned off.\n\nMembers can still check in with `/standup post`."
+	if channelID != "" {
+		description = fmt.Sprintf("Standup threads will open in <#%s>\n\nEach day at the reminder time the bot opens a thread, and replies in it are posted as standups.", channelID)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Configuration Updated",
+		Description: description,
+		Color:       0x00FF00, // Green
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Bootstrap Hub Bot Configuration",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleConfigStandupRestDays(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID, value string) {
+	days, err := database.ParseRestDays(value)
+	if err != nil {
+		respondWithError(s, i, fmt.Sprintf("Invalid rest days `%s`: %s. Use comma-separated weekdays such as `sat,sun`, or `none`.", value, err))
+		return
+	}
+
+	if err := store.UpdateStandupRestDays(guildID, days); err != nil {
+		log.Printf("Error updating rest days: %v", err)
+		respondWithError(s, i, "Failed to update rest days.")
+		return
+	}
+
+	description := "No rest days: every missed day counts against standup streaks."
+	if len(days) > 0 {
+		names := make([]string, 0, len(days))
+		for _, day := range days {
+			names = append(names, day.String())
+		}
+		description = fmt.Sprintf("Rest days set to **%s**\n\nMissing a standup on these days won't break anyone's streak, and no standup reminders are sent.", strings.Join(names, ", "))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Configuration Updated",
+		Description: description,
+		Color:       0x00FF00, // Green
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Bootstrap Hub Bot Configuration",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleConfigStandupDigest(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID, channelID string) {
+	if err := store.UpdateStandupDigestChannel(guildID, channelID); err != nil {
+		log.Printf("Error updating standup digest channel: %v", err)
+		respondWithError(s, i, "Failed to update the standup digest channel.")
+		return
+	}
+
+	description := "Weekly standup digests will be posted to the reminder channel."
+	if channelID != "" {
+		description = fmt.Sprintf("Weekly standup digests will be posted to <#%s>", channelID)
+	}
+	description += "\n\nEvery Monday at the reminder time the bot posts last week's participation and each member's standups, and DMs each digest to the member's buddies."
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Configuration Updated",
+		Description: description,
+		Color:       0x00FF00, // Green
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Bootstrap Hub Bot Configuration",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleConfigTimezone(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID, zone string) {
+	zone = strings.TrimSpace(zone)
+	if _, err := database.LoadTimezone(zone); err != nil {
+		respondWithError(s, i, fmt.Sprintf("Unknown timezone `%s`. Use a name from the IANA timezone database, e.g. `America/New_York` or `Asia/Singapore`.", zone))
+		return
+	}
+
+	err := store.UpdateGuildTimezone(guildID, zone)
+	if err != nil {
+		log.Printf("Error updating guild timezone: %v", err)
+		respondWithError(s, i, "Failed to update timezone.")
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Configuration Updated",
+		Description: fmt.Sprintf("Default timezone set to **%s**\n\nStandup days, Focus Period days and reminders now follow this timezone for members who haven't set their own with `/settings timezone`.", store.GuildLocation(guildID)),
+		Color:       0x00FF00, // Green
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Bootstrap Hub Bot Configuration",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleConfigJobs(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
+	jobs, err := store.GetScheduledJobs()
+	if err != nil {
+		log.Printf("Error fetching scheduled jobs: %v", err)
+		respondWithError(s, i, "Failed to fetch scheduled jobs.")
+		return
+	}
+
+	if len(jobs) == 0 {
+		respondWithError(s, i, "No scheduled jobs have been registered yet. Is the scheduler running?")
+		return
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, len(jobs))
+	for _, job := range jobs {
+		lastRun := "Never"
+		if job.LastRunAt != nil {
+			lastRun = job.LastRunAt.Format("Jan 2, 15:04")
+		}
+
+		value := fmt.Sprintf("%s\n📅 %s\n✅ Last run: %s", job.Description, job.Schedule, lastRun)
+		if job.LastError != "" {
+			value += fmt.Sprintf("\n⚠️ Last error: %s", truncateString(job.LastError, 200))
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   job.Name,
+			Value:  value,
+			Inline: false,
+		})
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Scheduled Jobs",
+		Description: "Each job runs at most once per period. Jobs missed while the bot was offline run as soon as it is back.",
+		Color:       0x5865F2, // Discord blurple
+		Fields:      fields,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Bootstrap Hub Bot Configuration",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+// adminCommand creates the /admin command for moderating points and seasons
+func adminCommand(store *database.Store) *Command {
+	adjustOptions := func(verb string) []*discordgo.ApplicationCommandOption {
+		return []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "user",
+				Description: fmt.Sprintf("The member to %s points", verb),
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Required:    true,
+			},
+			{
+				Name:        "amount",
+				Description: fmt.Sprintf("How many points to %s", verb),
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Required:    true,
+				MinValue:    floatPtr(1),
+				MaxValue:    database.MaxPointsAdjustment,
+			},
+			{
+				Name:        "reason",
+				Description: "Why, recorded in the member's points history",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Required:    true,
+				MaxLength:   200,
+			},
+		}
+	}
+
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name:        "admin",
+			Description: "Moderate points, seasons and standups (admin only)",
+			DefaultMemberPermissions: func() *int64 {
+				perms := int64(discordgo.PermissionAdministrator)
+				return &perms
+			}(),
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "points",
+					Description: "Grant or revoke a member's points",
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "grant",
+							Description: "Give a member points",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options:     adjustOptions("grant"),
+						},
+						{
+							Name:        "revoke",
+							Description: "Take points away from a member",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options:     adjustOptions("revoke"),
+						},
+					},
+				},
+				{
+					Name:        "season",
+					Description: "Manage leaderboard seasons",
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "archive",
+							Description: "End the season: save the standings and start a fresh leaderboard",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "name",
+									Description: "Name to archive the season under, e.g. Spring 2026",
+									Type:        discordgo.ApplicationCommandOptionString,
+									Required:    true,
+									MaxLength:   database.MaxSeasonNameLength,
+								},
+							},
+						},
+					},
+				},
+				{
+					Name:        "standup",
+					Description: "Repair members' standup streaks",
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "backfill",
+							Description: "Record a standup for a day a member missed, e.g. during a bot outage",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "user",
+									Description: "The member who missed the day",
+									Type:        discordgo.ApplicationCommandOptionUser,
+									Required:    true,
+								},
+								{
+									Name:        "date",
+									Description: "The missed day in the member's timezone, e.g. 2026-03-02",
+									Type:        discordgo.ApplicationCommandOptionString,
+									Required:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleAdminCommand(s, i, store)
+		},
+	}
+}
+
+func handleAdminCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
+	if !hasAdminRole(s, i) {
+		embed := &discordgo.MessageEmbed{
+			Title:       "Permission Denied",
+			Description: "You need one of these roles to use this command: Admin, Moderator, or Mod",
+			Color:       0xFF0000, // Red
+		}
+		respondWithEmbedEphemeral(s, i, embed, true)
+		return
+	}
+
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 || len(options[0].Options) == 0 {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	admin, err := store.GetOrCreateUser(i.Member.User.ID, i.GuildID, i.Member.User.Username)
+	if err != nil {
+		log.Printf("Error getting/creating user: %v", err)
+		respondWithError(s, i, "Failed to process your request. Please try again.")
+		return
+	}
+
+	group, subCommand := options[0].Name, options[0].Options[0]
+	switch group + " " + subCommand.Name {
+	case "points grant", "points revoke":
+		handleAdminPoints(s, i, store, admin, subCommand)
+	case "season archive":
+		handleAdminSeasonArchive(s, i, store, admin, subCommand.Options[0].StringValue())
+	case "standup backfill":
+		handleAdminStandupBackfill(s, i, store, subCommand)
+	default:
+		respondWithError(s, i, "Unknown subcommand")
+	}
+}
+
+func handleAdminPoints(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, admin *database.User, subCommand *discordgo.ApplicationCommandInteractionDataOption) {
+	var target *discordgo.User
+	var amount int
+	var reason string
+	for _, opt := range subCommand.Options {
+		switch opt.Name {
+		case "user":
+			target = optionUser(i, opt)
+		case "amount":
+			amount = int(opt.IntValue())
+		case "reason":
+			reason = opt.StringValue()
+		}
+	}
+	if target == nil {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	member, err := store.GetOrCreateUser(target.ID, i.GuildID, target.Username)
+	if err != nil {
+		log.Printf("Error getting/creating user: %v", err)
+		respondWithError(s, i, "Failed to find that user.")
+		return
+	}
+
+	title, verb, change := "Points Granted", "granted to", amount
+	if subCommand.Name == "revoke" {
+		title, verb, change = "Points Revoked", "revoked from", -amount
+	}
+
+	total, err := store.AdjustPoints(member.ID, i.GuildID, change, admin.ID, reason)
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: fmt.Sprintf("**%d** points %s **%s**. They now have **%d** points.", amount, verb, target.Username, total),
+		Color:       0x00FF00, // Green
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Reason",
+				Value: reason,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Recorded in /points history",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleAdminSeasonArchive(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, admin *database.User, name string) {
+	season, err := store.ArchiveSeason(i.GuildID, name, admin.ID)
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("🏁 %s Has Ended", season.Name),
+		Description: seasonStandingsText(season.Standings, 3) + "\nEveryone's points are reset for a fresh leaderboard. Good luck this season!",
+		Color:       0xFFD700, // Gold
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("See the final standings with /leaderboard season %s", season.Name),
+		},
+	}
+
+	respondWithEmbed(s, i, embed)
+}
+
+func handleAdminStandupBackfill(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, subCommand *discordgo.ApplicationCommandInteractionDataOption) {
+	var target *discordgo.User
+	var date string
+	for _, opt := range subCommand.Options {
+		switch opt.Name {
+		case "user":
+			target = optionUser(i, opt)
+		case "date":
+			date = strings.TrimSpace(opt.StringValue())
+		}
+	}
+	if target == nil {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	member, err := store.GetOrCreateUser(target.ID, i.GuildID, target.Username)
+	if err != nil {
+		log.Printf("Error getting/creating user: %v", err)
+		respondWithError(s, i, "Failed to find that user.")
+		return
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", date, store.UserLocation(member.ID, i.GuildID))
+	if err != nil {
+		respondWithError(s, i, fmt.Sprintf("Invalid date `%s`. Use the format YYYY-MM-DD, e.g. `2026-03-02`.", date))
+		return
+	}
+
+	_, streak, err := store.BackfillStandup(member.ID, i.GuildID, day)
+	if err != nil {
+		respondWithError(s, i, fmt.Sprintf("Couldn't backfill the standup: %v", err))
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Standup Backfilled",
+		Description: fmt.Sprintf("Recorded a standup for **%s** on **%s** and rebuilt their streak.", target.Username, day.Format("Mon, Jan 2")),
+		Color:       0x00FF00, // Green
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Current Streak",
+				Value:  pluralize(streak.CurrentStreak, "day"),
+				Inline: true,
+			},
+			{
+				Name:   "Longest Streak",
+				Value:  pluralize(streak.LongestStreak, "day"),
+				Inline: true,
+			},
+			{
+				Name:   "Total Standups",
+				Value:  fmt.Sprintf("%d", streak.TotalStandups),
+				Inline: true,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "The standup earns its base point but no streak bonus",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
@@ -0,0 +1,317 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord/discordtest"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestConfigReminders(t *testing.T) {
+	h := newTestHarness(t)
+	admin := newTestUser("user-admin", "admin")
+	channel := &discordgo.Channel{ID: "channel-nudges", Name: "nudges"}
+
+	resp := h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("reminders", discordtest.SubCommand("channel", discordtest.Channel("channel", channel))))
+	embed := assertTitle(t, resp, "Configuration Updated")
+	if embed.Fields[0].Value != "<#channel-nudges>" {
+		t.Errorf("Expected the new reminder channel, got %q", embed.Fields[0].Value)
+	}
+
+	h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("reminders", discordtest.SubCommand("time", discordtest.Int("hour", 7))))
+	h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("reminders", discordtest.SubCommand("toggle",
+		discordtest.String("feature", "standup"),
+		discordtest.Bool("enabled", false),
+	)))
+
+	config, err := h.store.GetGuildConfig(testGuildID)
+	if err != nil {
+		t.Fatalf("Failed to load guild config: %v", err)
+	}
+	if config.ReminderChannel != channel.ID || config.ReminderHour != 7 {
+		t.Errorf("Expected channel %s at 07:00, got %s at %d", channel.ID, config.ReminderChannel, config.ReminderHour)
+	}
+	if config.StandupRemindersEnabled || !config.FocusRemindersEnabled {
+		t.Errorf("Expected only standup reminders to be off, got %+v", config)
+	}
+
+	resp = h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("reminders", discordtest.SubCommand("show")))
+	embed = assertTitle(t, resp, "Reminder Settings")
+	if !strings.Contains(embed.Fields[2].Value, "**Standups:** ❌ Off") {
+		t.Errorf("Expected standup reminders to show as off, got %q", embed.Fields[2].Value)
+	}
+
+	// Members without an admin role can't change reminders
+	resp = h.run(newTestUser("user-alice", "alice"), nil, "config", discordtest.SubCommandGroup("reminders", discordtest.SubCommand("show")))
+	assertTitle(t, resp, "Permission Denied")
+}
+
+func TestConfigFocus(t *testing.T) {
+	h := newTestHarness(t)
+	admin := newTestUser("user-admin", "admin")
+	alice := newTestUser("user-alice", "alice")
+
+	resp := h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("focus", discordtest.SubCommand("length", discordtest.Int("days", 7))))
+	embed := assertTitle(t, resp, "Configuration Updated")
+	if embed.Fields[2].Value != "Days 2, 4, 5, 6, 7 of 7" {
+		t.Errorf("Expected the default reminder days scaled to 7 days, got %q", embed.Fields[2].Value)
+	}
+
+	resp = h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("focus", discordtest.SubCommand("reminders", discordtest.String("points", "every day"))))
+	assertError(t, resp, "Invalid reminder points")
+
+	h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("focus", discordtest.SubCommand("reminders", discordtest.String("points", "50, 90"))))
+	resp = h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("focus", discordtest.SubCommand("show")))
+	embed = assertTitle(t, resp, "Focus Period Settings")
+	if embed.Fields[1].Value != "50%, 90%" || embed.Fields[2].Value != "Days 4, 7 of 7" {
+		t.Errorf("Expected reminders at 50%% and 90%%, got %q / %q", embed.Fields[1].Value, embed.Fields[2].Value)
+	}
+
+	// New periods pick up the guild's length
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	embed = assertTitle(t, resp, "New Focus Period Started!")
+	if !strings.Contains(embed.Description, "7-day") {
+		t.Errorf("Expected a 7-day Focus Period, got %q", embed.Description)
+	}
+}
+
+func TestConfigSprints(t *testing.T) {
+	h := newTestHarness(t)
+	admin := newTestUser("user-admin", "admin")
+	alice := newTestUser("user-alice", "alice")
+
+	resp := h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("sprints", discordtest.SubCommand("mode", discordtest.Bool("enabled", true))))
+	embed := assertTitle(t, resp, "Configuration Updated")
+	if embed.Fields[0].Value != "✅ On" {
+		t.Errorf("Expected cohort mode to be on, got %q", embed.Fields[0].Value)
+	}
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	assertTitle(t, resp, "No Sprint Running")
+
+	resp = h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("sprints", discordtest.SubCommand("create",
+		discordtest.String("name", "Spring Sprint"),
+		discordtest.String("start", "next tuesday"),
+	)))
+	assertError(t, resp, "Invalid start date")
+
+	today := time.Now().Format("2006-01-02")
+	resp = h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("sprints", discordtest.SubCommand("create",
+		discordtest.String("name", "Spring Sprint"),
+		discordtest.String("start", today),
+		discordtest.Int("days", 7),
+	)))
+	embed = assertTitle(t, resp, "Configuration Updated")
+	if !strings.Contains(embed.Fields[1].Value, "Spring Sprint") {
+		t.Errorf("Expected the new sprint in the schedule, got %q", embed.Fields[1].Value)
+	}
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	embed = assertTitle(t, resp, "New Focus Period Started!")
+	if !strings.Contains(embed.Description, "Spring Sprint") {
+		t.Errorf("Expected to join the cohort sprint, got %q", embed.Description)
+	}
+
+	// Sprints that already started can't be deleted
+	resp = h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("sprints", discordtest.SubCommand("delete", discordtest.Int("id", 1))))
+	assertError(t, resp, "Couldn't delete the sprint")
+}
+
+func TestConfigGuardrails(t *testing.T) {
+	h := newTestHarness(t)
+	admin := newTestUser("user-admin", "admin")
+	alice := newTestUser("user-alice", "alice")
+	adminRoles := []string{testAdminRoleID}
+
+	resp := h.run(admin, adminRoles, "config", discordtest.SubCommandGroup("guardrails", discordtest.SubCommand("settings")))
+	embed := assertTitle(t, resp, "Guardrails")
+	if embed.Fields[0].Value != "Off" || embed.Fields[2].Value != "✅ On" {
+		t.Errorf("Expected no minimum and the duplicate check on by default, got %q and %q", embed.Fields[0].Value, embed.Fields[2].Value)
+	}
+
+	resp = h.run(admin, adminRoles, "config", discordtest.SubCommandGroup("guardrails", discordtest.SubCommand("settings", discordtest.Int("min-minutes", 15))))
+	embed = assertTitle(t, resp, "Configuration Updated")
+	if embed.Fields[0].Value != "15 minutes" || embed.Fields[1].Value != "No cap" {
+		t.Errorf("Expected only the minimum to change, got %q and %q", embed.Fields[0].Value, embed.Fields[1].Value)
+	}
+
+	h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", "Ship landing page")))
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("complete", discordtest.Int("number", 1)))
+	embed = assertTitle(t, resp, "Goal Completed!")
+	if !strings.Contains(embed.Description, "+0 points") || embed.Fields[2].Name != "Points Held for Review" {
+		t.Errorf("Expected the quick completion's points to be held, got %q and %+v", embed.Description, embed.Fields)
+	}
+
+	// A repeat of a completed goal is pointed out when it's added
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", "ship the landing page")))
+	embed = assertTitle(t, resp, "Goal Added!")
+	if embed.Fields[2].Name != "Looks Familiar" {
+		t.Errorf("Expected a duplicate warning, got %+v", embed.Fields)
+	}
+
+	resp = h.run(admin, adminRoles, "config", discordtest.SubCommandGroup("guardrails", discordtest.SubCommand("queue")))
+	embed = assertTitle(t, resp, "Completions Held for Review")
+	if len(embed.Fields) != 1 || !strings.HasPrefix(embed.Fields[0].Name, "ID 1 - alice: Ship landing page") {
+		t.Fatalf("Expected alice's completion in the queue, got %+v", embed.Fields)
+	}
+
+	resp = h.run(admin, adminRoles, "config", discordtest.SubCommandGroup("guardrails", discordtest.SubCommand("review",
+		discordtest.Int("id", 1), discordtest.String("decision", "approve"))))
+	embed = assertTitle(t, resp, "Completion Reviewed")
+	if !strings.Contains(embed.Description, "+5 points awarded") {
+		t.Errorf("Expected the held points to be awarded, got %q", embed.Description)
+	}
+
+	user, _ := h.store.GetOrCreateUser(alice.ID, testGuildID, alice.Username)
+	member, _ := h.store.GetGuildMember(user.ID, testGuildID)
+	if member.TotalPoints != 5 {
+		t.Errorf("Expected 5 points after approval, got %d", member.TotalPoints)
+	}
+
+	resp = h.run(admin, adminRoles, "config", discordtest.SubCommandGroup("guardrails", discordtest.SubCommand("queue")))
+	embed = assertTitle(t, resp, "Completions Held for Review")
+	if len(embed.Fields) != 0 {
+		t.Errorf("Expected an empty queue, got %+v", embed.Fields)
+	}
+}
+
+func TestConfigStandupThreads(t *testing.T) {
+	h := newTestHarness(t)
+	admin := newTestUser("user-admin", "admin")
+	channel := &discordgo.Channel{ID: "channel-standups", Name: "standups"}
+
+	resp := h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("standup", discordtest.SubCommand("threads", discordtest.Channel("channel", channel))))
+	embed := assertTitle(t, resp, "Configuration Updated")
+	if !strings.Contains(embed.Description, "<#channel-standups>") {
+		t.Errorf("Expected the standup thread channel, got %q", embed.Description)
+	}
+	configs, err := h.store.GetGuildsWithStandupThreads()
+	if err != nil || len(configs) != 1 || configs[0].StandupThreadChannel != channel.ID {
+		t.Fatalf("Expected standup threads in %s, got %+v (%v)", channel.ID, configs, err)
+	}
+
+	// Leaving the channel out turns threads off
+	h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("standup", discordtest.SubCommand("threads")))
+	if configs, _ := h.store.GetGuildsWithStandupThreads(); len(configs) != 0 {
+		t.Errorf("Expected standup threads to be off, got %+v", configs)
+	}
+}
+
+func TestConfigStandupDigest(t *testing.T) {
+	h := newTestHarness(t)
+	admin := newTestUser("user-admin", "admin")
+	channel := &discordgo.Channel{ID: "channel-digest", Name: "digest"}
+
+	resp := h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("standup", discordtest.SubCommand("digest", discordtest.Channel("channel", channel))))
+	embed := assertTitle(t, resp, "Configuration Updated")
+	if !strings.Contains(embed.Description, "<#channel-digest>") {
+		t.Errorf("Expected the digest channel, got %q", embed.Description)
+	}
+	if config, _ := h.store.GetGuildConfig(testGuildID); config.StandupDigestChannel != channel.ID {
+		t.Fatalf("Expected digests in %s, got %q", channel.ID, config.StandupDigestChannel)
+	}
+
+	// Leaving the channel out goes back to the reminder channel
+	resp = h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("standup", discordtest.SubCommand("digest")))
+	embed = assertTitle(t, resp, "Configuration Updated")
+	if !strings.Contains(embed.Description, "reminder channel") {
+		t.Errorf("Expected digests in the reminder channel, got %q", embed.Description)
+	}
+	if config, _ := h.store.GetGuildConfig(testGuildID); config.StandupDigestChannel != "" {
+		t.Errorf("Expected no digest channel, got %q", config.StandupDigestChannel)
+	}
+}
+
+func TestAdminPointsAndSeasons(t *testing.T) {
+	h := newTestHarness(t)
+	admin := newTestUser("user-admin", "admin")
+	alice := newTestUser("user-alice", "alice")
+	adminRoles := []string{testAdminRoleID}
+
+	grant := discordtest.SubCommandGroup("points", discordtest.SubCommand("grant",
+		discordtest.User("user", alice), discordtest.Int("amount", 12), discordtest.String("reason", "Ran the demo day")))
+	resp := h.run(alice, nil, "admin", grant)
+	assertTitle(t, resp, "Permission Denied")
+
+	resp = h.run(admin, adminRoles, "admin", grant)
+	embed := assertTitle(t, resp, "Points Granted")
+	if !strings.Contains(embed.Description, "They now have **12** points") || embed.Fields[0].Value != "Ran the demo day" {
+		t.Errorf("Expected 12 points granted with the reason, got %q and %+v", embed.Description, embed.Fields)
+	}
+
+	resp = h.run(admin, adminRoles, "admin", discordtest.SubCommandGroup("points", discordtest.SubCommand("revoke",
+		discordtest.User("user", alice), discordtest.Int("amount", 20), discordtest.String("reason", "Oops"))))
+	assertError(t, resp, "only 12 points can be revoked")
+
+	resp = h.run(admin, adminRoles, "admin", discordtest.SubCommandGroup("points", discordtest.SubCommand("revoke",
+		discordtest.User("user", alice), discordtest.Int("amount", 2), discordtest.String("reason", "Counted twice"))))
+	embed = assertTitle(t, resp, "Points Revoked")
+	if !strings.Contains(embed.Description, "They now have **10** points") {
+		t.Errorf("Expected 10 points left, got %q", embed.Description)
+	}
+
+	resp = h.run(alice, nil, "points", discordtest.SubCommand("history"))
+	embed = assertTitle(t, resp, "📒 Your Points")
+	if !strings.Contains(embed.Fields[1].Value, "`-2` Admin adjustments · Counted twice") {
+		t.Errorf("Expected the revoke and its reason in the history, got %q", embed.Fields[1].Value)
+	}
+
+	resp = h.run(alice, nil, "leaderboard", discordtest.SubCommand("season"))
+	assertTitle(t, resp, "Past Seasons")
+
+	resp = h.run(admin, adminRoles, "admin", discordtest.SubCommandGroup("season", discordtest.SubCommand("archive", discordtest.String("name", "Spring 2026"))))
+	embed = assertTitle(t, resp, "🏁 Spring 2026 Has Ended")
+	if isEphemeral(resp) {
+		t.Error("Expected the season's end to be announced publicly")
+	}
+	if !strings.Contains(embed.Description, "🥇 **alice** - 10 points") {
+		t.Errorf("Expected alice as champion, got %q", embed.Description)
+	}
+
+	resp = h.run(alice, nil, "leaderboard", discordtest.SubCommand("alltime"))
+	assertTitle(t, resp, "All-Time Leaderboard")
+
+	resp = h.run(alice, nil, "leaderboard", discordtest.SubCommand("season"))
+	embed = assertTitle(t, resp, "🏁 Past Seasons")
+	if !strings.Contains(embed.Description, "**Spring 2026**") || !strings.Contains(embed.Description, "🥇 alice - 10 points") {
+		t.Errorf("Expected the archived season with its champion, got %q", embed.Description)
+	}
+
+	resp = h.run(alice, nil, "leaderboard", discordtest.SubCommand("season", discordtest.String("name", "spring 2026")))
+	embed = assertTitle(t, resp, "🏁 Spring 2026 - Final Standings")
+	if !strings.Contains(embed.Description, "🥇 **alice** - 10 points (0 tasks)") {
+		t.Errorf("Expected the final standings, got %q", embed.Description)
+	}
+
+	resp = h.run(alice, nil, "leaderboard", discordtest.SubCommand("season", discordtest.String("name", "Winter")))
+	assertError(t, resp, "no season named")
+}
+
+func TestAdminStandupBackfill(t *testing.T) {
+	h := newTestHarness(t)
+	admin := newTestUser("user-admin", "admin")
+	alice := newTestUser("user-alice", "alice")
+	adminRoles := []string{testAdminRoleID}
+
+	backfill := func(user *discordgo.User, roles []string, date string) *discordgo.InteractionResponse {
+		return h.run(user, roles, "admin", discordtest.SubCommandGroup("standup", discordtest.SubCommand("backfill",
+			discordtest.User("user", alice), discordtest.String("date", date))))
+	}
+	day := func(daysAgo int) string {
+		return time.Now().AddDate(0, 0, -daysAgo).Format("2006-01-02")
+	}
+
+	assertTitle(t, backfill(alice, nil, day(1)), "Permission Denied")
+	assertError(t, backfill(admin, adminRoles, "yesterday"), "Use the format YYYY-MM-DD")
+	assertError(t, backfill(admin, adminRoles, day(0)), "only days before today can be backfilled")
+
+	assertTitle(t, backfill(admin, adminRoles, day(2)), "Standup Backfilled")
+	embed := assertTitle(t, backfill(admin, adminRoles, day(1)), "Standup Backfilled")
+	if embed.Fields[0].Value != "2 days" || embed.Fields[2].Value != "2" {
+		t.Errorf("Expected a 2-day streak from 2 standups, got %+v", embed.Fields)
+	}
+	assertError(t, backfill(admin, adminRoles, day(1)), "already a standup")
+}
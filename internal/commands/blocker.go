@@ -0,0 +1,224 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
+	"github.com/bwmarrin/discordgo"
+)
+
+// blockerListLimit is how many open blockers /blocker list shows
+const blockerListLimit = 15
+
+// blockerCommand creates the /blocker command
+func blockerCommand(store *database.Store) *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name:        "blocker",
+			Description: "See what's blocking members and credit whoever helps",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "list",
+					Description: "List the server's unresolved blockers",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+				},
+				{
+					Name:        "resolve",
+					Description: "Mark one of your blockers resolved and credit whoever helped",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "id",
+							Description: "Blocker ID from /blocker list",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+						},
+						{
+							Name:        "helper",
+							Description: fmt.Sprintf("The member who helped (earns %d points)", database.BlockerHelpPoints),
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Required:    false,
+						},
+					},
+				},
+			},
+		},
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleBlockerCommand(s, i, store)
+		},
+	}
+}
+
+func handleBlockerCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	if i.Member == nil || i.GuildID == "" {
+		respondWithError(s, i, "Blocker commands can only be used in a server.")
+		return
+	}
+
+	switch options[0].Name {
+	case "list":
+		handleBlockerList(s, i, store, i.GuildID)
+	case "resolve":
+		var blockerID uint
+		var helper *discordgo.User
+		for _, opt := range options[0].Options {
+			switch opt.Name {
+			case "id":
+				blockerID = uint(opt.IntValue())
+			case "helper":
+				helper = optionUser(i, opt)
+			}
+		}
+		handleBlockerResolve(s, i, store, i.GuildID, blockerID, helper)
+	default:
+		respondWithError(s, i, "Unknown subcommand")
+	}
+}
+
+// blockerLines lists blockers one per line with their ID, owner and age
+func blockerLines(blockers []database.Blocker, limit int, now time.Time) string {
+	var text strings.Builder
+	for idx, blocker := range blockers {
+		if idx >= limit {
+			text.WriteString(fmt.Sprintf("\n*...and %d more*", len(blockers)-limit))
+			break
+		}
+		days := max(int(now.Sub(blocker.CreatedAt).Hours()/24), 0)
+		age := "today"
+		if days > 0 {
+			age = fmt.Sprintf("%dd ago", days)
+		}
+		text.WriteString(fmt.Sprintf("`#%d` **%s** · %s · %s\n", blocker.ID, blocker.User.Username, truncateString(blocker.Description, 80), age))
+	}
+	return text.String()
+}
+
+func handleBlockerList(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string) {
+	blockers, err := store.GetOpenBlockers(guildID)
+	if err != nil {
+		log.Printf("Error fetching open blockers: %v", err)
+		respondWithError(s, i, "Failed to fetch blockers.")
+		return
+	}
+
+	if len(blockers) == 0 {
+		embed := &discordgo.MessageEmbed{
+			Title:       "Unresolved Blockers",
+			Description: "Nobody is blocked right now. 🎉\n\nBlockers you mention in `/standup post` show up here until they're resolved.",
+			Color:       0x00FF00, // Green
+		}
+		respondWithEmbedEphemeral(s, i, embed, true)
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🚧 Unresolved Blockers",
+		Description: blockerLines(blockers, blockerListLimit, time.Now()),
+		Color:       0xFFA500, // Orange
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Helped someone? They can credit you with /blocker resolve",
+		},
+	}
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleBlockerResolve(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string, blockerID uint, helper *discordgo.User) {
+	resolver, err := store.GetOrCreateUser(i.Member.User.ID, guildID, i.Member.User.Username)
+	if err != nil {
+		log.Printf("Error getting/creating user: %v", err)
+		respondWithError(s, i, "Failed to process your request. Please try again.")
+		return
+	}
+
+	blocker, err := store.GetBlocker(guildID, blockerID)
+	if err != nil {
+		log.Printf("Error fetching blocker: %v", err)
+		respondWithError(s, i, "Failed to fetch the blocker.")
+		return
+	}
+	if blocker == nil {
+		respondWithError(s, i, fmt.Sprintf("Blocker #%d not found. Use `/blocker list` to see IDs.", blockerID))
+		return
+	}
+	if blocker.UserID != resolver.ID && !hasAdminRole(s, i) {
+		respondWithError(s, i, fmt.Sprintf("Only %s or an admin can resolve this blocker.", blocker.User.Username))
+		return
+	}
+
+	var helperID *uint
+	if helper != nil {
+		if helper.Bot {
+			respondWithError(s, i, "Bots can't be credited for helping.")
+			return
+		}
+		helperUser, err := store.GetOrCreateUser(helper.ID, guildID, helper.Username)
+		if err != nil {
+			log.Printf("Error getting/creating helper: %v", err)
+			respondWithError(s, i, "Failed to process your request. Please try again.")
+			return
+		}
+		helperID = &helperUser.ID
+	}
+
+	blocker, credited, err := store.ResolveBlocker(guildID, blockerID, helperID)
+	if err != nil {
+		respondWithError(s, i, fmt.Sprintf("Couldn't resolve the blocker: %s.", err))
+		return
+	}
+
+	description := fmt.Sprintf("<@%s> is unblocked: %s", blocker.User.DiscordID, truncateString(blocker.Description, 200))
+	if credited > 0 {
+		description += fmt.Sprintf("\n\nThanks to <@%s> for helping! **+%d points**", helper.ID, credited)
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("✅ Blocker #%d Resolved", blocker.ID),
+		Description: description,
+		Color:       0x00FF00, // Green
+	}
+	respondWithEmbed(s, i, embed)
+
+	if blocker.ChannelID != "" && blocker.MessageID != "" {
+		if err := s.MessageReactionAdd(blocker.ChannelID, blocker.MessageID, "✅"); err != nil {
+			log.Printf("Error marking blocker post resolved: %v", err)
+		}
+	}
+}
+
+// postBlocker asks for help with a new blocker in the guild's help channel, if it has one
+func postBlocker(s discord.Session, store *database.Store, guildID, username string, blocker *database.Blocker) {
+	if blocker == nil {
+		return
+	}
+	config, err := store.GetGuildConfig(guildID)
+	if err != nil || config.HelpChannel == "" {
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("🚧 %s is blocked", username),
+		Description: truncateString(blocker.Description, 1000),
+		Color:       0xFFA500, // Orange
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Blocker #%d · Helped out? Ask them to credit you with /blocker resolve", blocker.ID),
+		},
+	}
+
+	msg, err := s.ChannelMessageSendEmbed(config.HelpChannel, embed)
+	if err != nil {
+		log.Printf("Error posting blocker to help channel: %v", err)
+		return
+	}
+	if err := store.UpdateBlockerMessage(blocker.ID, config.HelpChannel, msg.ID); err != nil {
+		log.Printf("Error saving blocker message: %v", err)
+	}
+}
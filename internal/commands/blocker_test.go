@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord/discordtest"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestBlockerHelpFlow(t *testing.T) {
+	h := newTestHarness(t)
+	admin := newTestUser("user-admin", "admin")
+	alice := newTestUser("user-alice", "alice")
+	bob := newTestUser("user-bob", "bob")
+	helpChannel := &discordgo.Channel{ID: "channel-help", Name: "help"}
+
+	resp := h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommand("help-channel", discordtest.Channel("channel", helpChannel)))
+	assertTitle(t, resp, "Configuration Updated")
+
+	resp = h.run(alice, nil, "blocker", discordtest.SubCommand("list"))
+	assertTitle(t, resp, "Unresolved Blockers")
+
+	h.run(alice, nil, "standup", discordtest.SubCommand("post"))
+	h.submit(alice, standupModalID, map[string]string{
+		standupWorkingOnID: "Pricing page",
+		standupBlockersID:  "Stripe webhooks keep failing",
+	})
+	posts := h.session.MessagesIn(helpChannel.ID)
+	if len(posts) != 1 || posts[0].Embed.Title != "🚧 alice is blocked" || posts[0].Embed.Description != "Stripe webhooks keep failing" {
+		t.Fatalf("Expected the blocker in the help channel, got %+v", posts)
+	}
+
+	resp = h.run(bob, nil, "blocker", discordtest.SubCommand("list"))
+	embed := assertTitle(t, resp, "🚧 Unresolved Blockers")
+	if !strings.HasPrefix(embed.Description, "`#1` **alice** · Stripe webhooks keep failing · today") {
+		t.Errorf("Expected alice's blocker listed, got %q", embed.Description)
+	}
+
+	// Only the blocked member or an admin can resolve it
+	resp = h.run(bob, nil, "blocker", discordtest.SubCommand("resolve", discordtest.Int("id", 1), discordtest.User("helper", bob)))
+	assertError(t, resp, "Only alice or an admin")
+
+	resp = h.run(alice, nil, "blocker", discordtest.SubCommand("resolve", discordtest.Int("id", 1), discordtest.User("helper", bob)))
+	embed = assertTitle(t, resp, "✅ Blocker #1 Resolved")
+	if !strings.Contains(embed.Description, "Thanks to <@user-bob> for helping! **+5 points**") {
+		t.Errorf("Expected bob to be thanked, got %q", embed.Description)
+	}
+	if reactions := h.session.Reactions(); len(reactions) != 1 || reactions[0].ChannelID != helpChannel.ID || reactions[0].Emoji != "✅" {
+		t.Errorf("Expected the help channel post marked resolved, got %+v", reactions)
+	}
+
+	resp = h.run(alice, nil, "blocker", discordtest.SubCommand("resolve", discordtest.Int("id", 1)))
+	assertError(t, resp, "already resolved")
+}
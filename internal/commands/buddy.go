@@ -6,11 +6,12 @@ import (
 	"strings"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
 	"github.com/bwmarrin/discordgo"
 )
 
 // buddyCommand creates the /buddy command group
-func buddyCommand() *Command {
+func buddyCommand(store *database.Store) *Command {
 	return &Command{
 		Definition: &discordgo.ApplicationCommand{
 			Name:        "buddy",
@@ -88,11 +89,13 @@ func buddyCommand() *Command {
 				},
 			},
 		},
-		Handler: handleBuddyCommand,
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleBuddyCommand(s, i, store)
+		},
 	}
 }
 
-func handleBuddyCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func handleBuddyCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
 	options := i.ApplicationCommandData().Options
 	if len(options) == 0 {
 		respondWithError(s, i, "Invalid command usage")
@@ -119,7 +122,7 @@ func handleBuddyCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	}
 
 	// Get or create user in database
-	user, err := database.GetOrCreateUser(userID, guildID, username)
+	user, err := store.GetOrCreateUser(userID, guildID, username)
 	if err != nil {
 		log.Printf("Error getting/creating user: %v", err)
 		respondWithError(s, i, "Failed to process your request. Please try again.")
@@ -128,31 +131,31 @@ func handleBuddyCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 	switch subCommand {
 	case "request":
-		targetUser := options[0].Options[0].UserValue(s)
-		handleBuddyRequest(s, i, user, guildID, targetUser)
+		targetUser := optionUser(i, options[0].Options[0])
+		handleBuddyRequest(s, i, store, user, guildID, targetUser)
 	case "accept":
-		targetUser := options[0].Options[0].UserValue(s)
-		handleBuddyAccept(s, i, user, guildID, targetUser)
+		targetUser := optionUser(i, options[0].Options[0])
+		handleBuddyAccept(s, i, store, user, guildID, targetUser)
 	case "decline":
-		targetUser := options[0].Options[0].UserValue(s)
-		handleBuddyDecline(s, i, user, guildID, targetUser)
+		targetUser := optionUser(i, options[0].Options[0])
+		handleBuddyDecline(s, i, store, user, guildID, targetUser)
 	case "status":
 		var targetUser *discordgo.User
 		if len(options[0].Options) > 0 {
-			targetUser = options[0].Options[0].UserValue(s)
+			targetUser = optionUser(i, options[0].Options[0])
 		}
-		handleBuddyStatus(s, i, user, guildID, targetUser)
+		handleBuddyStatus(s, i, store, user, guildID, targetUser)
 	case "list":
-		handleBuddyList(s, i, user, guildID)
+		handleBuddyList(s, i, store, user, guildID)
 	case "remove":
-		targetUser := options[0].Options[0].UserValue(s)
-		handleBuddyRemove(s, i, user, guildID, targetUser)
+		targetUser := optionUser(i, options[0].Options[0])
+		handleBuddyRemove(s, i, store, user, guildID, targetUser)
 	default:
 		respondWithError(s, i, "Unknown subcommand")
 	}
 }
 
-func handleBuddyRequest(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, targetUser *discordgo.User) {
+func handleBuddyRequest(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, targetUser *discordgo.User) {
 	if targetUser.ID == user.DiscordID {
 		respondWithError(s, i, "You can't be your own accountability buddy!")
 		return
@@ -164,14 +167,14 @@ func handleBuddyRequest(s *discordgo.Session, i *discordgo.InteractionCreate, us
 	}
 
 	// Get or create target user
-	target, err := database.GetOrCreateUser(targetUser.ID, guildID, targetUser.Username)
+	target, err := store.GetOrCreateUser(targetUser.ID, guildID, targetUser.Username)
 	if err != nil {
 		log.Printf("Error getting target user: %v", err)
 		respondWithError(s, i, "Failed to find that user.")
 		return
 	}
 
-	request, err := database.CreateBuddyRequest(user.ID, target.ID, guildID)
+	request, err := store.CreateBuddyRequest(user.ID, target.ID, guildID)
 	if err != nil {
 		respondWithError(s, i, err.Error())
 		return
@@ -214,16 +217,16 @@ func handleBuddyRequest(s *discordgo.Session, i *discordgo.InteractionCreate, us
 	}
 }
 
-func handleBuddyAccept(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, targetUser *discordgo.User) {
+func handleBuddyAccept(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, targetUser *discordgo.User) {
 	// Get requester
-	requester, err := database.GetOrCreateUser(targetUser.ID, guildID, targetUser.Username)
+	requester, err := store.GetOrCreateUser(targetUser.ID, guildID, targetUser.Username)
 	if err != nil {
 		log.Printf("Error getting requester: %v", err)
 		respondWithError(s, i, "Failed to find that user.")
 		return
 	}
 
-	_, err = database.AcceptBuddyRequest(requester.ID, user.ID, guildID)
+	_, err = store.AcceptBuddyRequest(requester.ID, user.ID, guildID)
 	if err != nil {
 		respondWithError(s, i, err.Error())
 		return
@@ -256,16 +259,16 @@ func handleBuddyAccept(s *discordgo.Session, i *discordgo.InteractionCreate, use
 	}
 }
 
-func handleBuddyDecline(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, targetUser *discordgo.User) {
+func handleBuddyDecline(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, targetUser *discordgo.User) {
 	// Get requester
-	requester, err := database.GetOrCreateUser(targetUser.ID, guildID, targetUser.Username)
+	requester, err := store.GetOrCreateUser(targetUser.ID, guildID, targetUser.Username)
 	if err != nil {
 		log.Printf("Error getting requester: %v", err)
 		respondWithError(s, i, "Failed to find that user.")
 		return
 	}
 
-	err = database.DeclineBuddyRequest(requester.ID, user.ID, guildID)
+	err = store.DeclineBuddyRequest(requester.ID, user.ID, guildID)
 	if err != nil {
 		respondWithError(s, i, err.Error())
 		return
@@ -280,24 +283,24 @@ func handleBuddyDecline(s *discordgo.Session, i *discordgo.InteractionCreate, us
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleBuddyStatus(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, targetUser *discordgo.User) {
+func handleBuddyStatus(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, targetUser *discordgo.User) {
 	if targetUser != nil {
 		// Check specific buddy's status
-		target, err := database.GetOrCreateUser(targetUser.ID, guildID, targetUser.Username)
+		target, err := store.GetOrCreateUser(targetUser.ID, guildID, targetUser.Username)
 		if err != nil {
 			log.Printf("Error getting target user: %v", err)
 			respondWithError(s, i, "Failed to find that user.")
 			return
 		}
 
-		isBuddy, err := database.AreBuddies(user.ID, target.ID, guildID)
+		isBuddy, err := store.AreBuddies(user.ID, target.ID, guildID)
 		if err != nil || !isBuddy {
 			respondWithError(s, i, "You're not buddies with this user.")
 			return
 		}
 
 		// Get their focus period
-		period, err := database.GetCurrentFocusPeriod(target.ID)
+		period, err := store.GetCurrentFocusPeriod(target.ID, guildID)
 		if err != nil {
 			log.Printf("Error getting focus period: %v", err)
 			respondWithError(s, i, "Failed to get buddy's status.")
@@ -321,6 +324,7 @@ func handleBuddyStatus(s *discordgo.Session, i *discordgo.InteractionCreate, use
 			progressPercent = (completedCount * 100) / totalCount
 		}
 		progressBar := buildProgressBar(progressPercent)
+		dayNumber := period.DayNumberIn(store.UserLocation(period.UserID, guildID))
 
 		embed := &discordgo.MessageEmbed{
 			Title: fmt.Sprintf("%s's Focus Period", targetUser.Username),
@@ -338,7 +342,7 @@ func handleBuddyStatus(s *discordgo.Session, i *discordgo.InteractionCreate, use
 				},
 				{
 					Name:   "Day",
-					Value:  fmt.Sprintf("Day %d of 14 (%d days left)", period.DayNumber(), period.DaysRemaining()),
+					Value:  fmt.Sprintf("Day %d of %d (%d days left)", dayNumber, period.LengthDays(), period.DaysRemaining()),
 					Inline: true,
 				},
 			},
@@ -349,7 +353,7 @@ func handleBuddyStatus(s *discordgo.Session, i *discordgo.InteractionCreate, use
 	}
 
 	// Show all buddies' status
-	buddies, err := database.GetUserBuddies(user.ID, guildID)
+	buddies, err := store.GetUserBuddies(user.ID, guildID)
 	if err != nil {
 		log.Printf("Error getting buddies: %v", err)
 		respondWithError(s, i, "Failed to get buddies.")
@@ -368,7 +372,7 @@ func handleBuddyStatus(s *discordgo.Session, i *discordgo.InteractionCreate, use
 
 	var description strings.Builder
 	for _, buddy := range buddies {
-		period, _ := database.GetCurrentFocusPeriod(buddy.ID)
+		period, _ := store.GetCurrentFocusPeriod(buddy.ID, guildID)
 		if period != nil {
 			completedCount := period.CompletedTaskCount()
 			totalCount := len(period.Tasks)
@@ -376,8 +380,9 @@ func handleBuddyStatus(s *discordgo.Session, i *discordgo.InteractionCreate, use
 			if totalCount > 0 {
 				progressPercent = (completedCount * 100) / totalCount
 			}
+			dayNumber := period.DayNumberIn(store.UserLocation(buddy.ID, guildID))
 			description.WriteString(fmt.Sprintf("**%s** - Day %d | %d%% (%d/%d tasks)\n",
-				buddy.Username, period.DayNumber(), progressPercent, completedCount, totalCount))
+				buddy.Username, dayNumber, progressPercent, completedCount, totalCount))
 		} else {
 			description.WriteString(fmt.Sprintf("**%s** - No active Focus Period\n", buddy.Username))
 		}
@@ -395,20 +400,20 @@ func handleBuddyStatus(s *discordgo.Session, i *discordgo.InteractionCreate, use
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleBuddyList(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string) {
-	buddies, err := database.GetUserBuddies(user.ID, guildID)
+func handleBuddyList(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string) {
+	buddies, err := store.GetUserBuddies(user.ID, guildID)
 	if err != nil {
 		log.Printf("Error getting buddies: %v", err)
 		respondWithError(s, i, "Failed to get your buddy list.")
 		return
 	}
 
-	pendingReceived, err := database.GetPendingBuddyRequests(user.ID, guildID)
+	pendingReceived, err := store.GetPendingBuddyRequests(user.ID, guildID)
 	if err != nil {
 		log.Printf("Error getting pending requests: %v", err)
 	}
 
-	pendingSent, err := database.GetSentBuddyRequests(user.ID, guildID)
+	pendingSent, err := store.GetSentBuddyRequests(user.ID, guildID)
 	if err != nil {
 		log.Printf("Error getting sent requests: %v", err)
 	}
@@ -474,15 +479,15 @@ func handleBuddyList(s *discordgo.Session, i *discordgo.InteractionCreate, user
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleBuddyRemove(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, targetUser *discordgo.User) {
-	target, err := database.GetOrCreateUser(targetUser.ID, guildID, targetUser.Username)
+func handleBuddyRemove(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, targetUser *discordgo.User) {
+	target, err := store.GetOrCreateUser(targetUser.ID, guildID, targetUser.Username)
 	if err != nil {
 		log.Printf("Error getting target user: %v", err)
 		respondWithError(s, i, "Failed to find that user.")
 		return
 	}
 
-	err = database.RemoveBuddy(user.ID, target.ID, guildID)
+	err = store.RemoveBuddy(user.ID, target.ID, guildID)
 	if err != nil {
 		respondWithError(s, i, err.Error())
 		return
@@ -498,8 +503,8 @@ func handleBuddyRemove(s *discordgo.Session, i *discordgo.InteractionCreate, use
 }
 
 // NotifyBuddiesOfCompletion sends DMs to buddies when a user completes a task
-func NotifyBuddiesOfCompletion(s *discordgo.Session, user *database.User, guildID string, task *database.Task) {
-	buddies, err := database.GetBuddiesWithNotifications(user.ID, guildID)
+func NotifyBuddiesOfCompletion(s discord.Session, store *database.Store, user *database.User, guildID string, task *database.Task) {
+	buddies, err := store.GetBuddiesWithNotifications(user.ID, guildID)
 	if err != nil {
 		log.Printf("Error getting buddies for notification: %v", err)
 		return
@@ -7,11 +7,12 @@ import (
 	"time"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
 	"github.com/bwmarrin/discordgo"
 )
 
 // challengeCommand creates the /challenge command group
-func challengeCommand() *Command {
+func challengeCommand(store *database.Store) *Command {
 	return &Command{
 		Definition: &discordgo.ApplicationCommand{
 			Name:        "challenge",
@@ -164,11 +165,13 @@ func challengeCommand() *Command {
 				},
 			},
 		},
-		Handler: handleChallengeCommand,
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleChallengeCommand(s, i, store)
+		},
 	}
 }
 
-func handleChallengeCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func handleChallengeCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
 	options := i.ApplicationCommandData().Options
 	if len(options) == 0 {
 		respondWithError(s, i, "Invalid command usage")
@@ -195,7 +198,7 @@ func handleChallengeCommand(s *discordgo.Session, i *discordgo.InteractionCreate
 	}
 
 	// Get or create user in database
-	user, err := database.GetOrCreateUser(userID, guildID, username)
+	user, err := store.GetOrCreateUser(userID, guildID, username)
 	if err != nil {
 		log.Printf("Error getting/creating user: %v", err)
 		respondWithError(s, i, "Failed to process your request. Please try again.")
@@ -204,28 +207,28 @@ func handleChallengeCommand(s *discordgo.Session, i *discordgo.InteractionCreate
 
 	switch subCommand {
 	case "create":
-		handleChallengeCreate(s, i, user, guildID, options[0].Options)
+		handleChallengeCreate(s, i, store, user, guildID, options[0].Options)
 	case "progress":
-		handleChallengeProgress(s, i, user, options[0].Options)
+		handleChallengeProgress(s, i, store, user, options[0].Options)
 	case "complete":
-		handleChallengeComplete(s, i, user, options[0].Options)
+		handleChallengeComplete(s, i, store, user, options[0].Options)
 	case "validate":
-		handleChallengeValidate(s, i, user, guildID, options[0].Options)
+		handleChallengeValidate(s, i, store, user, guildID, options[0].Options)
 	case "list":
 		status := ""
 		if len(options[0].Options) > 0 {
 			status = options[0].Options[0].StringValue()
 		}
-		handleChallengeList(s, i, user, guildID, status)
+		handleChallengeList(s, i, store, user, guildID, status)
 	case "view":
 		challengeID := uint(options[0].Options[0].IntValue())
-		handleChallengeView(s, i, user, challengeID)
+		handleChallengeView(s, i, store, user, challengeID)
 	default:
 		respondWithError(s, i, "Unknown subcommand")
 	}
 }
 
-func handleChallengeCreate(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleChallengeCreate(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	var goal string
 	var days int
 	var multiplier float64 = 1.5
@@ -240,7 +243,7 @@ func handleChallengeCreate(s *discordgo.Session, i *discordgo.InteractionCreate,
 		case "multiplier":
 			multiplier = opt.FloatValue()
 		case "buddy1", "buddy2", "buddy3":
-			buddyUser := opt.UserValue(s)
+			buddyUser := optionUser(i, opt)
 			if buddyUser != nil && buddyUser.ID != user.DiscordID && !buddyUser.Bot {
 				buddyUsers = append(buddyUsers, buddyUser)
 			}
@@ -255,12 +258,12 @@ func handleChallengeCreate(s *discordgo.Session, i *discordgo.InteractionCreate,
 	// Verify all buddies are actually buddies
 	participantIDs := make([]uint, 0, len(buddyUsers))
 	for _, buddyUser := range buddyUsers {
-		buddy, err := database.GetOrCreateUser(buddyUser.ID, guildID, buddyUser.Username)
+		buddy, err := store.GetOrCreateUser(buddyUser.ID, guildID, buddyUser.Username)
 		if err != nil {
 			continue
 		}
 
-		isBuddy, _ := database.AreBuddies(user.ID, buddy.ID, guildID)
+		isBuddy, _ := store.AreBuddies(user.ID, buddy.ID, guildID)
 		if !isBuddy {
 			respondWithError(s, i, fmt.Sprintf("**%s** is not your buddy. Add them as a buddy first with `/buddy request`.", buddyUser.Username))
 			return
@@ -268,7 +271,7 @@ func handleChallengeCreate(s *discordgo.Session, i *discordgo.InteractionCreate,
 		participantIDs = append(participantIDs, buddy.ID)
 	}
 
-	challenge, err := database.CreateChallenge(user.ID, guildID, goal, "", days, participantIDs, multiplier)
+	challenge, err := store.CreateChallenge(user.ID, guildID, goal, "", days, participantIDs, multiplier)
 	if err != nil {
 		log.Printf("Error creating challenge: %v", err)
 		respondWithError(s, i, "Failed to create challenge.")
@@ -348,7 +351,7 @@ func handleChallengeCreate(s *discordgo.Session, i *discordgo.InteractionCreate,
 	}
 }
 
-func handleChallengeProgress(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleChallengeProgress(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	var challengeID uint
 	var update string
 
@@ -361,7 +364,7 @@ func handleChallengeProgress(s *discordgo.Session, i *discordgo.InteractionCreat
 		}
 	}
 
-	progress, err := database.AddChallengeProgress(challengeID, user.ID, update)
+	progress, err := store.AddChallengeProgress(challengeID, user.ID, update)
 	if err != nil {
 		respondWithError(s, i, err.Error())
 		return
@@ -379,7 +382,7 @@ func handleChallengeProgress(s *discordgo.Session, i *discordgo.InteractionCreat
 	respondWithEmbed(s, i, embed)
 }
 
-func handleChallengeComplete(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleChallengeComplete(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	var challengeID uint
 	var proofURL string
 
@@ -392,7 +395,7 @@ func handleChallengeComplete(s *discordgo.Session, i *discordgo.InteractionCreat
 		}
 	}
 
-	_, err := database.SubmitChallengeCompletion(challengeID, user.ID, proofURL)
+	_, err := store.SubmitChallengeCompletion(challengeID, user.ID, proofURL)
 	if err != nil {
 		respondWithError(s, i, err.Error())
 		return
@@ -414,7 +417,7 @@ func handleChallengeComplete(s *discordgo.Session, i *discordgo.InteractionCreat
 	respondWithEmbed(s, i, embed)
 
 	// Notify other participants
-	_, participants, _ := database.GetChallengeWithParticipants(challengeID)
+	_, participants, _ := store.GetChallengeWithParticipants(challengeID)
 	for _, p := range participants {
 		if p.UserID == user.ID {
 			continue
@@ -440,7 +443,7 @@ func handleChallengeComplete(s *discordgo.Session, i *discordgo.InteractionCreat
 	}
 }
 
-func handleChallengeValidate(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleChallengeValidate(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	var challengeID uint
 	var targetUser *discordgo.User
 	var approve bool
@@ -450,19 +453,19 @@ func handleChallengeValidate(s *discordgo.Session, i *discordgo.InteractionCreat
 		case "id":
 			challengeID = uint(opt.IntValue())
 		case "user":
-			targetUser = opt.UserValue(s)
+			targetUser = optionUser(i, opt)
 		case "approve":
 			approve = opt.BoolValue()
 		}
 	}
 
-	target, err := database.GetOrCreateUser(targetUser.ID, guildID, targetUser.Username)
+	target, err := store.GetOrCreateUser(targetUser.ID, guildID, targetUser.Username)
 	if err != nil {
 		respondWithError(s, i, "Failed to find that user.")
 		return
 	}
 
-	err = database.ValidateChallengeCompletion(challengeID, user.ID, target.ID, approve)
+	err = store.ValidateChallengeCompletion(challengeID, user.ID, target.ID, approve)
 	if err != nil {
 		respondWithError(s, i, err.Error())
 		return
@@ -506,8 +509,8 @@ func handleChallengeValidate(s *discordgo.Session, i *discordgo.InteractionCreat
 	}
 }
 
-func handleChallengeList(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, status string) {
-	challenges, err := database.GetUserChallenges(user.ID, guildID, status)
+func handleChallengeList(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, status string) {
+	challenges, err := store.GetUserChallenges(user.ID, guildID, status)
 	if err != nil {
 		log.Printf("Error getting challenges: %v", err)
 		respondWithError(s, i, "Failed to get your challenges.")
@@ -560,15 +563,15 @@ func handleChallengeList(s *discordgo.Session, i *discordgo.InteractionCreate, u
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleChallengeView(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, challengeID uint) {
-	challenge, participants, err := database.GetChallengeWithParticipants(challengeID)
+func handleChallengeView(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, challengeID uint) {
+	challenge, participants, err := store.GetChallengeWithParticipants(challengeID)
 	if err != nil {
 		respondWithError(s, i, err.Error())
 		return
 	}
 
 	// Verify user is a participant
-	isParticipant, _ := database.IsUserInChallenge(challengeID, user.ID)
+	isParticipant, _ := store.IsUserInChallenge(challengeID, user.ID)
 	if !isParticipant {
 		respondWithError(s, i, "You're not a participant in this challenge.")
 		return
@@ -620,7 +623,7 @@ func handleChallengeView(s *discordgo.Session, i *discordgo.InteractionCreate, u
 	}
 
 	// Get recent progress
-	progress, _ := database.GetChallengeProgress(challengeID)
+	progress, _ := store.GetChallengeProgress(challengeID)
 	if len(progress) > 0 {
 		var progressList strings.Builder
 		for idx, p := range progress {
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord/discordtest"
+	"github.com/bwmarrin/discordgo"
+)
+
+// makeBuddies pairs two users through the /buddy request and accept flow
+func makeBuddies(t *testing.T, h *testHarness, a, b *discordgo.User) {
+	t.Helper()
+	h.run(a, nil, "buddy", discordtest.SubCommand("request", discordtest.User("user", b)))
+	resp := h.run(b, nil, "buddy", discordtest.SubCommand("accept", discordtest.User("user", a)))
+	if embed := responseEmbed(t, resp); embed.Title == "Error" {
+		t.Fatalf("Failed to pair buddies: %s", embed.Description)
+	}
+}
+
+func TestChallengeCreateRequiresBuddy(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+	bob := newTestUser("user-bob", "bob")
+
+	resp := h.run(alice, nil, "challenge", discordtest.SubCommand("create",
+		discordtest.String("goal", "Launch on Product Hunt"),
+		discordtest.Int("days", 7),
+		discordtest.User("buddy1", bob),
+	))
+	assertError(t, resp, "**bob** is not your buddy")
+}
+
+func TestChallengeLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+	bob := newTestUser("user-bob", "bob")
+	makeBuddies(t, h, alice, bob)
+
+	resp := h.run(alice, nil, "challenge", discordtest.SubCommand("create",
+		discordtest.String("goal", "Launch on Product Hunt"),
+		discordtest.Int("days", 7),
+		discordtest.User("buddy1", bob),
+	))
+	embed := assertTitle(t, resp, "Challenge #1 Created!")
+	if !strings.Contains(embed.Fields[2].Value, "**bob**") {
+		t.Errorf("Expected bob in participants, got %q", embed.Fields[2].Value)
+	}
+
+	// The buddy is invited by DM
+	invites := h.session.DirectMessages(bob.ID)
+	if len(invites) == 0 || invites[len(invites)-1].Embed.Title != "New Challenge!" {
+		t.Fatalf("Expected a challenge DM for bob, got %+v", invites)
+	}
+
+	resp = h.run(alice, nil, "challenge", discordtest.SubCommand("progress",
+		discordtest.Int("id", 1),
+		discordtest.String("update", "Drafted the launch post"),
+	))
+	assertTitle(t, resp, "Progress Logged - Challenge #1")
+
+	resp = h.run(alice, nil, "challenge", discordtest.SubCommand("complete",
+		discordtest.Int("id", 1),
+		discordtest.String("proof-url", "https://example.com/launch"),
+	))
+	assertTitle(t, resp, "Completion Submitted!")
+
+	// Other participants are asked to validate
+	dms := h.session.DirectMessages(bob.ID)
+	if dms[len(dms)-1].Embed.Title != "Buddy Submitted Challenge Completion!" {
+		t.Errorf("Expected a validation request DM for bob, got %q", dms[len(dms)-1].Embed.Title)
+	}
+}
@@ -5,38 +5,45 @@ import (
 	"log"
 	"time"
 
-	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/openai"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
 	"github.com/bwmarrin/discordgo"
 )
 
 // Command represents a slash command with its definition and handler
 type Command struct {
 	Definition *discordgo.ApplicationCommand
-	Handler    func(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Handler    func(s discord.Session, i *discordgo.InteractionCreate)
 }
 
-// GetAllCommands returns all available bot commands
-func GetAllCommands(openaiClient *openai.Client) []*Command {
+// GetAllCommands returns all available bot commands backed by the given store
+func GetAllCommands(store *database.Store, pointsEstimator estimator.Estimator) []*Command {
 	return []*Command{
 		pingCommand(),
 		helpCommand(),
-		focusCommand(openaiClient),
-		resourceCommand(),
-		leaderboardCommand(),
-		configCommand(),
+		focusCommand(store, pointsEstimator),
+		resourceCommand(store),
+		leaderboardCommand(store),
+		pointsCommand(store),
+		configCommand(store),
+		adminCommand(store),
 		// New features
-		standupCommand(),
-		winCommand(),
-		buddyCommand(),
-		challengeCommand(),
-		mrrCommand(),
-		projectCommand(),
+		standupCommand(store),
+		blockerCommand(store),
+		winCommand(store),
+		buddyCommand(store),
+		challengeCommand(store),
+		mrrCommand(store),
+		projectCommand(store),
+		settingsCommand(store),
+		notificationsCommand(store),
 	}
 }
 
 // GetCommandDefinitions returns just the command definitions for registration
 func GetCommandDefinitions() []*discordgo.ApplicationCommand {
-	commands := GetAllCommands(nil)
+	commands := GetAllCommands(nil, nil)
 	definitions := make([]*discordgo.ApplicationCommand, len(commands))
 	for i, cmd := range commands {
 		definitions[i] = cmd.Definition
@@ -45,9 +52,9 @@ func GetCommandDefinitions() []*discordgo.ApplicationCommand {
 }
 
 // GetHandlers returns a map of command names to their handlers
-func GetHandlers(openaiClient *openai.Client) map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	commands := GetAllCommands(openaiClient)
-	handlers := make(map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate))
+func GetHandlers(store *database.Store, pointsEstimator estimator.Estimator) map[string]func(s discord.Session, i *discordgo.InteractionCreate) {
+	commands := GetAllCommands(store, pointsEstimator)
+	handlers := make(map[string]func(s discord.Session, i *discordgo.InteractionCreate))
 	for _, cmd := range commands {
 		handlers[cmd.Definition.Name] = cmd.Handler
 	}
@@ -61,7 +68,7 @@ func pingCommand() *Command {
 			Name:        "ping",
 			Description: "Test if the Bootstrap Hub Bot is responsive - responds with Pong!",
 		},
-		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
 			start := time.Now()
 
 			err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -78,3 +85,41 @@ func pingCommand() *Command {
 }
 
 // helpCommand is defined in help.go
+
+// optionUser returns the user selected in a user option.
+// Discord sends the full user in the interaction's resolved data.
+func optionUser(i *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) *discordgo.User {
+	userID, _ := opt.Value.(string)
+	if resolved := i.ApplicationCommandData().Resolved; resolved != nil {
+		if user, ok := resolved.Users[userID]; ok {
+			return user
+		}
+	}
+	return &discordgo.User{ID: userID}
+}
+
+// optionRole returns the role selected in a role option
+func optionRole(i *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) *discordgo.Role {
+	roleID, _ := opt.Value.(string)
+	if resolved := i.ApplicationCommandData().Resolved; resolved != nil {
+		if role, ok := resolved.Roles[roleID]; ok {
+			return role
+		}
+	}
+	return &discordgo.Role{ID: roleID}
+}
+
+// optionChannel returns the channel selected in a channel option.
+// Resolved channels are partial, so the full channel is fetched when possible.
+func optionChannel(s discord.Session, i *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) *discordgo.Channel {
+	channelID, _ := opt.Value.(string)
+	if channel, err := s.Channel(channelID); err == nil {
+		return channel
+	}
+	if resolved := i.ApplicationCommandData().Resolved; resolved != nil {
+		if channel, ok := resolved.Channels[channelID]; ok {
+			return channel
+		}
+	}
+	return &discordgo.Channel{ID: channelID}
+}
@@ -1,25 +1,29 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
-	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/openai"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
 	"github.com/bwmarrin/discordgo"
 )
 
 // focusCommand creates the /focus command group
-func focusCommand(openaiClient *openai.Client) *Command {
+func focusCommand(store *database.Store, pointsEstimator estimator.Estimator) *Command {
 	return &Command{
 		Definition: &discordgo.ApplicationCommand{
 			Name:        "focus",
-			Description: "Manage your 2-week Focus Period goals",
+			Description: "Manage your Focus Period goals",
 			Options: []*discordgo.ApplicationCommandOption{
 				{
 					Name:        "start",
-					Description: "Start a new 2-week Focus Period",
+					Description: "Start a new Focus Period",
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 				},
 				{
@@ -33,6 +37,18 @@ func focusCommand(openaiClient *openai.Client) *Command {
 							Type:        discordgo.ApplicationCommandOptionString,
 							Required:    true,
 						},
+						{
+							Name:        "description",
+							Description: "More detail on what the goal involves",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Required:    false,
+						},
+						{
+							Name:        "due",
+							Description: "When the goal is due, as YYYY-MM-DD",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Required:    false,
+						},
 					},
 				},
 				{
@@ -50,6 +66,207 @@ func focusCommand(openaiClient *openai.Client) *Command {
 						},
 					},
 				},
+				{
+					Name:        "edit",
+					Description: "Change the wording of a goal",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "number",
+							Description: "The goal number to edit",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+							MinValue:    floatPtr(1),
+							MaxValue:    100,
+						},
+						{
+							Name:        "goal",
+							Description: "The new wording for the goal",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Required:    true,
+						},
+					},
+				},
+				{
+					Name:        "remove",
+					Description: "Remove a goal from your current Focus Period",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "number",
+							Description: "The goal number to remove",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+							MinValue:    floatPtr(1),
+							MaxValue:    100,
+						},
+					},
+				},
+				{
+					Name:        "reopen",
+					Description: "Mark a completed goal as not done",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "number",
+							Description: "The goal number to reopen",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+							MinValue:    floatPtr(1),
+							MaxValue:    100,
+						},
+					},
+				},
+				{
+					Name:        "move",
+					Description: "Move a goal to a different place in your list",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "number",
+							Description: "The goal number to move",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+							MinValue:    floatPtr(1),
+							MaxValue:    100,
+						},
+						{
+							Name:        "position",
+							Description: "Where the goal should go (1 is the top)",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+							MinValue:    floatPtr(1),
+							MaxValue:    100,
+						},
+					},
+				},
+				{
+					Name:        "step",
+					Description: "Break a goal into a checklist of steps",
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "add",
+							Description: "Add a step to a goal",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "number",
+									Description: "The goal number the step belongs to",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    true,
+									MinValue:    floatPtr(1),
+									MaxValue:    100,
+								},
+								{
+									Name:        "step",
+									Description: "What the step is",
+									Type:        discordgo.ApplicationCommandOptionString,
+									Required:    true,
+								},
+							},
+						},
+						{
+							Name:        "check",
+							Description: "Check off a step",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "number",
+									Description: "The goal number the step belongs to",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    true,
+									MinValue:    floatPtr(1),
+									MaxValue:    100,
+								},
+								{
+									Name:        "step",
+									Description: "The step number to check off",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    true,
+									MinValue:    floatPtr(1),
+									MaxValue:    database.MaxStepsPerTask,
+								},
+							},
+						},
+						{
+							Name:        "uncheck",
+							Description: "Mark a checked step as not done",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "number",
+									Description: "The goal number the step belongs to",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    true,
+									MinValue:    floatPtr(1),
+									MaxValue:    100,
+								},
+								{
+									Name:        "step",
+									Description: "The step number to uncheck",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    true,
+									MinValue:    floatPtr(1),
+									MaxValue:    database.MaxStepsPerTask,
+								},
+							},
+						},
+						{
+							Name:        "remove",
+							Description: "Remove a step from a goal",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Name:        "number",
+									Description: "The goal number the step belongs to",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    true,
+									MinValue:    floatPtr(1),
+									MaxValue:    100,
+								},
+								{
+									Name:        "step",
+									Description: "The step number to remove",
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Required:    true,
+									MinValue:    floatPtr(1),
+									MaxValue:    database.MaxStepsPerTask,
+								},
+							},
+						},
+					},
+				},
+				{
+					Name:        "appeal",
+					Description: "Ask a buddy or admin to re-score a goal's points",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "number",
+							Description: "The goal number to re-score",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+							MinValue:    floatPtr(1),
+							MaxValue:    100,
+						},
+						{
+							Name:        "points",
+							Description: "The points you think the goal is worth",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+							MinValue:    floatPtr(estimator.MinPoints),
+							MaxValue:    estimator.MaxPoints,
+						},
+						{
+							Name:        "reason",
+							Description: "Why the estimate is off",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Required:    true,
+							MaxLength:   300,
+						},
+					},
+				},
 				{
 					Name:        "list",
 					Description: "View your current Focus Period goals",
@@ -62,8 +279,8 @@ func focusCommand(openaiClient *openai.Client) *Command {
 				},
 			},
 		},
-		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-			handleFocusCommand(s, i, openaiClient)
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleFocusCommand(s, i, store, pointsEstimator)
 		},
 	}
 }
@@ -72,7 +289,7 @@ func floatPtr(f float64) *float64 {
 	return &f
 }
 
-func handleFocusCommand(s *discordgo.Session, i *discordgo.InteractionCreate, openaiClient *openai.Client) {
+func handleFocusCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, pointsEstimator estimator.Estimator) {
 	options := i.ApplicationCommandData().Options
 	if len(options) == 0 {
 		respondWithError(s, i, "Invalid command usage")
@@ -94,7 +311,7 @@ func handleFocusCommand(s *discordgo.Session, i *discordgo.InteractionCreate, op
 	}
 
 	// Get or create user in database
-	user, err := database.GetOrCreateUser(userID, guildID, username)
+	user, err := store.GetOrCreateUser(userID, guildID, username)
 	if err != nil {
 		log.Printf("Error getting/creating user: %v", err)
 		respondWithError(s, i, "Failed to process your request. Please try again.")
@@ -103,25 +320,80 @@ func handleFocusCommand(s *discordgo.Session, i *discordgo.InteractionCreate, op
 
 	switch subCommand {
 	case "start":
-		handleFocusStart(s, i, user, guildID)
+		handleFocusStart(s, i, store, user, guildID)
 	case "add":
-		goalText := options[0].Options[0].StringValue()
-		handleFocusAdd(s, i, user, goalText, openaiClient)
+		var goalText, description, due string
+		for _, opt := range options[0].Options {
+			switch opt.Name {
+			case "goal":
+				goalText = opt.StringValue()
+			case "description":
+				description = strings.TrimSpace(opt.StringValue())
+			case "due":
+				due = strings.TrimSpace(opt.StringValue())
+			}
+		}
+		handleFocusAdd(s, i, store, user, guildID, goalText, description, due, pointsEstimator)
 	case "complete":
 		goalNum := int(options[0].Options[0].IntValue())
-		handleFocusComplete(s, i, user, goalNum)
+		handleFocusComplete(s, i, store, user, guildID, goalNum)
+	case "edit":
+		var goalNum int
+		var goalText string
+		for _, opt := range options[0].Options {
+			switch opt.Name {
+			case "number":
+				goalNum = int(opt.IntValue())
+			case "goal":
+				goalText = opt.StringValue()
+			}
+		}
+		handleFocusEdit(s, i, store, user, guildID, goalNum, goalText, pointsEstimator)
+	case "remove":
+		goalNum := int(options[0].Options[0].IntValue())
+		handleFocusRemove(s, i, store, user, guildID, goalNum)
+	case "reopen":
+		goalNum := int(options[0].Options[0].IntValue())
+		handleFocusReopen(s, i, store, user, guildID, goalNum)
+	case "move":
+		var goalNum, position int
+		for _, opt := range options[0].Options {
+			switch opt.Name {
+			case "number":
+				goalNum = int(opt.IntValue())
+			case "position":
+				position = int(opt.IntValue())
+			}
+		}
+		handleFocusMove(s, i, store, user, guildID, goalNum, position)
+	case "step":
+		handleFocusStep(s, i, store, user, guildID, options[0].Options)
+	case "appeal":
+		var goalNum, points int
+		var reason string
+		for _, opt := range options[0].Options {
+			switch opt.Name {
+			case "number":
+				goalNum = int(opt.IntValue())
+			case "points":
+				points = int(opt.IntValue())
+			case "reason":
+				reason = strings.TrimSpace(opt.StringValue())
+			}
+		}
+		handleFocusAppeal(s, i, store, user, guildID, goalNum, points, reason)
 	case "list":
-		handleFocusList(s, i, user)
+		handleFocusList(s, i, store, user, guildID)
 	case "status":
-		handleFocusStatus(s, i, user)
+		handleFocusStatus(s, i, store, user, guildID)
 	default:
 		respondWithError(s, i, "Unknown subcommand")
 	}
 }
 
-func handleFocusStart(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string) {
+func handleFocusStart(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string) {
 	// Check if user already has an active focus period
-	existing, err := database.GetCurrentFocusPeriod(user.ID)
+	existing, err := store.GetCurrentFocusPeriod(user.ID, guildID)
 	if err != nil {
 		log.Printf("Error checking existing focus period: %v", err)
 		respondWithError(s, i, "Failed to check your current Focus Period.")
@@ -139,16 +411,37 @@ func handleFocusStart(s *discordgo.Session, i *discordgo.InteractionCreate, user
 	}
 
 	// Create new focus period
-	period, err := database.CreateFocusPeriod(user.ID, guildID)
+	period, err := store.CreateFocusPeriod(user.ID, guildID)
+	if errors.Is(err, database.ErrNoCohortSprint) {
+		description := "This server runs Focus Periods as cohort sprints, and no sprint is running right now."
+		if next, nextErr := store.GetNextCohortSprint(guildID, time.Now()); nextErr == nil && next != nil {
+			description += fmt.Sprintf("\n\nThe next sprint, **%s**, starts %s. Run `/focus start` again then to join it!",
+				next.Name, next.StartDate.In(store.GuildLocation(guildID)).Format("Jan 2"))
+		}
+		embed := &discordgo.MessageEmbed{
+			Title:       "No Sprint Running",
+			Description: description,
+			Color:       0xFFA500, // Orange
+		}
+		respondWithEmbedEphemeral(s, i, embed, true)
+		return
+	}
 	if err != nil {
 		log.Printf("Error creating focus period: %v", err)
 		respondWithError(s, i, "Failed to create your Focus Period.")
 		return
 	}
 
+	description := fmt.Sprintf("Your %d-day Focus Period has begun. Time to set your goals and crush them!", period.LengthDays())
+	if period.SprintID != nil {
+		if sprint, err := store.GetCurrentCohortSprint(guildID, time.Now()); err == nil && sprint != nil {
+			description = fmt.Sprintf("You've joined **%s** with the rest of the server. Time to set your goals and crush them!", sprint.Name)
+		}
+	}
+
 	embed := &discordgo.MessageEmbed{
 		Title:       "New Focus Period Started!",
-		Description: "Your 2-week Focus Period has begun. Time to set your goals and crush them!",
+		Description: description,
 		Color:       0x00FF00, // Green
 		Fields: []*discordgo.MessageEmbedField{
 			{
@@ -171,12 +464,158 @@ func handleFocusStart(s *discordgo.Session, i *discordgo.InteractionCreate, user
 			Text: "Bootstrap Hub Bot - Focus on what matters",
 		},
 	}
-	respondWithEmbedEphemeral(s, i, embed, true)
+
+	// Offer to bring unfinished goals over from the last period
+	previous, err := store.GetPreviousFocusPeriod(user.ID, guildID)
+	if err != nil {
+		log.Printf("Error fetching previous focus period: %v", err)
+	}
+	if previous == nil || previous.PendingTaskCount() == 0 {
+		respondWithEmbedEphemeral(s, i, embed, true)
+		return
+	}
+
+	var unfinished strings.Builder
+	for _, task := range previous.Tasks {
+		if !task.Completed {
+			unfinished.WriteString(fmt.Sprintf("• %s\n", task.Title))
+		}
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "Unfinished Goals",
+		Value:  fmt.Sprintf("Your last Focus Period ended with these goals open:\n%s", unfinished.String()),
+		Inline: false,
+	})
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.Button{
+							Label:    fmt.Sprintf("Carry over %d goal(s)", previous.PendingTaskCount()),
+							Style:    discordgo.PrimaryButton,
+							CustomID: fmt.Sprintf("%s%d:%d", focusCarryOverPrefix, previous.ID, period.ID),
+						},
+						discordgo.Button{
+							Label:    "Start fresh",
+							Style:    discordgo.SecondaryButton,
+							CustomID: focusCarryOverSkipID,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding with carry-over prompt: %v", err)
+	}
+}
+
+// Custom IDs for the carry-over buttons on /focus start and the buttons on /focus appeal
+const (
+	focusCarryOverPrefix     = "focus_carryover:"
+	focusCarryOverSkipID     = "focus_carryover_skip"
+	focusAppealApprovePrefix = "focus_appeal_approve:"
+	focusAppealRejectPrefix  = "focus_appeal_reject:"
+)
+
+// IsFocusComponent reports whether a button belongs to a /focus prompt
+func IsFocusComponent(customID string) bool {
+	return customID == focusCarryOverSkipID ||
+		strings.HasPrefix(customID, focusCarryOverPrefix) ||
+		strings.HasPrefix(customID, focusAppealApprovePrefix) ||
+		strings.HasPrefix(customID, focusAppealRejectPrefix)
+}
+
+// HandleFocusComponent handles the carry-over buttons shown by /focus start and the appeal buttons shown by /focus appeal
+func HandleFocusComponent(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
+	customID := i.MessageComponentData().CustomID
+	if strings.HasPrefix(customID, focusAppealApprovePrefix) || strings.HasPrefix(customID, focusAppealRejectPrefix) {
+		handleFocusAppealDecision(s, i, store, customID)
+		return
+	}
+
+	if customID == focusCarryOverSkipID {
+		updateFocusPrompt(s, i, &discordgo.MessageEmbed{
+			Title:       "Fresh Start",
+			Description: "Your unfinished goals stay with your last Focus Period.\n\nUse `/focus add <goal>` to set new goals!",
+			Color:       0x00FF00, // Green
+		})
+		return
+	}
+
+	var fromID, toID uint
+	if _, err := fmt.Sscanf(strings.TrimPrefix(customID, focusCarryOverPrefix), "%d:%d", &fromID, &toID); err != nil {
+		log.Printf("Invalid carry-over button %q: %v", customID, err)
+		return
+	}
+
+	if i.Member == nil {
+		return
+	}
+	user, err := store.GetOrCreateUser(i.Member.User.ID, i.GuildID, i.Member.User.Username)
+	if err != nil {
+		log.Printf("Error getting/creating user: %v", err)
+		respondWithError(s, i, "Failed to process your request. Please try again.")
+		return
+	}
+
+	// Only carry goals into the period the prompt was shown for
+	period, err := store.GetCurrentFocusPeriod(user.ID, i.GuildID)
+	if err != nil || period == nil || period.ID != toID {
+		respondWithError(s, i, "This Focus Period is no longer active.")
+		return
+	}
+	previous, err := store.GetPreviousFocusPeriod(user.ID, i.GuildID)
+	if err != nil || previous == nil || previous.ID != fromID {
+		respondWithError(s, i, "Those goals can no longer be carried over.")
+		return
+	}
+
+	carried, err := store.CarryOverTasks(fromID, toID)
+	if err != nil {
+		log.Printf("Error carrying over goals: %v", err)
+		respondWithError(s, i, "Failed to carry over your goals.")
+		return
+	}
+
+	tasks, _ := store.GetTasksByFocusPeriod(toID)
+	var goals strings.Builder
+	for _, task := range tasks {
+		goals.WriteString(fmt.Sprintf("**#%d:** %s\n", task.Position, task.Title))
+	}
+
+	updateFocusPrompt(s, i, &discordgo.MessageEmbed{
+		Title:       "Goals Carried Over",
+		Description: fmt.Sprintf("Carried over %d goal(s) into your new Focus Period:\n\n%s", len(carried), goals.String()),
+		Color:       0x00FF00, // Green
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Use /focus edit or /focus remove to adjust them",
+		},
+	})
+}
+
+// updateFocusPrompt replaces a /focus prompt with a result, removing its buttons
+func updateFocusPrompt(s discord.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Printf("Error updating focus prompt: %v", err)
+	}
 }
 
-func handleFocusAdd(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, goal string, openaiClient *openai.Client) {
+func handleFocusAdd(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID, goal, description, due string, pointsEstimator estimator.Estimator) {
 	// Get current focus period
-	period, err := database.GetCurrentFocusPeriod(user.ID)
+	period, err := store.GetCurrentFocusPeriod(user.ID, guildID)
 	if err != nil {
 		log.Printf("Error getting focus period: %v", err)
 		respondWithError(s, i, "Failed to get your Focus Period.")
@@ -186,39 +625,74 @@ func handleFocusAdd(s *discordgo.Session, i *discordgo.InteractionCreate, user *
 	if period == nil {
 		embed := &discordgo.MessageEmbed{
 			Title:       "No Active Focus Period",
-			Description: "You don't have an active Focus Period.\n\nUse `/focus start` to begin a new 2-week Focus Period!",
+			Description: "You don't have an active Focus Period.\n\nUse `/focus start` to begin a new Focus Period!",
 			Color:       0xFFA500, // Orange
 		}
 		respondWithEmbedEphemeral(s, i, embed, true)
 		return
 	}
 
-	// Calculate points using OpenAI
-	points := 5 // Default
-	if openaiClient != nil {
-		calculatedPoints, err := openaiClient.CalculatePoints(goal, "")
+	// Due dates are whole days in the member's timezone and must fall within the period
+	var dueDate *time.Time
+	if due != "" {
+		loc := store.UserLocation(user.ID, guildID)
+		day, err := time.ParseInLocation("2006-01-02", due, loc)
+		if err != nil {
+			respondWithError(s, i, fmt.Sprintf("Invalid due date `%s`. Use the format YYYY-MM-DD, e.g. `2026-03-02`.", due))
+			return
+		}
+		if day.Before(database.StartOfDay(time.Now().In(loc))) {
+			respondWithError(s, i, "The due date can't be in the past.")
+			return
+		}
+		if !day.Before(period.EndDate) {
+			respondWithError(s, i, fmt.Sprintf("The due date must fall within your Focus Period, which ends %s.",
+				period.EndDate.In(loc).AddDate(0, 0, -1).Format("Jan 2")))
+			return
+		}
+		local := day.Local()
+		dueDate = &local
+	}
+
+	// Estimating can outlast Discord's response deadline, so acknowledge first
+	if !deferEphemeral(s, i) {
+		return
+	}
+
+	// Estimate points for the goal
+	estimate := estimator.Estimate{Points: estimator.DefaultPoints}
+	if pointsEstimator != nil {
+		calculated, err := pointsEstimator.Estimate(estimator.WithGuild(context.Background(), guildID), goal, description)
 		if err != nil {
 			log.Printf("Error calculating points: %v, using default", err)
 		} else {
-			points = calculatedPoints
+			estimate = calculated
 		}
 	}
 
 	// Add the task with calculated points
-	task, err := database.AddTask(period.ID, goal, "", points)
+	task, err := store.AddTask(period.ID, goal, description, estimate, dueDate)
 	if err != nil {
 		log.Printf("Error adding task: %v", err)
-		respondWithError(s, i, "Failed to add your goal.")
+		editDeferredError(s, i, "Failed to add your goal.")
 		return
 	}
 
 	// Reload tasks to get count
-	tasks, _ := database.GetTasksByFocusPeriod(period.ID)
+	tasks, _ := store.GetTasksByFocusPeriod(period.ID)
 	taskCount := len(tasks)
 
+	details := fmt.Sprintf("**#%d:** %s\n**Points:** %d/10", task.Position, goal, task.Points)
+	if description != "" {
+		details += fmt.Sprintf("\n*%s*", description)
+	}
+	if dueDate != nil {
+		details += fmt.Sprintf("\n**Due:** %s", dueDate.In(store.UserLocation(user.ID, guildID)).Format("Mon, Jan 2"))
+	}
+
 	embed := &discordgo.MessageEmbed{
 		Title:       "Goal Added!",
-		Description: fmt.Sprintf("**#%d:** %s\n**Points:** %d/10", task.Position, goal, points),
+		Description: details,
 		Color:       0x00FF00, // Green
 		Fields: []*discordgo.MessageEmbedField{
 			{
@@ -234,6 +708,27 @@ func handleFocusAdd(s *discordgo.Session, i *discordgo.InteractionCreate, user *
 		},
 	}
 
+	if config, err := store.GetGuildConfig(guildID); err == nil && config.DuplicateGoalCheck {
+		match, err := store.FindSimilarCompletedGoal(user.ID, guildID, goal)
+		if err != nil {
+			log.Printf("Error checking for duplicate goals: %v", err)
+		} else if match != nil {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:   "Looks Familiar",
+				Value:  fmt.Sprintf("You completed \"%s\" on %s. Completing a repeat goal holds its points for an admin to review.", match.Title, match.CompletedAt.Format("Jan 2")),
+				Inline: false,
+			})
+		}
+	}
+
+	if why := rationaleText(task.Rationale); why != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Why These Points",
+			Value:  why + "\n\nDisagree? Use `/focus appeal` to ask for a re-score.",
+			Inline: false,
+		})
+	}
+
 	if taskCount < database.MinimumTasksRequired {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 			Name:   "Tip",
@@ -242,12 +737,12 @@ func handleFocusAdd(s *discordgo.Session, i *discordgo.InteractionCreate, user *
 		})
 	}
 
-	respondWithEmbedEphemeral(s, i, embed, true)
+	editDeferredEmbed(s, i, embed)
 }
 
-func handleFocusComplete(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, goalNum int) {
+func handleFocusComplete(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, goalNum int) {
 	// Get current focus period
-	period, err := database.GetCurrentFocusPeriod(user.ID)
+	period, err := store.GetCurrentFocusPeriod(user.ID, guildID)
 	if err != nil {
 		log.Printf("Error getting focus period: %v", err)
 		respondWithError(s, i, "Failed to get your Focus Period.")
@@ -257,32 +752,23 @@ func handleFocusComplete(s *discordgo.Session, i *discordgo.InteractionCreate, u
 	if period == nil {
 		embed := &discordgo.MessageEmbed{
 			Title:       "No Active Focus Period",
-			Description: "You don't have an active Focus Period.\n\nUse `/focus start` to begin a new 2-week Focus Period!",
+			Description: "You don't have an active Focus Period.\n\nUse `/focus start` to begin a new Focus Period!",
 			Color:       0xFFA500, // Orange
 		}
 		respondWithEmbedEphemeral(s, i, embed, true)
 		return
 	}
 
-	// Complete the task
-	task, err := database.CompleteTask(period.ID, goalNum)
+	// Complete the task, awarding the points not already credited for checked steps
+	completion, err := store.AwardTaskCompletion(period, goalNum)
 	if err != nil {
 		respondWithError(s, i, err.Error())
 		return
 	}
-
-	// Award points to user
-	guildID := i.GuildID
-	if guildID == "" {
-		guildID = "DM"
-	}
-	err = database.AddPointsToUser(user.ID, period.ID, task.Points, guildID, period.StartDate, period.EndDate)
-	if err != nil {
-		log.Printf("Error adding points to user: %v", err)
-	}
+	task := completion.Task
 
 	// Reload tasks to get counts
-	tasks, _ := database.GetTasksByFocusPeriod(period.ID)
+	tasks, _ := store.GetTasksByFocusPeriod(period.ID)
 	completedCount := 0
 	for _, t := range tasks {
 		if t.Completed {
@@ -292,7 +778,7 @@ func handleFocusComplete(s *discordgo.Session, i *discordgo.InteractionCreate, u
 
 	embed := &discordgo.MessageEmbed{
 		Title:       "Goal Completed!",
-		Description: fmt.Sprintf("**#%d:** ~~%s~~\n\n+%d points earned!", task.Position, task.Title, task.Points),
+		Description: fmt.Sprintf("**#%d:** ~~%s~~\n\n+%d points earned!", task.Position, task.Title, completion.Earned),
 		Color:       0x00FF00, // Green
 		Fields: []*discordgo.MessageEmbedField{
 			{
@@ -308,6 +794,21 @@ func handleFocusComplete(s *discordgo.Session, i *discordgo.InteractionCreate, u
 		},
 	}
 
+	if completion.Flag != nil {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Points Held for Review",
+			Value:  fmt.Sprintf("%d points are waiting for an admin to review this completion:\n%s", completion.Flag.HeldPoints, completion.Flag.Reasons),
+			Inline: false,
+		})
+	}
+	if completion.Capped > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Point Cap Reached",
+			Value:  fmt.Sprintf("%d points weren't awarded because this Focus Period has reached the server's point cap.", completion.Capped),
+			Inline: false,
+		})
+	}
+
 	// Add celebration message if all tasks completed
 	if completedCount == len(tasks) && len(tasks) > 0 {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
@@ -321,12 +822,456 @@ func handleFocusComplete(s *discordgo.Session, i *discordgo.InteractionCreate, u
 	respondWithEmbed(s, i, embed)
 
 	// Notify buddies of task completion
-	go NotifyBuddiesOfCompletion(s, user, guildID, task)
+	go NotifyBuddiesOfCompletion(s, store, user, guildID, task)
+}
+
+// currentFocusPeriod fetches the user's active Focus Period, responding for them if there isn't one
+func currentFocusPeriod(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string) *database.FocusPeriod {
+	period, err := store.GetCurrentFocusPeriod(user.ID, guildID)
+	if err != nil {
+		log.Printf("Error getting focus period: %v", err)
+		respondWithError(s, i, "Failed to get your Focus Period.")
+		return nil
+	}
+
+	if period == nil {
+		embed := &discordgo.MessageEmbed{
+			Title:       "No Active Focus Period",
+			Description: "You don't have an active Focus Period.\n\nUse `/focus start` to begin a new Focus Period!",
+			Color:       0xFFA500, // Orange
+		}
+		respondWithEmbedEphemeral(s, i, embed, true)
+		return nil
+	}
+
+	return period
+}
+
+func handleFocusEdit(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, goalNum int, goal string, pointsEstimator estimator.Estimator) {
+	period := currentFocusPeriod(s, i, store, user, guildID)
+	if period == nil {
+		return
+	}
+
+	goal = strings.TrimSpace(goal)
+	if goal == "" {
+		respondWithError(s, i, "The goal can't be empty.")
+		return
+	}
+
+	var current *database.Task
+	for idx := range period.Tasks {
+		if period.Tasks[idx].Position == goalNum {
+			current = &period.Tasks[idx]
+		}
+	}
+	if current == nil {
+		respondWithError(s, i, fmt.Sprintf("task #%d not found", goalNum))
+		return
+	}
+	if current.Completed {
+		respondWithError(s, i, fmt.Sprintf("task #%d is completed; reopen it before editing", goalNum))
+		return
+	}
+
+	// Estimating can outlast Discord's response deadline, so acknowledge first
+	if !deferEphemeral(s, i) {
+		return
+	}
+
+	// Re-score the new wording, keeping the current points if that fails
+	estimate := estimator.Estimate{Points: current.Points, Rationale: current.Rationale}
+	if pointsEstimator != nil {
+		calculated, err := pointsEstimator.Estimate(estimator.WithGuild(context.Background(), guildID), goal, current.Description)
+		if err != nil {
+			log.Printf("Error calculating points: %v, keeping previous points", err)
+		} else {
+			estimate = calculated
+		}
+	}
+
+	task, err := store.UpdateTask(period, goalNum, goal, estimate)
+	if err != nil {
+		editDeferredError(s, i, err.Error())
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Goal Updated",
+		Description: fmt.Sprintf("**#%d:** %s\n**Points:** %d/10", task.Position, task.Title, task.Points),
+		Color:       0x00FF00, // Green
+	}
+	editDeferredEmbed(s, i, embed)
+}
+
+func handleFocusRemove(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, goalNum int) {
+	period := currentFocusPeriod(s, i, store, user, guildID)
+	if period == nil {
+		return
+	}
+
+	task, err := store.DeleteTask(period, goalNum)
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	description := fmt.Sprintf("Removed **%s**. Your remaining goals have been renumbered.", task.Title)
+	if points := task.CreditedPoints(); points > 0 {
+		description += fmt.Sprintf("\n\n-%d points earned by the removed goal.", points)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Goal Removed",
+		Description: description,
+		Color:       0xFFA500, // Orange
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Use /focus list to see your goals",
+		},
+	}
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleFocusReopen(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, goalNum int) {
+	period := currentFocusPeriod(s, i, store, user, guildID)
+	if period == nil {
+		return
+	}
+
+	task, lost, err := store.ReopenTask(period, goalNum)
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	description := fmt.Sprintf("**#%d:** %s\n\n", task.Position, task.Title)
+	if lost > 0 {
+		description += fmt.Sprintf("-%d points until it's completed again.", lost)
+	} else {
+		description += "No points were taken back."
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       "Goal Reopened",
+		Description: description,
+		Color:       0xFFA500, // Orange
+	}
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleFocusMove(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, goalNum, position int) {
+	period := currentFocusPeriod(s, i, store, user, guildID)
+	if period == nil {
+		return
+	}
+
+	task, err := store.MoveTask(period.ID, goalNum, position)
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Goal Moved",
+		Description: fmt.Sprintf("**%s** is now goal **#%d**.", task.Title, task.Position),
+		Color:       0x00FF00, // Green
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Use /focus list to see your goals",
+		},
+	}
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleFocusAppeal(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, goalNum, points int, reason string) {
+	period := currentFocusPeriod(s, i, store, user, guildID)
+	if period == nil {
+		return
+	}
+
+	if reason == "" {
+		respondWithError(s, i, "Tell your reviewers why the estimate is off.")
+		return
+	}
+
+	appeal, err := store.CreatePointAppeal(period, goalNum, points, reason)
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	// Ping the member's buddies, who can decide alongside admins
+	content := "A buddy or an admin can decide this appeal."
+	buddies, err := store.GetUserBuddies(user.ID, guildID)
+	if err != nil {
+		log.Printf("Error getting buddies for appeal: %v", err)
+	}
+	if len(buddies) > 0 {
+		mentions := make([]string, len(buddies))
+		for idx, buddy := range buddies {
+			mentions[idx] = fmt.Sprintf("<@%s>", buddy.DiscordID)
+		}
+		content = fmt.Sprintf("%s, can you review this? An admin can decide too.", strings.Join(mentions, " "))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Points Appeal",
+		Description: fmt.Sprintf("<@%s> thinks **#%d: %s** is worth **%d** points, not %d.", user.DiscordID, appeal.Task.Position, appeal.Task.Title, appeal.RequestedPoints, appeal.PreviousPoints),
+		Color:       0x5865F2, // Discord blurple
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Reason",
+				Value:  appeal.Reason,
+				Inline: false,
+			},
+		},
+	}
+	if why := rationaleText(appeal.Task.Rationale); why != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Original Estimate",
+			Value:  why,
+			Inline: false,
+		})
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Embeds:  []*discordgo.MessageEmbed{embed},
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.Button{
+							Label:    fmt.Sprintf("Approve %d points", appeal.RequestedPoints),
+							Style:    discordgo.SuccessButton,
+							CustomID: fmt.Sprintf("%s%d", focusAppealApprovePrefix, appeal.ID),
+						},
+						discordgo.Button{
+							Label:    fmt.Sprintf("Keep %d points", appeal.PreviousPoints),
+							Style:    discordgo.SecondaryButton,
+							CustomID: fmt.Sprintf("%s%d", focusAppealRejectPrefix, appeal.ID),
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding with appeal: %v", err)
+	}
+}
+
+// handleFocusAppealDecision handles the approve and keep buttons on a points appeal.
+// Only the member's buddies and admins may decide, and never the member themselves.
+func handleFocusAppealDecision(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, customID string) {
+	approve := strings.HasPrefix(customID, focusAppealApprovePrefix)
+	var appealID uint
+	idText := strings.TrimPrefix(strings.TrimPrefix(customID, focusAppealApprovePrefix), focusAppealRejectPrefix)
+	if _, err := fmt.Sscanf(idText, "%d", &appealID); err != nil {
+		log.Printf("Invalid appeal button %q: %v", customID, err)
+		return
+	}
+
+	if i.Member == nil {
+		return
+	}
+	reviewer, err := store.GetOrCreateUser(i.Member.User.ID, i.GuildID, i.Member.User.Username)
+	if err != nil {
+		log.Printf("Error getting/creating user: %v", err)
+		respondWithError(s, i, "Failed to process your request. Please try again.")
+		return
+	}
+
+	appeal, err := store.GetPointAppeal(appealID)
+	if err != nil {
+		log.Printf("Error getting appeal: %v", err)
+		respondWithError(s, i, "Failed to load this appeal.")
+		return
+	}
+	if appeal == nil || appeal.GuildID != i.GuildID {
+		respondWithError(s, i, "This appeal no longer exists.")
+		return
+	}
+
+	if appeal.UserID == reviewer.ID {
+		respondWithError(s, i, "You can't decide your own appeal. Ask a buddy or an admin.")
+		return
+	}
+	buddies, err := store.AreBuddies(appeal.UserID, reviewer.ID, i.GuildID)
+	if err != nil {
+		log.Printf("Error checking buddies: %v", err)
+	}
+	if !buddies && !hasAdminRole(s, i) {
+		respondWithError(s, i, fmt.Sprintf("Only %s's buddies or an admin can decide this appeal.", appeal.User.Username))
+		return
+	}
+
+	pointsChange, err := store.ResolvePointAppeal(appeal, reviewer.ID, approve)
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	if !approve {
+		updateFocusPrompt(s, i, &discordgo.MessageEmbed{
+			Title:       "Appeal Declined",
+			Description: fmt.Sprintf("**#%d: %s** stays at %d points.", appeal.Task.Position, appeal.Task.Title, appeal.PreviousPoints),
+			Color:       0xFFA500, // Orange
+			Footer: &discordgo.MessageEmbedFooter{
+				Text: fmt.Sprintf("Decided by %s", reviewer.Username),
+			},
+		})
+		return
+	}
+
+	description := fmt.Sprintf("**#%d: %s** is now worth **%d** points (was %d).", appeal.Task.Position, appeal.Task.Title, appeal.RequestedPoints, appeal.PreviousPoints)
+	if pointsChange != 0 {
+		description += fmt.Sprintf("\n\n%+d points applied to <@%s>'s sprint and total.", pointsChange, appeal.User.DiscordID)
+	}
+	updateFocusPrompt(s, i, &discordgo.MessageEmbed{
+		Title:       "Appeal Approved",
+		Description: description,
+		Color:       0x00FF00, // Green
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Decided by %s", reviewer.Username),
+		},
+	})
+}
+
+func handleFocusStep(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if len(options) == 0 {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	period := currentFocusPeriod(s, i, store, user, guildID)
+	if period == nil {
+		return
+	}
+
+	subCommand := options[0]
+	var goalNum, stepNum int
+	var stepText string
+	for _, opt := range subCommand.Options {
+		switch opt.Name {
+		case "number":
+			goalNum = int(opt.IntValue())
+		case "step":
+			if subCommand.Name == "add" {
+				stepText = strings.TrimSpace(opt.StringValue())
+			} else {
+				stepNum = int(opt.IntValue())
+			}
+		}
+	}
+
+	var task *database.Task
+	var pointsChange int
+	var err error
+	var title string
+	switch subCommand.Name {
+	case "add":
+		if stepText == "" {
+			respondWithError(s, i, "The step can't be empty.")
+			return
+		}
+		task, pointsChange, err = store.AddTaskStep(period, goalNum, stepText)
+		title = "Step Added"
+	case "check":
+		task, pointsChange, err = store.SetTaskStepCompleted(period, goalNum, stepNum, true)
+		title = "Step Checked"
+	case "uncheck":
+		task, pointsChange, err = store.SetTaskStepCompleted(period, goalNum, stepNum, false)
+		title = "Step Unchecked"
+	case "remove":
+		task, pointsChange, err = store.RemoveTaskStep(period, goalNum, stepNum)
+		title = "Step Removed"
+	default:
+		respondWithError(s, i, "Unknown subcommand")
+		return
+	}
+	if err != nil {
+		respondWithError(s, i, err.Error())
+		return
+	}
+
+	var description strings.Builder
+	description.WriteString(fmt.Sprintf("**#%d:** %s (%d/%d steps)\n", task.Position, task.Title, task.CompletedStepCount(), len(task.Steps)))
+	writeTaskSteps(&description, task.Steps)
+	switch {
+	case pointsChange > 0:
+		description.WriteString(fmt.Sprintf("\n+%d points for progress on this goal!", pointsChange))
+	case pointsChange < 0:
+		description.WriteString(fmt.Sprintf("\n%d points from this goal's progress.", pointsChange))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: description.String(),
+		Color:       0x00FF00, // Green
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Goal Progress",
+				Value:  fmt.Sprintf("%s %d%%", buildProgressBar(int(task.Progress()*100)), int(task.Progress()*100)),
+				Inline: false,
+			},
+		},
+	}
+
+	if !task.Completed && len(task.Steps) > 0 && task.CompletedStepCount() == len(task.Steps) {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "All Steps Done",
+			Value:  fmt.Sprintf("Use `/focus complete %d` to finish the goal!", task.Position),
+			Inline: false,
+		})
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+// rationaleText lays out why a goal got its points, one reason per line
+func rationaleText(rationale estimator.Rationale) string {
+	var lines []string
+	for _, reason := range []struct{ label, text string }{
+		{"⏱️ Time", rationale.Time},
+		{"🧩 Complexity", rationale.Complexity},
+		{"🎯 Impact", rationale.Impact},
+	} {
+		if reason.text != "" {
+			lines = append(lines, fmt.Sprintf("**%s:** %s", reason.label, reason.text))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// rationaleLine condenses why a goal got its points onto one line for /focus list
+func rationaleLine(rationale estimator.Rationale) string {
+	var parts []string
+	for _, reason := range []struct{ icon, text string }{
+		{"⏱️", rationale.Time},
+		{"🧩", rationale.Complexity},
+		{"🎯", rationale.Impact},
+	} {
+		if reason.text != "" {
+			parts = append(parts, reason.icon+" "+reason.text)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// writeTaskSteps writes a goal's steps as a numbered checklist
+func writeTaskSteps(b *strings.Builder, steps []database.TaskStep) {
+	for _, step := range steps {
+		if step.Completed {
+			b.WriteString(fmt.Sprintf("└ %d. ☑ ~~%s~~\n", step.Position, step.Title))
+		} else {
+			b.WriteString(fmt.Sprintf("└ %d. ☐ %s\n", step.Position, step.Title))
+		}
+	}
 }
 
-func handleFocusList(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User) {
+func handleFocusList(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string) {
 	// Get current focus period
-	period, err := database.GetCurrentFocusPeriod(user.ID)
+	period, err := store.GetCurrentFocusPeriod(user.ID, guildID)
 	if err != nil {
 		log.Printf("Error getting focus period: %v", err)
 		respondWithError(s, i, "Failed to get your Focus Period.")
@@ -336,7 +1281,7 @@ func handleFocusList(s *discordgo.Session, i *discordgo.InteractionCreate, user
 	if period == nil {
 		embed := &discordgo.MessageEmbed{
 			Title:       "No Active Focus Period",
-			Description: "You don't have an active Focus Period.\n\nUse `/focus start` to begin a new 2-week Focus Period!",
+			Description: "You don't have an active Focus Period.\n\nUse `/focus start` to begin a new Focus Period!",
 			Color:       0xFFA500, // Orange
 		}
 		respondWithEmbedEphemeral(s, i, embed, true)
@@ -344,23 +1289,41 @@ func handleFocusList(s *discordgo.Session, i *discordgo.InteractionCreate, user
 	}
 
 	// Get tasks
-	tasks, err := database.GetTasksByFocusPeriod(period.ID)
+	tasks, err := store.GetTasksByFocusPeriod(period.ID)
 	if err != nil {
 		log.Printf("Error getting tasks: %v", err)
 		respondWithError(s, i, "Failed to get your goals.")
 		return
 	}
 
+	loc := store.UserLocation(user.ID, guildID)
+	now := time.Now().In(loc)
+
 	var goalsList strings.Builder
 	if len(tasks) == 0 {
 		goalsList.WriteString("*No goals set yet!*\n\nUse `/focus add <goal>` to add your first goal.")
 	} else {
 		for _, task := range tasks {
+			due := ""
+			switch {
+			case task.DueDate == nil || task.Completed:
+			case task.IsOverdueAt(now):
+				due = fmt.Sprintf(" ⚠️ overdue since %s", task.DueDate.In(loc).Format("Jan 2"))
+			default:
+				due = fmt.Sprintf(" 📅 due %s", task.DueDate.In(loc).Format("Jan 2"))
+			}
+
 			if task.Completed {
-				goalsList.WriteString(fmt.Sprintf("~~**#%d:** %s~~ ✅\n", task.Position, task.Title))
+				goalsList.WriteString(fmt.Sprintf("~~**#%d:** %s~~ ✅ · %d pts\n", task.Position, task.Title, task.Points))
+			} else if len(task.Steps) > 0 {
+				goalsList.WriteString(fmt.Sprintf("**#%d:** %s (%d/%d steps) · %d pts%s\n", task.Position, task.Title, task.CompletedStepCount(), len(task.Steps), task.Points, due))
 			} else {
-				goalsList.WriteString(fmt.Sprintf("**#%d:** %s\n", task.Position, task.Title))
+				goalsList.WriteString(fmt.Sprintf("**#%d:** %s · %d pts%s\n", task.Position, task.Title, task.Points, due))
+			}
+			if why := rationaleLine(task.Rationale); why != "" {
+				goalsList.WriteString(fmt.Sprintf("└ *%s*\n", why))
 			}
+			writeTaskSteps(&goalsList, task.Steps)
 		}
 	}
 
@@ -371,17 +1334,17 @@ func handleFocusList(s *discordgo.Session, i *discordgo.InteractionCreate, user
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Name:   "Period",
-				Value:  fmt.Sprintf("%s - %s", period.StartDate.Format("Jan 2"), period.EndDate.Format("Jan 2")),
+				Value:  fmt.Sprintf("%s - %s", period.StartDate.In(loc).Format("Jan 2"), period.EndDate.In(loc).Format("Jan 2")),
 				Inline: true,
 			},
 			{
 				Name:   "Day",
-				Value:  fmt.Sprintf("Day %d of 14", period.DayNumber()),
+				Value:  fmt.Sprintf("Day %d of %d", period.DayNumberIn(loc), period.LengthDays()),
 				Inline: true,
 			},
 			{
 				Name:   "Progress",
-				Value:  fmt.Sprintf("%d/%d completed", period.CompletedTaskCount(), len(tasks)),
+				Value:  fmt.Sprintf("%d/%d completed (%d%%)", period.CompletedTaskCount(), len(tasks), period.ProgressPercent()),
 				Inline: true,
 			},
 		},
@@ -393,9 +1356,9 @@ func handleFocusList(s *discordgo.Session, i *discordgo.InteractionCreate, user
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleFocusStatus(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User) {
+func handleFocusStatus(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string) {
 	// Get current focus period
-	period, err := database.GetCurrentFocusPeriod(user.ID)
+	period, err := store.GetCurrentFocusPeriod(user.ID, guildID)
 	if err != nil {
 		log.Printf("Error getting focus period: %v", err)
 		respondWithError(s, i, "Failed to get your Focus Period.")
@@ -405,7 +1368,7 @@ func handleFocusStatus(s *discordgo.Session, i *discordgo.InteractionCreate, use
 	if period == nil {
 		embed := &discordgo.MessageEmbed{
 			Title:       "No Active Focus Period",
-			Description: "You don't have an active Focus Period.\n\nUse `/focus start` to begin a new 2-week Focus Period and set goals to track your progress!",
+			Description: "You don't have an active Focus Period.\n\nUse `/focus start` to begin a new Focus Period and set goals to track your progress!",
 			Color:       0xFFA500, // Orange
 		}
 		respondWithEmbedEphemeral(s, i, embed, true)
@@ -413,16 +1376,13 @@ func handleFocusStatus(s *discordgo.Session, i *discordgo.InteractionCreate, use
 	}
 
 	// Get tasks
-	tasks, _ := database.GetTasksByFocusPeriod(period.ID)
+	tasks, _ := store.GetTasksByFocusPeriod(period.ID)
 	completedCount := period.CompletedTaskCount()
 	totalCount := len(tasks)
 	pendingCount := totalCount - completedCount
 
-	// Calculate progress percentage
-	progressPercent := 0
-	if totalCount > 0 {
-		progressPercent = (completedCount * 100) / totalCount
-	}
+	// Calculate progress percentage, counting checked steps of unfinished goals
+	progressPercent := period.ProgressPercent()
 
 	// Build progress bar
 	progressBar := buildProgressBar(progressPercent)
@@ -452,6 +1412,8 @@ func handleFocusStatus(s *discordgo.Session, i *discordgo.InteractionCreate, use
 		statusMessage = "Time to pick up the pace! You've got this!"
 	}
 
+	loc := store.UserLocation(user.ID, guildID)
+	dayNumber := period.DayNumberIn(loc)
 	embed := &discordgo.MessageEmbed{
 		Title: "Focus Period Status",
 		Color: statusColor,
@@ -468,7 +1430,7 @@ func handleFocusStatus(s *discordgo.Session, i *discordgo.InteractionCreate, use
 			},
 			{
 				Name:   "Time",
-				Value:  fmt.Sprintf("📅 Day %d of 14\n⏰ %d days remaining", period.DayNumber(), period.DaysRemaining()),
+				Value:  fmt.Sprintf("📅 Day %d of %d\n⏰ %d days remaining", dayNumber, period.LengthDays(), period.DaysRemaining()),
 				Inline: true,
 			},
 			{
@@ -482,6 +1444,23 @@ func handleFocusStatus(s *discordgo.Session, i *discordgo.InteractionCreate, use
 		},
 	}
 
+	// Call out goals whose due date has passed
+	var overdue strings.Builder
+	now := time.Now().In(loc)
+	for _, task := range tasks {
+		if task.IsOverdueAt(now) {
+			overdue.WriteString(fmt.Sprintf("**#%d:** %s (due %s)\n", task.Position, task.Title, task.DueDate.In(loc).Format("Jan 2")))
+		}
+	}
+	if overdue.Len() > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "⚠️ Overdue",
+			Value:  overdue.String(),
+			Inline: false,
+		})
+		embed.Color = 0xFF6B6B // Light red
+	}
+
 	if totalCount < database.MinimumTasksRequired {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 			Name:   "Recommendation",
@@ -503,12 +1482,12 @@ func buildProgressBar(percent int) string {
 }
 
 // respondWithEmbed sends an embed response (public by default)
-func respondWithEmbed(s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
+func respondWithEmbed(s discord.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
 	respondWithEmbedEphemeral(s, i, embed, false)
 }
 
 // respondWithEmbedEphemeral sends an embed response with optional ephemeral flag
-func respondWithEmbedEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed, ephemeral bool) {
+func respondWithEmbedEphemeral(s discord.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed, ephemeral bool) {
 	flags := discordgo.MessageFlags(0)
 	if ephemeral {
 		flags = discordgo.MessageFlagsEphemeral
@@ -525,8 +1504,43 @@ func respondWithEmbedEphemeral(s *discordgo.Session, i *discordgo.InteractionCre
 	}
 }
 
+// deferEphemeral acknowledges an interaction with a private "thinking" response to be filled in by editDeferredEmbed.
+// It reports false if Discord rejected the acknowledgement.
+func deferEphemeral(s discord.Session, i *discordgo.InteractionCreate) bool {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return false
+	}
+	return true
+}
+
+// editDeferredEmbed fills in a response deferred by deferEphemeral
+func editDeferredEmbed(s discord.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	})
+	if err != nil {
+		log.Printf("Error editing deferred response: %v", err)
+	}
+}
+
+// editDeferredError fills in a response deferred by deferEphemeral with an error message
+func editDeferredError(s discord.Session, i *discordgo.InteractionCreate, message string) {
+	editDeferredEmbed(s, i, &discordgo.MessageEmbed{
+		Title:       "Error",
+		Description: message,
+		Color:       0xFF0000, // Red
+	})
+}
+
 // respondWithError sends an error message (always ephemeral to avoid exposing user state)
-func respondWithError(s *discordgo.Session, i *discordgo.InteractionCreate, message string) {
+func respondWithError(s discord.Session, i *discordgo.InteractionCreate, message string) {
 	embed := &discordgo.MessageEmbed{
 		Title:       "Error",
 		Description: message,
@@ -0,0 +1,296 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord/discordtest"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestFocusWorkflow(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+
+	resp := h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", "Ship landing page")))
+	assertTitle(t, resp, "No Active Focus Period")
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	assertTitle(t, resp, "New Focus Period Started!")
+	if !isEphemeral(resp) {
+		t.Error("Expected /focus start to be ephemeral")
+	}
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	assertTitle(t, resp, "Focus Period Already Active")
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", "Ship landing page")))
+	embed := assertTitle(t, resp, "Goal Added!")
+	if !strings.Contains(embed.Description, "**Points:** 5/10") {
+		t.Errorf("Expected default points without OpenAI, got %q", embed.Description)
+	}
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("complete", discordtest.Int("number", 1)))
+	embed = assertTitle(t, resp, "Goal Completed!")
+	if isEphemeral(resp) {
+		t.Error("Expected /focus complete to be public")
+	}
+	if !strings.Contains(embed.Description, "+5 points") {
+		t.Errorf("Expected points in completion message, got %q", embed.Description)
+	}
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("list"))
+	embed = assertTitle(t, resp, "Your Focus Period Goals")
+	if !strings.Contains(embed.Description, "~~**#1:** Ship landing page~~") {
+		t.Errorf("Expected completed goal in list, got %q", embed.Description)
+	}
+
+	user, _ := h.store.GetOrCreateUser(alice.ID, testGuildID, alice.Username)
+	member, _ := h.store.GetGuildMember(user.ID, testGuildID)
+	if member == nil || member.TotalPoints != 5 {
+		t.Errorf("Expected 5 total points, got %+v", member)
+	}
+}
+
+func TestFocusCompleteUnknownGoal(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+
+	h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	resp := h.run(alice, nil, "focus", discordtest.SubCommand("complete", discordtest.Int("number", 3)))
+	assertTitle(t, resp, "Error")
+}
+
+func TestFocusEditRemoveReopenMove(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+
+	h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	for _, goal := range []string{"Landing page", "Pricing page", "Launch post"} {
+		h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", goal)))
+	}
+
+	resp := h.run(alice, nil, "focus", discordtest.SubCommand("edit", discordtest.Int("number", 2), discordtest.String("goal", "Pricing experiment")))
+	embed := assertTitle(t, resp, "Goal Updated")
+	if !strings.Contains(embed.Description, "**#2:** Pricing experiment") {
+		t.Errorf("Expected the edited goal, got %q", embed.Description)
+	}
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("move", discordtest.Int("number", 3), discordtest.Int("position", 1)))
+	assertTitle(t, resp, "Goal Moved")
+
+	h.run(alice, nil, "focus", discordtest.SubCommand("complete", discordtest.Int("number", 1)))
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("edit", discordtest.Int("number", 1), discordtest.String("goal", "Launch thread")))
+	assertError(t, resp, "reopen it before editing")
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("reopen", discordtest.Int("number", 1)))
+	embed = assertTitle(t, resp, "Goal Reopened")
+	if !strings.Contains(embed.Description, "until it's completed again") {
+		t.Errorf("Expected the points taken back, got %q", embed.Description)
+	}
+
+	user, _ := h.store.GetOrCreateUser(alice.ID, testGuildID, alice.Username)
+	member, _ := h.store.GetGuildMember(user.ID, testGuildID)
+	if member.TotalPoints != 0 {
+		t.Errorf("Expected reopening to take back the points, got %d", member.TotalPoints)
+	}
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("remove", discordtest.Int("number", 2)))
+	assertTitle(t, resp, "Goal Removed")
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("list"))
+	embed = assertTitle(t, resp, "Your Focus Period Goals")
+	if !strings.Contains(embed.Description, "**#1:** Launch post") || !strings.Contains(embed.Description, "**#2:** Pricing experiment") {
+		t.Errorf("Expected goals renumbered after removal, got %q", embed.Description)
+	}
+}
+
+func TestFocusSteps(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+	admin := newTestUser("user-admin", "admin")
+
+	h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", "Launch the beta")))
+	h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", "Write the docs")))
+
+	resp := h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("focus", discordtest.SubCommand("step-points", discordtest.Bool("enabled", true))))
+	embed := assertTitle(t, resp, "Configuration Updated")
+	if embed.Fields[3].Value != "✅ On" {
+		t.Errorf("Expected step points to be on, got %q", embed.Fields[3].Value)
+	}
+
+	for _, step := range []string{"Invite testers", "Ship build"} {
+		resp = h.run(alice, nil, "focus", discordtest.SubCommandGroup("step", discordtest.SubCommand("add", discordtest.Int("number", 1), discordtest.String("step", step))))
+		assertTitle(t, resp, "Step Added")
+	}
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommandGroup("step", discordtest.SubCommand("check", discordtest.Int("number", 1), discordtest.Int("step", 3))))
+	assertError(t, resp, "step 3 not found")
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommandGroup("step", discordtest.SubCommand("check", discordtest.Int("number", 1), discordtest.Int("step", 1))))
+	embed = assertTitle(t, resp, "Step Checked")
+	if !strings.Contains(embed.Description, "+2 points") {
+		t.Errorf("Expected partial points for the step, got %q", embed.Description)
+	}
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("list"))
+	embed = assertTitle(t, resp, "Your Focus Period Goals")
+	if !strings.Contains(embed.Description, "Launch the beta (1/2 steps)") || !strings.Contains(embed.Description, "☑ ~~Invite testers~~") {
+		t.Errorf("Expected the goal's checklist, got %q", embed.Description)
+	}
+
+	// Half of one goal out of two is a quarter of the way there
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("status"))
+	embed = assertTitle(t, resp, "Focus Period Status")
+	if !strings.HasSuffix(embed.Fields[0].Value, " 25%") {
+		t.Errorf("Expected partial progress from the checked step, got %q", embed.Fields[0].Value)
+	}
+
+	// Completing the goal earns the rest of its points
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("complete", discordtest.Int("number", 1)))
+	embed = assertTitle(t, resp, "Goal Completed!")
+	if !strings.Contains(embed.Description, "+3 points") {
+		t.Errorf("Expected the remaining points on completion, got %q", embed.Description)
+	}
+}
+
+func TestFocusAddWithDueDate(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+	h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+
+	resp := h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", "Ship pricing"), discordtest.String("due", "soon")))
+	assertError(t, resp, "Invalid due date")
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", "Ship pricing"), discordtest.String("due", "2001-01-01")))
+	assertError(t, resp, "can't be in the past")
+
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("add",
+		discordtest.String("goal", "Ship pricing"),
+		discordtest.String("description", "Three tiers with annual discount"),
+		discordtest.String("due", tomorrow),
+	))
+	embed := assertTitle(t, resp, "Goal Added!")
+	if !strings.Contains(embed.Description, "Three tiers with annual discount") || !strings.Contains(embed.Description, "**Due:**") {
+		t.Errorf("Expected the description and due date, got %q", embed.Description)
+	}
+
+	user, _ := h.store.GetOrCreateUser(alice.ID, testGuildID, alice.Username)
+	period, _ := h.store.GetCurrentFocusPeriod(user.ID, testGuildID)
+	task := period.Tasks[0]
+	if task.Description != "Three tiers with annual discount" || task.DueDate == nil {
+		t.Fatalf("Expected the description and due date to be stored, got %+v", task)
+	}
+
+	// Once the due day has passed the goal shows as overdue
+	h.store.DB().Model(&task).Update("due_date", task.DueDate.AddDate(0, 0, -3))
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("status"))
+	embed = assertTitle(t, resp, "Focus Period Status")
+	var overdue string
+	for _, field := range embed.Fields {
+		if field.Name == "⚠️ Overdue" {
+			overdue = field.Value
+		}
+	}
+	if !strings.Contains(overdue, "**#1:** Ship pricing") {
+		t.Errorf("Expected the goal listed as overdue, got %+v", embed.Fields)
+	}
+}
+
+func TestFocusStartOffersCarryOver(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+
+	// End a period with one unfinished goal
+	h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", "Write the docs")))
+	user, _ := h.store.GetOrCreateUser(alice.ID, testGuildID, alice.Username)
+	period, _ := h.store.GetCurrentFocusPeriod(user.ID, testGuildID)
+	h.store.DB().Model(period).Updates(map[string]interface{}{
+		"start_date": period.StartDate.AddDate(0, 0, -20),
+		"end_date":   period.EndDate.AddDate(0, 0, -20),
+	})
+
+	resp := h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	embed := assertTitle(t, resp, "New Focus Period Started!")
+	if !strings.Contains(embed.Fields[len(embed.Fields)-1].Value, "Write the docs") {
+		t.Errorf("Expected the unfinished goal in the prompt, got %q", embed.Fields[len(embed.Fields)-1].Value)
+	}
+	if len(resp.Data.Components) != 1 {
+		t.Fatalf("Expected carry-over buttons, got %d component rows", len(resp.Data.Components))
+	}
+	carryButton := resp.Data.Components[0].(discordgo.ActionsRow).Components[0].(discordgo.Button)
+
+	resp = h.click(alice, carryButton.CustomID)
+	embed = assertTitle(t, resp, "Goals Carried Over")
+	if !strings.Contains(embed.Description, "**#1:** Write the docs") {
+		t.Errorf("Expected the goal in the new period, got %q", embed.Description)
+	}
+
+	// Someone else can't press the button for alice
+	resp = h.click(newTestUser("user-bob", "bob"), carryButton.CustomID)
+	assertTitle(t, resp, "Error")
+}
+
+func TestFocusAppeal(t *testing.T) {
+	h := newTestHarness(t)
+	h.handlers = GetHandlers(h.store, estimator.Heuristic{})
+	alice := newTestUser("user-alice", "alice")
+	bob := newTestUser("user-bob", "bob")
+	carol := newTestUser("user-carol", "carol")
+
+	aliceUser, _ := h.store.GetOrCreateUser(alice.ID, testGuildID, alice.Username)
+	bobUser, _ := h.store.GetOrCreateUser(bob.ID, testGuildID, bob.Username)
+	h.store.CreateBuddyRequest(aliceUser.ID, bobUser.ID, testGuildID)
+	h.store.AcceptBuddyRequest(aliceUser.ID, bobUser.ID, testGuildID)
+
+	h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	resp := h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", "Reply to an email")))
+	embed := assertTitle(t, resp, "Goal Added!")
+	if why := embed.Fields[len(embed.Fields)-2]; why.Name != "Why These Points" || !strings.Contains(why.Value, "quick task (reply, email)") {
+		t.Errorf("Expected the estimate to be explained, got %+v", why)
+	}
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("list"))
+	embed = assertTitle(t, resp, "Your Focus Period Goals")
+	if !strings.Contains(embed.Description, "**#1:** Reply to an email · 1 pts") || !strings.Contains(embed.Description, "⏱️ Sounds like a quick task") {
+		t.Errorf("Expected points and rationale in the list, got %q", embed.Description)
+	}
+
+	h.run(alice, nil, "focus", discordtest.SubCommand("complete", discordtest.Int("number", 1)))
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("appeal",
+		discordtest.Int("number", 1), discordtest.Int("points", 4), discordtest.String("reason", "It was a 20-reply investor thread")))
+	assertTitle(t, resp, "Points Appeal")
+	if !strings.Contains(resp.Data.Content, "<@user-bob>") {
+		t.Errorf("Expected the buddy to be pinged, got %q", resp.Data.Content)
+	}
+	row := resp.Data.Components[0].(discordgo.ActionsRow)
+	approve := row.Components[0].(discordgo.Button).CustomID
+
+	resp = h.run(alice, nil, "focus", discordtest.SubCommand("appeal",
+		discordtest.Int("number", 1), discordtest.Int("points", 5), discordtest.String("reason", "Actually more")))
+	assertError(t, resp, "already has an appeal")
+
+	// Neither the member nor a stranger can decide
+	assertError(t, h.click(alice, approve), "your own appeal")
+	assertError(t, h.click(carol, approve), "buddies or an admin")
+
+	resp = h.click(bob, approve)
+	embed = assertTitle(t, resp, "Appeal Approved")
+	if !strings.Contains(embed.Description, "+3 points applied") {
+		t.Errorf("Expected the points change, got %q", embed.Description)
+	}
+	if len(resp.Data.Components) != 0 {
+		t.Error("Expected the buttons to be removed once decided")
+	}
+	assertError(t, h.click(bob, approve), "already been decided")
+
+	member, _ := h.store.GetGuildMember(aliceUser.ID, testGuildID)
+	if member.TotalPoints != 4 {
+		t.Errorf("Expected 4 total points after the appeal, got %d", member.TotalPoints)
+	}
+}
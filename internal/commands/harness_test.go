@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord/discordtest"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	testGuildID     = "guild-1"
+	testChannelID   = "channel-general"
+	testAdminRoleID = "role-admin"
+)
+
+// testHarness runs slash commands against an isolated store and a fake session
+type testHarness struct {
+	t        *testing.T
+	store    *database.Store
+	session  *discordtest.Session
+	handlers map[string]func(s discord.Session, i *discordgo.InteractionCreate)
+}
+
+func newTestHarness(t *testing.T) *testHarness {
+	t.Helper()
+
+	store, err := database.OpenInMemory()
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	session := discordtest.NewSession()
+	session.AddGuild(&discordgo.Guild{
+		ID:    testGuildID,
+		Roles: []*discordgo.Role{{ID: testAdminRoleID, Name: "Admin"}},
+	})
+
+	return &testHarness{
+		t:        t,
+		store:    store,
+		session:  session,
+		handlers: GetHandlers(store, nil),
+	}
+}
+
+// run invokes a slash command as the given user and returns the interaction response
+func (h *testHarness) run(user *discordgo.User, roles []string, name string, options ...discordtest.Option) *discordgo.InteractionResponse {
+	h.t.Helper()
+
+	handler, ok := h.handlers[name]
+	if !ok {
+		h.t.Fatalf("Unknown command /%s", name)
+	}
+
+	before := len(h.session.Responses())
+	invocation := discordtest.Invocation{GuildID: testGuildID, ChannelID: testChannelID, User: user, Roles: roles}
+	handler(h.session, invocation.Command(name, options...))
+
+	responses := h.session.Responses()
+	if len(responses) == before {
+		h.t.Fatalf("/%s did not respond", name)
+	}
+	return h.resolve(responses[len(responses)-1])
+}
+
+// resolve returns the response a user ends up seeing, filling a deferred response in from its edit
+func (h *testHarness) resolve(recorded discordtest.Response) *discordgo.InteractionResponse {
+	h.t.Helper()
+
+	resp := recorded.Response
+	if resp.Type != discordgo.InteractionResponseDeferredChannelMessageWithSource {
+		return resp
+	}
+
+	edit := h.session.EditFor(recorded.Interaction)
+	if edit == nil || edit.Embeds == nil {
+		h.t.Fatalf("Deferred response was never filled in")
+	}
+	data := &discordgo.InteractionResponseData{Embeds: *edit.Embeds}
+	if resp.Data != nil {
+		data.Flags = resp.Data.Flags
+	}
+	return &discordgo.InteractionResponse{Type: discordgo.InteractionResponseChannelMessageWithSource, Data: data}
+}
+
+// click presses a button or picks a select menu value as the given user and returns the response
+func (h *testHarness) click(user *discordgo.User, customID string, values ...string) *discordgo.InteractionResponse {
+	h.t.Helper()
+
+	before := len(h.session.Responses())
+	invocation := discordtest.Invocation{GuildID: testGuildID, ChannelID: testChannelID, User: user}
+	interaction := invocation.Component(customID, values...)
+	if IsFocusComponent(customID) {
+		HandleFocusComponent(h.session, interaction, h.store)
+	} else {
+		HandleHelpComponent(h.session, interaction)
+	}
+
+	responses := h.session.Responses()
+	if len(responses) == before {
+		h.t.Fatalf("Component %s did not respond", customID)
+	}
+	return responses[len(responses)-1].Response
+}
+
+// submit sends a modal as the given user and returns the response
+func (h *testHarness) submit(user *discordgo.User, customID string, values map[string]string) *discordgo.InteractionResponse {
+	h.t.Helper()
+
+	before := len(h.session.Responses())
+	invocation := discordtest.Invocation{GuildID: testGuildID, ChannelID: testChannelID, User: user}
+	interaction := invocation.ModalSubmit(customID, values)
+	if !IsStandupModal(customID) {
+		h.t.Fatalf("Unknown modal %s", customID)
+	}
+	HandleStandupModal(h.session, interaction, h.store)
+
+	responses := h.session.Responses()
+	if len(responses) == before {
+		h.t.Fatalf("Modal %s did not respond", customID)
+	}
+	return responses[len(responses)-1].Response
+}
+
+// newTestUser builds a Discord user for a test
+func newTestUser(id, username string) *discordgo.User {
+	return &discordgo.User{ID: id, Username: username}
+}
+
+// responseEmbed returns the single embed of a response
+func responseEmbed(t *testing.T, resp *discordgo.InteractionResponse) *discordgo.MessageEmbed {
+	t.Helper()
+	if resp.Data == nil || len(resp.Data.Embeds) != 1 {
+		t.Fatalf("Expected one embed in response, got %+v", resp.Data)
+	}
+	return resp.Data.Embeds[0]
+}
+
+// assertTitle fails the test unless the response embed has the given title
+func assertTitle(t *testing.T, resp *discordgo.InteractionResponse, title string) *discordgo.MessageEmbed {
+	t.Helper()
+	embed := responseEmbed(t, resp)
+	if embed.Title != title {
+		t.Fatalf("Expected title %q, got %q (%s)", title, embed.Title, embed.Description)
+	}
+	return embed
+}
+
+// assertError fails the test unless the response is an error containing the given text
+func assertError(t *testing.T, resp *discordgo.InteractionResponse, contains string) {
+	t.Helper()
+	embed := assertTitle(t, resp, "Error")
+	if !strings.Contains(embed.Description, contains) {
+		t.Errorf("Expected error containing %q, got %q", contains, embed.Description)
+	}
+}
+
+// isEphemeral reports whether a response is only visible to the invoking user
+func isEphemeral(resp *discordgo.InteractionResponse) bool {
+	return resp.Data != nil && resp.Data.Flags&discordgo.MessageFlagsEphemeral != 0
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -21,15 +22,15 @@ var helpCategories = []HelpCategory{
 		ID:          "focus",
 		Name:        "Goal Tracking",
 		Emoji:       "\U0001F3AF", // Target emoji
-		Description: "Manage your 2-week Focus Periods",
-		Commands:    "`/focus start` - Start a new 2-week Focus Period\n`/focus add <goal>` - Add a goal (AI calculates points)\n`/focus complete <#>` - Mark a goal as completed\n`/focus list` - View your current goals\n`/focus status` - See your progress overview",
+		Description: "Manage your Focus Periods",
+		Commands:    "`/focus start` - Start a new Focus Period\n`/focus add <goal> [description] [due]` - Add a goal (AI calculates points)\n`/focus complete <#>` - Mark a goal as completed\n`/focus edit <#> <goal>` - Reword a goal\n`/focus remove <#>` - Remove a goal\n`/focus reopen <#>` - Undo a completion\n`/focus move <#> <position>` - Reorder your goals\n`/focus step add <#> <step>` - Break a goal into steps\n`/focus step check <#> <step>` - Check off a step\n`/focus appeal <#> <points> <reason>` - Ask for a goal to be re-scored\n`/focus list` - View your current goals\n`/focus status` - See your progress overview",
 	},
 	{
 		ID:          "standup",
 		Name:        "Daily Check-ins",
 		Emoji:       "\U0001F4DD", // Memo emoji
 		Description: "Post daily standups and track streaks",
-		Commands:    "`/standup post` - Post your daily standup\n`/standup streak` - View your streak stats\n`/standup leaderboard` - View streak rankings\n`/standup history` - View recent standups",
+		Commands:    "`/standup post` - Opens a form for your daily standup\n`/standup streak` - View your streak stats\n`/standup buy-freeze` - Buy a freeze that covers a missed day\n`/standup leaderboard` - View streak rankings\n`/standup history` - View recent standups\n`/blocker list` - See who's blocked and could use help\n`/blocker resolve <id> [helper]` - Close a blocker and credit who helped",
 	},
 	{
 		ID:          "win",
@@ -57,7 +58,7 @@ var helpCategories = []HelpCategory{
 		Name:        "Leaderboards",
 		Emoji:       "\U0001F3C6", // Trophy emoji
 		Description: "View community rankings",
-		Commands:    "`/leaderboard alltime` - All-time point rankings\n`/leaderboard sprint` - Current sprint rankings",
+		Commands:    "`/leaderboard alltime` - All-time point rankings\n`/leaderboard sprint` - Current sprint rankings\n`/leaderboard season [name]` - Past seasons' final standings\n`/points history [user]` - See where points came from",
 	},
 	{
 		ID:          "resource",
@@ -73,12 +74,19 @@ var helpCategories = []HelpCategory{
 		Description: "Manage channels in your project category",
 		Commands:    "**User Commands**\n`/project create-channel` - Create a text, voice, or thread channel\n`/project list-channels` - View your channels and quota\n\n**Admin Commands**\n`/project admin setup` - Map a role to a category\n`/project admin remove-mapping` - Remove a mapping\n`/project admin list-mappings` - Show all mappings",
 	},
+	{
+		ID:          "settings",
+		Name:        "Settings",
+		Emoji:       "\U0001F6E0\uFE0F", // Hammer and wrench emoji
+		Description: "Personal preferences",
+		Commands:    "`/settings timezone [zone]` - View or set your timezone for standups and reminders\n`/notifications set` - Get a reminder type by channel, DM, or not at all\n`/notifications quiet-hours` - Hold reminders during set hours\n`/notifications quiet-hours-off` - Remove quiet hours\n`/notifications show` - View your notification settings",
+	},
 	{
 		ID:          "admin",
 		Name:        "Admin",
 		Emoji:       "\u2699\uFE0F", // Gear emoji
 		Description: "Server configuration",
-		Commands:    "`/config leaderboard-channel` - Set the leaderboard channel\n`/config wins-channel` - Set the wins announcement channel\n`/config mrr-channel` - Set the MRR milestone channel",
+		Commands:    "`/config leaderboard-channel` - Set the leaderboard channel\n`/config wins-channel` - Set the wins announcement channel\n`/config mrr-channel` - Set the MRR milestone channel\n`/config help-channel [channel]` - Post new blockers for help\n`/config reminders channel` - Set the reminder channel\n`/config reminders time <hour>` - Set the reminder hour\n`/config reminders toggle` - Turn focus, standup, challenge or MRR reminders on or off\n`/config focus length <days>` - Set the Focus Period length\n`/config focus reminders <points>` - Set when Focus Period reminders are sent\n`/config focus step-points` - Award points for checked steps\n`/config sprints mode` - Turn cohort sprints on or off\n`/config sprints create <name> <start>` - Schedule a cohort sprint\n`/config sprints list` - Show running and upcoming sprints\n`/config guardrails settings` - Guard against farming points\n`/config guardrails queue` - Review held completions\n`/config standup threads [channel]` - Open a daily standup thread\n`/config standup rest-days <days>` - Set days that don't break streaks\n`/config standup digest [channel]` - Set the weekly standup digest channel\n`/config timezone <zone>` - Set the server's default timezone\n`/config jobs` - Show scheduled jobs and their last run\n`/admin points grant|revoke <user> <amount> <reason>` - Adjust a member's points\n`/admin season archive <name>` - End the season and reset the leaderboard\n`/admin standup backfill <user> <date>` - Record a missed standup and rebuild the streak",
 	},
 }
 
@@ -93,7 +101,7 @@ func helpCommand() *Command {
 	}
 }
 
-func handleHelp(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func handleHelp(s discord.Session, i *discordgo.InteractionCreate) {
 	embed := buildMainHelpEmbed()
 	selectMenu := buildCategorySelectMenu()
 
@@ -121,12 +129,12 @@ func buildMainHelpEmbed() *discordgo.MessageEmbed {
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Name:   "Quick Start",
-				Value:  "`/focus start` - Begin your 2-week journey\n`/standup post` - Daily check-in\n`/win share` - Celebrate your wins",
+				Value:  "`/focus start` - Begin your Focus Period\n`/standup post` - Daily check-in\n`/win share` - Celebrate your wins",
 				Inline: false,
 			},
 			{
 				Name:   "Categories",
-				Value:  "\U0001F3AF Goal Tracking \u2022 \U0001F4DD Daily Check-ins \u2022 \U0001F389 Wins\n\U0001F91D Accountability \u2022 \U0001F4B0 Revenue \u2022 \U0001F3C6 Leaderboards\n\U0001F4DA Resources \u2022 \U0001F4C1 Project Channels \u2022 \u2699\uFE0F Admin",
+				Value:  "\U0001F3AF Goal Tracking \u2022 \U0001F4DD Daily Check-ins \u2022 \U0001F389 Wins\n\U0001F91D Accountability \u2022 \U0001F4B0 Revenue \u2022 \U0001F3C6 Leaderboards\n\U0001F4DA Resources \u2022 \U0001F4C1 Project Channels\n\U0001F6E0\uFE0F Settings \u2022 \u2699\uFE0F Admin",
 				Inline: false,
 			},
 		},
@@ -157,7 +165,7 @@ func buildCategorySelectMenu() discordgo.SelectMenu {
 }
 
 // HandleHelpComponent handles select menu interactions for the help system
-func HandleHelpComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func HandleHelpComponent(s discord.Session, i *discordgo.InteractionCreate) {
 	data := i.MessageComponentData()
 	if data.CustomID != "help_category_select" {
 		return
@@ -6,11 +6,12 @@ import (
 	"strings"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
 	"github.com/bwmarrin/discordgo"
 )
 
 // leaderboardCommand creates the /leaderboard command
-func leaderboardCommand() *Command {
+func leaderboardCommand(store *database.Store) *Command {
 	return &Command{
 		Definition: &discordgo.ApplicationCommand{
 			Name:        "leaderboard",
@@ -26,13 +27,28 @@ func leaderboardCommand() *Command {
 					Description: "View current sprint leaderboard rankings",
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 				},
+				{
+					Name:        "season",
+					Description: "View past seasons' final standings",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "name",
+							Description: "The season to view (leave empty to list past seasons)",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Required:    false,
+						},
+					},
+				},
 			},
 		},
-		Handler: handleLeaderboardCommand,
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleLeaderboardCommand(s, i, store)
+		},
 	}
 }
 
-func handleLeaderboardCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func handleLeaderboardCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
 	options := i.ApplicationCommandData().Options
 	if len(options) == 0 {
 		respondWithError(s, i, "Invalid command usage")
@@ -47,16 +63,24 @@ func handleLeaderboardCommand(s *discordgo.Session, i *discordgo.InteractionCrea
 
 	switch subCommand {
 	case "alltime":
-		handleLeaderboardAllTime(s, i, guildID)
+		handleLeaderboardAllTime(s, i, store, guildID)
 	case "sprint":
-		handleLeaderboardSprint(s, i, guildID)
+		handleLeaderboardSprint(s, i, store, guildID)
+	case "season":
+		name := ""
+		for _, opt := range options[0].Options {
+			if opt.Name == "name" {
+				name = opt.StringValue()
+			}
+		}
+		handleLeaderboardSeason(s, i, store, guildID, name)
 	default:
 		respondWithError(s, i, "Unknown subcommand")
 	}
 }
 
-func handleLeaderboardAllTime(s *discordgo.Session, i *discordgo.InteractionCreate, guildID string) {
-	entries, err := database.GetAllTimeLeaderboard(guildID, 10)
+func handleLeaderboardAllTime(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string) {
+	entries, err := store.GetAllTimeLeaderboard(guildID, 10)
 	if err != nil {
 		log.Printf("Error fetching all-time leaderboard: %v", err)
 		respondWithError(s, i, "Failed to fetch leaderboard.")
@@ -103,8 +127,8 @@ func handleLeaderboardAllTime(s *discordgo.Session, i *discordgo.InteractionCrea
 	respondWithEmbed(s, i, embed)
 }
 
-func handleLeaderboardSprint(s *discordgo.Session, i *discordgo.InteractionCreate, guildID string) {
-	entries, err := database.GetSprintLeaderboard(guildID, 10)
+func handleLeaderboardSprint(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string) {
+	entries, err := store.GetSprintLeaderboard(guildID, 10)
 	if err != nil {
 		log.Printf("Error fetching sprint leaderboard: %v", err)
 		respondWithError(s, i, "Failed to fetch leaderboard.")
@@ -150,3 +174,105 @@ func handleLeaderboardSprint(s *discordgo.Session, i *discordgo.InteractionCreat
 
 	respondWithEmbed(s, i, embed)
 }
+
+func handleLeaderboardSeason(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID, name string) {
+	if name == "" {
+		handleLeaderboardSeasonList(s, i, store, guildID)
+		return
+	}
+
+	season, err := store.GetSeason(guildID, name)
+	if err != nil {
+		log.Printf("Error fetching season: %v", err)
+		respondWithError(s, i, "Failed to fetch leaderboard.")
+		return
+	}
+	if season == nil {
+		respondWithError(s, i, fmt.Sprintf("There's no season named \"%s\". Use `/leaderboard season` to list past seasons.", name))
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("🏁 %s - Final Standings", season.Name),
+		Description: seasonStandingsText(season.Standings, 10),
+		Color:       0xFFD700, // Gold
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: seasonDates(season),
+		},
+	}
+
+	respondWithEmbed(s, i, embed)
+}
+
+func handleLeaderboardSeasonList(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string) {
+	seasons, err := store.GetSeasons(guildID)
+	if err != nil {
+		log.Printf("Error fetching seasons: %v", err)
+		respondWithError(s, i, "Failed to fetch leaderboard.")
+		return
+	}
+
+	if len(seasons) == 0 {
+		embed := &discordgo.MessageEmbed{
+			Title:       "Past Seasons",
+			Description: "No seasons have been archived yet.\n\nThe current standings are on `/leaderboard alltime`.",
+			Color:       0xFFA500, // Orange
+		}
+		respondWithEmbed(s, i, embed)
+		return
+	}
+
+	var seasonsText strings.Builder
+	for _, season := range seasons {
+		seasonsText.WriteString(fmt.Sprintf("**%s** · %s", season.Name, seasonDates(&season)))
+		if len(season.Standings) > 0 {
+			champion := season.Standings[0]
+			seasonsText.WriteString(fmt.Sprintf("\n🥇 %s - %d points", champion.Username, champion.Points))
+		}
+		seasonsText.WriteString("\n\n")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🏁 Past Seasons",
+		Description: seasonsText.String(),
+		Color:       0xFFD700, // Gold
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Use /leaderboard season <name> for a season's final standings",
+		},
+	}
+
+	respondWithEmbed(s, i, embed)
+}
+
+// seasonStandingsText lists up to limit of a season's standings with medals for the top three
+func seasonStandingsText(standings []database.SeasonStanding, limit int) string {
+	var b strings.Builder
+	for idx, standing := range standings {
+		if idx == limit {
+			break
+		}
+		medal := ""
+		switch standing.Rank {
+		case 1:
+			medal = "🥇"
+		case 2:
+			medal = "🥈"
+		case 3:
+			medal = "🥉"
+		default:
+			medal = fmt.Sprintf("`#%d`", standing.Rank)
+		}
+
+		b.WriteString(fmt.Sprintf("%s **%s** - %d points (%d tasks)\n",
+			medal, standing.Username, standing.Points, standing.TasksCount))
+	}
+	return b.String()
+}
+
+// seasonDates describes when a season ran
+func seasonDates(season *database.Season) string {
+	if season.StartedAt == nil {
+		return "Ended " + season.EndedAt.Format("Jan 2, 2006")
+	}
+	return fmt.Sprintf("%s - %s", season.StartedAt.Format("Jan 2, 2006"), season.EndedAt.Format("Jan 2, 2006"))
+}
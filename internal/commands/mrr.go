@@ -6,11 +6,12 @@ import (
 	"strings"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
 	"github.com/bwmarrin/discordgo"
 )
 
 // mrrCommand creates the /mrr command group
-func mrrCommand() *Command {
+func mrrCommand(store *database.Store) *Command {
 	return &Command{
 		Definition: &discordgo.ApplicationCommand{
 			Name:        "mrr",
@@ -102,11 +103,13 @@ func mrrCommand() *Command {
 				},
 			},
 		},
-		Handler: handleMRRCommand,
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleMRRCommand(s, i, store)
+		},
 	}
 }
 
-func handleMRRCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func handleMRRCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
 	options := i.ApplicationCommandData().Options
 	if len(options) == 0 {
 		respondWithError(s, i, "Invalid command usage")
@@ -128,7 +131,7 @@ func handleMRRCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	}
 
 	// Get or create user in database
-	user, err := database.GetOrCreateUser(userID, guildID, username)
+	user, err := store.GetOrCreateUser(userID, guildID, username)
 	if err != nil {
 		log.Printf("Error getting/creating user: %v", err)
 		respondWithError(s, i, "Failed to process your request. Please try again.")
@@ -137,29 +140,29 @@ func handleMRRCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 	switch subCommand {
 	case "update":
-		handleMRRUpdate(s, i, user, guildID, options[0].Options)
+		handleMRRUpdate(s, i, store, user, guildID, options[0].Options)
 	case "public":
-		handleMRRPublic(s, i, user, guildID)
+		handleMRRPublic(s, i, store, user, guildID)
 	case "private":
-		handleMRRPrivate(s, i, user, guildID)
+		handleMRRPrivate(s, i, store, user, guildID)
 	case "history":
 		months := 6
 		if len(options[0].Options) > 0 {
 			months = int(options[0].Options[0].IntValue())
 		}
-		handleMRRHistory(s, i, user, guildID, months)
+		handleMRRHistory(s, i, store, user, guildID, months)
 	case "leaderboard":
-		handleMRRLeaderboard(s, i, guildID)
+		handleMRRLeaderboard(s, i, store, guildID)
 	case "stats":
-		handleMRRStats(s, i, user, guildID)
+		handleMRRStats(s, i, store, user, guildID)
 	case "set-channel":
-		handleMRRSetChannel(s, i, user, guildID, options[0].Options)
+		handleMRRSetChannel(s, i, store, user, guildID, options[0].Options)
 	default:
 		respondWithError(s, i, "Unknown subcommand")
 	}
 }
 
-func handleMRRUpdate(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleMRRUpdate(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	var amount float64
 	var currency, note string
 
@@ -179,13 +182,13 @@ func handleMRRUpdate(s *discordgo.Session, i *discordgo.InteractionCreate, user
 	}
 
 	// Get previous MRR for comparison
-	previousEntry, _ := database.GetLatestMRR(user.ID, guildID)
+	previousEntry, _ := store.GetLatestMRR(user.ID, guildID)
 	var growth float64
 	if previousEntry != nil {
 		growth = database.GetMRRGrowth(amount, previousEntry.Amount)
 	}
 
-	entry, milestone, err := database.CreateMRREntry(user.ID, guildID, amount, currency, note)
+	entry, milestone, err := store.CreateMRREntry(user.ID, guildID, amount, currency, note)
 	if err != nil {
 		log.Printf("Error creating MRR entry: %v", err)
 		respondWithError(s, i, "Failed to update your MRR.")
@@ -239,9 +242,9 @@ func handleMRRUpdate(s *discordgo.Session, i *discordgo.InteractionCreate, user
 		}
 
 		// Post to MRR channel if configured and user is public
-		settings, _ := database.GetMRRSettings(user.ID, guildID)
+		settings, _ := store.GetMRRSettings(user.ID, guildID)
 		if settings != nil && settings.IsPublic {
-			mrrChannel, _ := database.GetMRRChannel(guildID)
+			mrrChannel, _ := store.GetMRRChannel(guildID)
 			if mrrChannel != "" {
 				channelEmbed := &discordgo.MessageEmbed{
 					Title:       "🎉 Milestone Reached!",
@@ -266,8 +269,8 @@ func handleMRRUpdate(s *discordgo.Session, i *discordgo.InteractionCreate, user
 	}
 }
 
-func handleMRRPublic(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string) {
-	err := database.UpdateMRRVisibility(user.ID, guildID, true)
+func handleMRRPublic(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string) {
+	err := store.UpdateMRRVisibility(user.ID, guildID, true)
 	if err != nil {
 		log.Printf("Error updating MRR visibility: %v", err)
 		respondWithError(s, i, "Failed to update visibility.")
@@ -286,8 +289,8 @@ func handleMRRPublic(s *discordgo.Session, i *discordgo.InteractionCreate, user
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleMRRPrivate(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string) {
-	err := database.UpdateMRRVisibility(user.ID, guildID, false)
+func handleMRRPrivate(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string) {
+	err := store.UpdateMRRVisibility(user.ID, guildID, false)
 	if err != nil {
 		log.Printf("Error updating MRR visibility: %v", err)
 		respondWithError(s, i, "Failed to update visibility.")
@@ -306,8 +309,8 @@ func handleMRRPrivate(s *discordgo.Session, i *discordgo.InteractionCreate, user
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleMRRHistory(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, months int) {
-	entries, err := database.GetMRRHistory(user.ID, guildID, months)
+func handleMRRHistory(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, months int) {
+	entries, err := store.GetMRRHistory(user.ID, guildID, months)
 	if err != nil {
 		log.Printf("Error getting MRR history: %v", err)
 		respondWithError(s, i, "Failed to get your MRR history.")
@@ -366,8 +369,8 @@ func handleMRRHistory(s *discordgo.Session, i *discordgo.InteractionCreate, user
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleMRRLeaderboard(s *discordgo.Session, i *discordgo.InteractionCreate, guildID string) {
-	entries, err := database.GetMRRLeaderboard(guildID, 10)
+func handleMRRLeaderboard(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string) {
+	entries, err := store.GetMRRLeaderboard(guildID, 10)
 	if err != nil {
 		log.Printf("Error getting MRR leaderboard: %v", err)
 		respondWithError(s, i, "Failed to get the leaderboard.")
@@ -415,8 +418,8 @@ func handleMRRLeaderboard(s *discordgo.Session, i *discordgo.InteractionCreate,
 	respondWithEmbed(s, i, embed)
 }
 
-func handleMRRStats(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string) {
-	stats, err := database.GetMRRStats(user.ID, guildID)
+func handleMRRStats(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string) {
+	stats, err := store.GetMRRStats(user.ID, guildID)
 	if err != nil {
 		log.Printf("Error getting MRR stats: %v", err)
 		respondWithError(s, i, "Failed to get your MRR stats.")
@@ -515,12 +518,12 @@ func getVisibilityStatus(isPublic bool) string {
 	return "🔒 Private"
 }
 
-func handleMRRSetChannel(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleMRRSetChannel(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	var channelID string
 
 	for _, opt := range options {
 		if opt.Name == "channel" {
-			channel := opt.ChannelValue(s)
+			channel := optionChannel(s, i, opt)
 			if channel != nil {
 				channelID = channel.ID
 			}
@@ -532,7 +535,7 @@ func handleMRRSetChannel(s *discordgo.Session, i *discordgo.InteractionCreate, u
 		return
 	}
 
-	err := database.UpdateMRRProjectChannel(user.ID, guildID, channelID)
+	err := store.UpdateMRRProjectChannel(user.ID, guildID, channelID)
 	if err != nil {
 		log.Printf("Error updating MRR project channel: %v", err)
 		respondWithError(s, i, "Failed to set project channel.")
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord/discordtest"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestMRRUpdateTracksGrowth(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+
+	resp := h.run(alice, nil, "mrr", discordtest.SubCommand("update", discordtest.Number("amount", 50)))
+	embed := assertTitle(t, resp, "MRR Updated!")
+	if !strings.Contains(embed.Description, "$50.00") {
+		t.Errorf("Expected amount in description, got %q", embed.Description)
+	}
+
+	resp = h.run(alice, nil, "mrr", discordtest.SubCommand("update",
+		discordtest.Number("amount", 75),
+		discordtest.String("currency", "EUR"),
+		discordtest.String("note", "New annual plan"),
+	))
+	embed = assertTitle(t, resp, "MRR Updated!")
+	if !strings.Contains(embed.Description, "€75.00") {
+		t.Errorf("Expected euro amount in description, got %q", embed.Description)
+	}
+	if len(embed.Fields) < 2 || !strings.Contains(embed.Fields[0].Value, "+50.0%") {
+		t.Errorf("Expected growth and note fields, got %+v", embed.Fields)
+	}
+
+	// Nothing is posted publicly without a milestone
+	if messages := h.session.Messages(); len(messages) != 0 {
+		t.Errorf("Expected no channel messages, got %d", len(messages))
+	}
+}
+
+func TestMRRMilestoneCelebratedInChannel(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+
+	if err := h.store.UpdateMRRChannel(testGuildID, "channel-mrr"); err != nil {
+		t.Fatalf("Failed to set MRR channel: %v", err)
+	}
+
+	resp := h.run(alice, nil, "mrr", discordtest.SubCommand("public"))
+	assertTitle(t, resp, "MRR Now Public")
+
+	h.run(alice, nil, "mrr", discordtest.SubCommand("update", discordtest.Number("amount", 600)))
+
+	messages := h.session.MessagesIn("channel-mrr")
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 milestone message, got %d", len(messages))
+	}
+	if !strings.Contains(messages[0].Embed.Description, "$500 MRR") {
+		t.Errorf("Expected $500 milestone, got %q", messages[0].Embed.Description)
+	}
+
+	if reactions := h.session.Reactions(); len(reactions) != 2 || reactions[0].MessageID != messages[0].ID {
+		t.Errorf("Expected 2 reactions on the milestone message, got %+v", reactions)
+	}
+
+	followups := h.session.Followups()
+	if len(followups) != 1 || followups[0].Embeds[0].Title != "🎉 MRR Milestone Reached!" {
+		t.Errorf("Expected a milestone followup, got %+v", followups)
+	}
+}
+
+func TestMRRSetChannel(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+	channel := &discordgo.Channel{ID: "channel-project", Name: "my-project"}
+
+	resp := h.run(alice, nil, "mrr", discordtest.SubCommand("set-channel", discordtest.Channel("channel", channel)))
+	assertTitle(t, resp, "Project Channel Set")
+
+	user, _ := h.store.GetOrCreateUser(alice.ID, testGuildID, alice.Username)
+	settings, err := h.store.GetMRRSettings(user.ID, testGuildID)
+	if err != nil {
+		t.Fatalf("Failed to get MRR settings: %v", err)
+	}
+	if settings.ProjectChannelID != channel.ID {
+		t.Errorf("Expected project channel %s, got %s", channel.ID, settings.ProjectChannelID)
+	}
+}
@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
+	"github.com/bwmarrin/discordgo"
+)
+
+// reminderTypeLabels names each reminder feature for display
+var reminderTypeLabels = map[database.ReminderFeature]string{
+	database.ReminderFeatureFocus:     "Focus Periods",
+	database.ReminderFeatureStandup:   "Standups",
+	database.ReminderFeatureChallenge: "Challenges",
+	database.ReminderFeatureMRR:       "MRR",
+}
+
+// notificationsCommand creates the /notifications command for reminder delivery preferences
+func notificationsCommand(store *database.Store) *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name:        "notifications",
+			Description: "Choose how you receive reminders",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "set",
+					Description: "Choose how one type of reminder reaches you",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "type",
+							Description: "Which reminders to change",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Required:    true,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "Focus Periods", Value: string(database.ReminderFeatureFocus)},
+								{Name: "Standups", Value: string(database.ReminderFeatureStandup)},
+								{Name: "Challenges", Value: string(database.ReminderFeatureChallenge)},
+								{Name: "MRR", Value: string(database.ReminderFeatureMRR)},
+							},
+						},
+						{
+							Name:        "delivery",
+							Description: "Where the reminders go",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Required:    true,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "Channel", Value: string(database.DeliveryChannel)},
+								{Name: "Direct message", Value: string(database.DeliveryDM)},
+								{Name: "Off", Value: string(database.DeliveryOff)},
+							},
+						},
+					},
+				},
+				{
+					Name:        "quiet-hours",
+					Description: "Hold reminders during these hours in your timezone",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "start",
+							Description: "Hour quiet hours begin (0-23)",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+							MinValue:    floatPtr(0),
+							MaxValue:    23,
+						},
+						{
+							Name:        "end",
+							Description: "Hour quiet hours end (0-23)",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+							MinValue:    floatPtr(0),
+							MaxValue:    23,
+						},
+					},
+				},
+				{
+					Name:        "quiet-hours-off",
+					Description: "Remove your quiet hours",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+				},
+				{
+					Name:        "show",
+					Description: "Show your notification settings",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+				},
+			},
+		},
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleNotificationsCommand(s, i, store)
+		},
+	}
+}
+
+func handleNotificationsCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	if i.Member == nil || i.GuildID == "" {
+		respondWithError(s, i, "This command can only be used in a server.")
+		return
+	}
+
+	user, err := store.GetOrCreateUser(i.Member.User.ID, i.GuildID, i.Member.User.Username)
+	if err != nil {
+		log.Printf("Error getting/creating user: %v", err)
+		respondWithError(s, i, "Failed to process your request. Please try again.")
+		return
+	}
+
+	subCommand := options[0]
+	switch subCommand.Name {
+	case "set":
+		var feature database.ReminderFeature
+		var delivery database.Delivery
+		for _, opt := range subCommand.Options {
+			switch opt.Name {
+			case "type":
+				feature = database.ReminderFeature(opt.StringValue())
+			case "delivery":
+				delivery = database.Delivery(opt.StringValue())
+			}
+		}
+		err = store.SetNotificationDelivery(user.ID, i.GuildID, feature, delivery)
+	case "quiet-hours":
+		var start, end int
+		for _, opt := range subCommand.Options {
+			switch opt.Name {
+			case "start":
+				start = int(opt.IntValue())
+			case "end":
+				end = int(opt.IntValue())
+			}
+		}
+		if start == end {
+			respondWithError(s, i, "Quiet hours must start and end at different hours.")
+			return
+		}
+		err = store.SetQuietHours(user.ID, i.GuildID, start, end)
+	case "quiet-hours-off":
+		err = store.ClearQuietHours(user.ID, i.GuildID)
+	case "show":
+		// Nothing to change, just report the settings
+	default:
+		respondWithError(s, i, "Unknown subcommand")
+		return
+	}
+
+	if err != nil {
+		log.Printf("Error updating notification settings: %v", err)
+		respondWithError(s, i, "Failed to update your notification settings.")
+		return
+	}
+
+	handleNotificationsShow(s, i, store, user, i.GuildID, subCommand.Name != "show")
+}
+
+func handleNotificationsShow(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, updated bool) {
+	settings, err := store.GetNotificationSettings(user.ID, guildID)
+	if err != nil {
+		log.Printf("Error fetching notification settings: %v", err)
+		respondWithError(s, i, "Failed to fetch your notification settings.")
+		return
+	}
+
+	var deliveries strings.Builder
+	for _, feature := range database.ReminderFeatures {
+		label := "📢 Channel"
+		switch settings.DeliveryFor(feature) {
+		case database.DeliveryDM:
+			label = "✉️ Direct message"
+		case database.DeliveryOff:
+			label = "🔕 Off"
+		}
+		deliveries.WriteString(fmt.Sprintf("**%s:** %s\n", reminderTypeLabels[feature], label))
+	}
+
+	quietHours := "None"
+	if settings.QuietHoursStart != nil && settings.QuietHoursEnd != nil {
+		quietHours = fmt.Sprintf("%02d:00 - %02d:00 (%s)", *settings.QuietHoursStart, *settings.QuietHoursEnd, store.UserLocation(user.ID, guildID))
+	}
+
+	title := "Your Notifications"
+	if updated {
+		title = "Notifications Updated"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: deliveries.String(),
+		Color:       0x5865F2, // Discord blurple
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Quiet Hours",
+				Value:  quietHours,
+				Inline: false,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "If you have DMs closed, DM reminders are posted in the reminder channel instead",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
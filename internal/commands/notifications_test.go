@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord/discordtest"
+)
+
+func TestNotificationsSetAndQuietHours(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+
+	resp := h.run(alice, nil, "notifications", discordtest.SubCommand("set",
+		discordtest.String("type", "standup"),
+		discordtest.String("delivery", "dm"),
+	))
+	embed := assertTitle(t, resp, "Notifications Updated")
+	if !strings.Contains(embed.Description, "**Standups:** ✉️ Direct message") {
+		t.Errorf("Expected standups by DM, got %q", embed.Description)
+	}
+
+	resp = h.run(alice, nil, "notifications", discordtest.SubCommand("quiet-hours",
+		discordtest.Int("start", 9),
+		discordtest.Int("end", 9),
+	))
+	assertError(t, resp, "different hours")
+
+	resp = h.run(alice, nil, "notifications", discordtest.SubCommand("quiet-hours",
+		discordtest.Int("start", 22),
+		discordtest.Int("end", 7),
+	))
+	embed = assertTitle(t, resp, "Notifications Updated")
+	if !strings.HasPrefix(embed.Fields[0].Value, "22:00 - 07:00") {
+		t.Errorf("Expected quiet hours 22:00 - 07:00, got %q", embed.Fields[0].Value)
+	}
+
+	user, _ := h.store.GetOrCreateUser(alice.ID, testGuildID, alice.Username)
+	settings, err := h.store.GetNotificationSettings(user.ID, testGuildID)
+	if err != nil {
+		t.Fatalf("Failed to get notification settings: %v", err)
+	}
+	if settings.DeliveryFor(database.ReminderFeatureStandup) != database.DeliveryDM || !settings.InQuietHours(23) {
+		t.Errorf("Expected DM standups and quiet hours to be saved, got %+v", settings)
+	}
+
+	h.run(alice, nil, "notifications", discordtest.SubCommand("quiet-hours-off"))
+	resp = h.run(alice, nil, "notifications", discordtest.SubCommand("show"))
+	embed = assertTitle(t, resp, "Your Notifications")
+	if embed.Fields[0].Value != "None" {
+		t.Errorf("Expected quiet hours to be cleared, got %q", embed.Fields[0].Value)
+	}
+}
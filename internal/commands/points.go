@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
+	"github.com/bwmarrin/discordgo"
+)
+
+// pointsHistoryLimit is how many recent transactions /points history lists
+const pointsHistoryLimit = 10
+
+// pointsSourceLabels names each points source for display
+var pointsSourceLabels = map[string]string{
+	database.PointsSourceOpeningBalance: "Earlier points",
+	database.PointsSourceFocusPeriod:    "Focus Period",
+	database.PointsSourceTaskCompleted:  "Goals completed",
+	database.PointsSourceTaskSteps:      "Goal steps",
+	database.PointsSourceTaskRevoked:    "Goals reopened or removed",
+	database.PointsSourceAppeal:         "Appeals",
+	database.PointsSourceReview:         "Reviewed completions",
+	database.PointsSourceStandup:        "Standups",
+	database.PointsSourceWin:            "Wins shared",
+	database.PointsSourceChallenge:      "Challenges",
+	database.PointsSourceAdjustment:     "Admin adjustments",
+	database.PointsSourceSeasonReset:    "Season resets",
+	database.PointsSourceStreakFreeze:   "Streak freezes",
+	database.PointsSourceBlockerHelp:    "Helping unblock others",
+}
+
+// pointsSourceLabel returns the display name of a points source
+func pointsSourceLabel(sourceType string) string {
+	if label, ok := pointsSourceLabels[sourceType]; ok {
+		return label
+	}
+	return sourceType
+}
+
+// pointsCommand creates the /points command
+func pointsCommand(store *database.Store) *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name:        "points",
+			Description: "See where points come from",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "history",
+					Description: "View a breakdown of points and recent awards",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "user",
+							Description: "The member to look up (leave empty for yourself)",
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Required:    false,
+						},
+					},
+				},
+			},
+		},
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handlePointsCommand(s, i, store)
+		},
+	}
+}
+
+func handlePointsCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	if i.Member == nil || i.GuildID == "" {
+		respondWithError(s, i, "Points commands can only be used in a server.")
+		return
+	}
+
+	switch options[0].Name {
+	case "history":
+		target := i.Member.User
+		for _, opt := range options[0].Options {
+			if opt.Name == "user" {
+				target = optionUser(i, opt)
+			}
+		}
+		handlePointsHistory(s, i, store, i.GuildID, target)
+	default:
+		respondWithError(s, i, "Unknown subcommand")
+	}
+}
+
+func handlePointsHistory(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string, target *discordgo.User) {
+	user, err := store.GetOrCreateUser(target.ID, guildID, target.Username)
+	if err != nil {
+		log.Printf("Error getting/creating user: %v", err)
+		respondWithError(s, i, "Failed to process your request. Please try again.")
+		return
+	}
+
+	breakdown, err := store.GetPointsBreakdown(user.ID, guildID)
+	if err != nil {
+		log.Printf("Error fetching points breakdown: %v", err)
+		respondWithError(s, i, "Failed to fetch points history.")
+		return
+	}
+	history, err := store.GetPointsHistory(user.ID, guildID, pointsHistoryLimit)
+	if err != nil {
+		log.Printf("Error fetching points history: %v", err)
+		respondWithError(s, i, "Failed to fetch points history.")
+		return
+	}
+
+	name := target.Username
+	if target.ID == i.Member.User.ID {
+		name = "Your"
+	} else {
+		name += "'s"
+	}
+
+	if len(history) == 0 {
+		embed := &discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("%s Points", name),
+			Description: "No points earned in this server yet.\n\nComplete goals, post standups and share wins to earn points.",
+			Color:       0xFFA500, // Orange
+		}
+		respondWithEmbedEphemeral(s, i, embed, true)
+		return
+	}
+
+	total := 0
+	var breakdownText strings.Builder
+	for _, entry := range breakdown {
+		total += entry.Points
+		breakdownText.WriteString(fmt.Sprintf("%s: **%+d** (%d)\n", pointsSourceLabel(entry.SourceType), entry.Points, entry.Count))
+	}
+
+	var historyText strings.Builder
+	for _, entry := range history {
+		historyText.WriteString(fmt.Sprintf("`%+d` %s", entry.Amount, pointsSourceLabel(entry.SourceType)))
+		if entry.Note != "" {
+			historyText.WriteString(" · " + truncateString(entry.Note, 60))
+		}
+		historyText.WriteString(fmt.Sprintf(" · <t:%d:d>\n", entry.CreatedAt.Unix()))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("📒 %s Points", name),
+		Description: fmt.Sprintf("**%d** points in this server", total),
+		Color:       0x5865F2, // Blurple
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Breakdown",
+				Value: breakdownText.String(),
+			},
+			{
+				Name:  "Recent",
+				Value: historyText.String(),
+			},
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord/discordtest"
+)
+
+func TestPointsHistory(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+	bob := newTestUser("user-bob", "bob")
+
+	resp := h.run(alice, nil, "points", discordtest.SubCommand("history"))
+	assertTitle(t, resp, "Your Points")
+
+	h.run(alice, nil, "focus", discordtest.SubCommand("start"))
+	h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", "Ship landing page")))
+	h.run(alice, nil, "focus", discordtest.SubCommand("add", discordtest.String("goal", "Call ten customers")))
+	h.run(alice, nil, "focus", discordtest.SubCommand("complete", discordtest.Int("number", 1)))
+	h.run(alice, nil, "focus", discordtest.SubCommand("complete", discordtest.Int("number", 2)))
+	h.run(alice, nil, "focus", discordtest.SubCommand("reopen", discordtest.Int("number", 2)))
+
+	resp = h.run(alice, nil, "points", discordtest.SubCommand("history"))
+	embed := assertTitle(t, resp, "📒 Your Points")
+	if !isEphemeral(resp) {
+		t.Error("Expected /points history to be ephemeral")
+	}
+	if embed.Description != "**5** points in this server" {
+		t.Errorf("Expected 5 points, got %q", embed.Description)
+	}
+	if len(embed.Fields) != 2 {
+		t.Fatalf("Expected breakdown and recent fields, got %+v", embed.Fields)
+	}
+	if !strings.Contains(embed.Fields[0].Value, "Goals completed: **+10** (2)") || !strings.Contains(embed.Fields[0].Value, "Goals reopened or removed: **-5** (1)") {
+		t.Errorf("Expected a breakdown by source, got %q", embed.Fields[0].Value)
+	}
+	if !strings.HasPrefix(embed.Fields[1].Value, "`-5` Goals reopened or removed · Call ten customers") {
+		t.Errorf("Expected the newest transaction first, got %q", embed.Fields[1].Value)
+	}
+
+	// Members can look up each other's points
+	resp = h.run(bob, nil, "points", discordtest.SubCommand("history", discordtest.User("user", alice)))
+	embed = assertTitle(t, resp, "📒 alice's Points")
+	if embed.Description != "**5** points in this server" {
+		t.Errorf("Expected alice's 5 points, got %q", embed.Description)
+	}
+}
@@ -7,10 +7,11 @@ import (
 	"strings"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
 	"github.com/bwmarrin/discordgo"
 )
 
-func projectCommand() *Command {
+func projectCommand(store *database.Store) *Command {
 	return &Command{
 		Definition: &discordgo.ApplicationCommand{
 			Name:        "project",
@@ -111,11 +112,13 @@ func projectCommand() *Command {
 				},
 			},
 		},
-		Handler: handleProjectCommand,
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleProjectCommand(s, i, store)
+		},
 	}
 }
 
-func handleProjectCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func handleProjectCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
 	if i.GuildID == "" || i.Member == nil {
 		respondWithError(s, i, "This command can only be used in a server.")
 		return
@@ -129,11 +132,11 @@ func handleProjectCommand(s *discordgo.Session, i *discordgo.InteractionCreate)
 
 	switch options[0].Name {
 	case "admin":
-		handleProjectAdmin(s, i, options[0].Options)
+		handleProjectAdmin(s, i, store, options[0].Options)
 	case "create-channel":
-		handleProjectCreateChannel(s, i, options[0].Options)
+		handleProjectCreateChannel(s, i, store, options[0].Options)
 	case "list-channels":
-		handleProjectListChannels(s, i)
+		handleProjectListChannels(s, i, store)
 	default:
 		respondWithError(s, i, "Unknown subcommand.")
 	}
@@ -141,7 +144,7 @@ func handleProjectCommand(s *discordgo.Session, i *discordgo.InteractionCreate)
 
 // ==================== Admin Handlers ====================
 
-func handleProjectAdmin(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleProjectAdmin(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	if !hasAdminRole(s, i) {
 		embed := &discordgo.MessageEmbed{
 			Title:       "Permission Denied",
@@ -159,31 +162,31 @@ func handleProjectAdmin(s *discordgo.Session, i *discordgo.InteractionCreate, op
 
 	switch options[0].Name {
 	case "setup":
-		handleProjectAdminSetup(s, i, options[0].Options)
+		handleProjectAdminSetup(s, i, store, options[0].Options)
 	case "remove-mapping":
-		handleProjectAdminRemoveMapping(s, i, options[0].Options)
+		handleProjectAdminRemoveMapping(s, i, store, options[0].Options)
 	case "list-mappings":
-		handleProjectAdminListMappings(s, i)
+		handleProjectAdminListMappings(s, i, store)
 	default:
 		respondWithError(s, i, "Unknown admin subcommand.")
 	}
 }
 
-func handleProjectAdminSetup(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleProjectAdminSetup(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
 	for _, opt := range options {
 		optionMap[opt.Name] = opt
 	}
 
-	role := optionMap["role"].RoleValue(s, i.GuildID)
-	channel := optionMap["category"].ChannelValue(s)
+	role := optionRole(i, optionMap["role"])
+	channel := optionChannel(s, i, optionMap["category"])
 
 	maxChannels := database.DefaultMaxChannels
 	if opt, ok := optionMap["max-channels"]; ok {
 		maxChannels = int(opt.IntValue())
 	}
 
-	mapping, err := database.CreateProjectMapping(
+	mapping, err := store.CreateProjectMapping(
 		i.GuildID, role.ID, role.Name,
 		channel.ID, channel.Name, maxChannels,
 	)
@@ -205,10 +208,10 @@ func handleProjectAdminSetup(s *discordgo.Session, i *discordgo.InteractionCreat
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleProjectAdminRemoveMapping(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
-	role := options[0].RoleValue(s, i.GuildID)
+func handleProjectAdminRemoveMapping(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	role := optionRole(i, options[0])
 
-	err := database.RemoveProjectMapping(i.GuildID, role.ID)
+	err := store.RemoveProjectMapping(i.GuildID, role.ID)
 	if err != nil {
 		log.Printf("Error removing project mapping: %v", err)
 		respondWithError(s, i, fmt.Sprintf("Failed to remove mapping: %s", err.Error()))
@@ -223,8 +226,8 @@ func handleProjectAdminRemoveMapping(s *discordgo.Session, i *discordgo.Interact
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleProjectAdminListMappings(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	mappings, err := database.GetProjectMappings(i.GuildID)
+func handleProjectAdminListMappings(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
+	mappings, err := store.GetProjectMappings(i.GuildID)
 	if err != nil {
 		log.Printf("Error fetching project mappings: %v", err)
 		respondWithError(s, i, "Failed to fetch mappings.")
@@ -255,7 +258,7 @@ func handleProjectAdminListMappings(s *discordgo.Session, i *discordgo.Interacti
 
 // ==================== User Handlers ====================
 
-func handleProjectCreateChannel(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleProjectCreateChannel(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
 	for _, opt := range options {
 		optionMap[opt.Name] = opt
@@ -270,7 +273,7 @@ func handleProjectCreateChannel(s *discordgo.Session, i *discordgo.InteractionCr
 		return
 	}
 
-	mappings, err := database.GetUserMappings(i.GuildID, i.Member.Roles)
+	mappings, err := store.GetUserMappings(i.GuildID, i.Member.Roles)
 	if err != nil {
 		log.Printf("Error fetching user mappings: %v", err)
 		respondWithError(s, i, "Failed to look up your project roles.")
@@ -318,7 +321,7 @@ func handleProjectCreateChannel(s *discordgo.Session, i *discordgo.InteractionCr
 	}
 
 	// Check channel limit
-	count, err := database.CountUserChannelsInCategory(i.GuildID, i.Member.User.ID, mapping.CategoryID)
+	count, err := store.CountUserChannelsInCategory(i.GuildID, i.Member.User.ID, mapping.CategoryID)
 	if err != nil {
 		log.Printf("Error counting user channels: %v", err)
 		respondWithError(s, i, "Failed to check your channel quota.")
@@ -375,7 +378,7 @@ func handleProjectCreateChannel(s *discordgo.Session, i *discordgo.InteractionCr
 			respondWithError(s, i, "When creating a thread, you must specify the `parent-channel` option.")
 			return
 		}
-		parentChannel := parentOpt.ChannelValue(s)
+		parentChannel := optionChannel(s, i, parentOpt)
 
 		// Verify the parent channel is in the same category
 		if parentChannel.ParentID != mapping.CategoryID {
@@ -401,7 +404,7 @@ func handleProjectCreateChannel(s *discordgo.Session, i *discordgo.InteractionCr
 	}
 
 	// Record in DB
-	_, err = database.CreateProjectChannel(
+	_, err = store.CreateProjectChannel(
 		i.GuildID, i.Member.User.ID, createdID,
 		mapping.CategoryID, mapping.RoleID, sanitized, channelType,
 	)
@@ -422,13 +425,13 @@ func handleProjectCreateChannel(s *discordgo.Session, i *discordgo.InteractionCr
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleProjectListChannels(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func handleProjectListChannels(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
 	if len(i.Member.Roles) == 0 {
 		respondWithError(s, i, "You don't have any project roles.")
 		return
 	}
 
-	mappings, err := database.GetUserMappings(i.GuildID, i.Member.Roles)
+	mappings, err := store.GetUserMappings(i.GuildID, i.Member.Roles)
 	if err != nil {
 		log.Printf("Error fetching user mappings: %v", err)
 		respondWithError(s, i, "Failed to look up your project roles.")
@@ -443,7 +446,7 @@ func handleProjectListChannels(s *discordgo.Session, i *discordgo.InteractionCre
 	var fields []*discordgo.MessageEmbedField
 
 	for _, m := range mappings {
-		channels, err := database.GetUserChannelsInCategory(i.GuildID, i.Member.User.ID, m.CategoryID)
+		channels, err := store.GetUserChannelsInCategory(i.GuildID, i.Member.User.ID, m.CategoryID)
 		if err != nil {
 			log.Printf("Error fetching channels for category %s: %v", m.CategoryID, err)
 			continue
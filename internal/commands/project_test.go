@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord/discordtest"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestProjectAdminRequiresAdminRole(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+
+	resp := h.run(alice, nil, "project", discordtest.SubCommandGroup("admin", discordtest.SubCommand("list-mappings")))
+	assertTitle(t, resp, "Permission Denied")
+}
+
+func TestProjectCreateChannelWithinQuota(t *testing.T) {
+	h := newTestHarness(t)
+	admin := newTestUser("user-admin", "admin")
+	alice := newTestUser("user-alice", "alice")
+
+	role := &discordgo.Role{ID: "role-acme", Name: "acme"}
+	category := &discordgo.Channel{ID: "category-acme", Name: "Acme", Type: discordgo.ChannelTypeGuildCategory}
+	h.session.AddChannel(category)
+
+	resp := h.run(admin, []string{testAdminRoleID}, "project", discordtest.SubCommandGroup("admin", discordtest.SubCommand("setup",
+		discordtest.Role("role", role),
+		discordtest.Channel("category", category),
+		discordtest.Int("max-channels", 1),
+	)))
+	assertTitle(t, resp, "Project Mapping Created")
+
+	// Users without a mapped role are turned away
+	resp = h.run(alice, nil, "project", discordtest.SubCommand("create-channel",
+		discordtest.String("name", "Launch Plan"),
+		discordtest.String("type", "text"),
+	))
+	assertError(t, resp, "You don't have any project roles")
+
+	h.run(alice, []string{role.ID}, "project", discordtest.SubCommand("create-channel",
+		discordtest.String("name", "Launch Plan"),
+		discordtest.String("type", "text"),
+	))
+
+	created := h.session.CreatedChannels()
+	if len(created) != 1 {
+		t.Fatalf("Expected 1 created channel, got %d", len(created))
+	}
+	if created[0].Name != "launch-plan" || created[0].ParentID != category.ID {
+		t.Errorf("Expected launch-plan under %s, got %s under %s", category.ID, created[0].Name, created[0].ParentID)
+	}
+
+	// The mapping only allows one channel per user
+	resp = h.run(alice, []string{role.ID}, "project", discordtest.SubCommand("create-channel",
+		discordtest.String("name", "Second"),
+		discordtest.String("type", "text"),
+	))
+	assertError(t, resp, "maximum of 1 channels")
+	if len(h.session.CreatedChannels()) != 1 {
+		t.Error("Expected no further channels to be created")
+	}
+}
@@ -9,11 +9,12 @@ import (
 	"time"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
 	"github.com/bwmarrin/discordgo"
 )
 
 // resourceCommand creates the /resource command with all subcommands
-func resourceCommand() *Command {
+func resourceCommand(store *database.Store) *Command {
 	return &Command{
 		Definition: &discordgo.ApplicationCommand{
 			Name:        "resource",
@@ -159,12 +160,14 @@ func resourceCommand() *Command {
 				},
 			},
 		},
-		Handler: handleResourceCommand,
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleResourceCommand(s, i, store)
+		},
 	}
 }
 
 // handleResourceCommand routes to the appropriate subcommand handler
-func handleResourceCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func handleResourceCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
 	options := i.ApplicationCommandData().Options
 
 	if len(options) == 0 {
@@ -174,18 +177,18 @@ func handleResourceCommand(s *discordgo.Session, i *discordgo.InteractionCreate)
 
 	switch options[0].Name {
 	case "submit":
-		handleResourceSubmit(s, i, options[0].Options)
+		handleResourceSubmit(s, i, store, options[0].Options)
 	case "list":
-		handleResourceList(s, i, options[0].Options)
+		handleResourceList(s, i, store, options[0].Options)
 	case "private":
-		handleResourcePrivate(s, i, options[0].Options)
+		handleResourcePrivate(s, i, store, options[0].Options)
 	default:
 		respondError(s, i, "Unknown subcommand")
 	}
 }
 
 // handleResourceSubmit handles /resource submit
-func handleResourceSubmit(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleResourceSubmit(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	// Extract parameters
 	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
 	for _, opt := range options {
@@ -220,7 +223,7 @@ func handleResourceSubmit(s *discordgo.Session, i *discordgo.InteractionCreate,
 	username := i.Member.User.Username
 
 	// Check for duplicate URL
-	duplicate, err := database.CheckDuplicateURL(guildID, urlStr)
+	duplicate, err := store.CheckDuplicateURL(guildID, urlStr)
 	if err != nil {
 		log.Printf("Error checking duplicate URL: %v", err)
 		respondError(s, i, "Failed to check for duplicates. Please try again.")
@@ -232,7 +235,7 @@ func handleResourceSubmit(s *discordgo.Session, i *discordgo.InteractionCreate,
 	}
 
 	// Create the resource
-	resource, err := database.CreatePublicResource(guildID, userID, username, urlStr, title, description, category, tags)
+	resource, err := store.CreatePublicResource(guildID, userID, username, urlStr, title, description, category, tags)
 	if err != nil {
 		log.Printf("Error creating resource: %v", err)
 		respondError(s, i, "Failed to create resource. Please try again.")
@@ -305,14 +308,14 @@ func handleResourceSubmit(s *discordgo.Session, i *discordgo.InteractionCreate,
 
 	// Update resource with vote message details
 	expiresAt := time.Now().Add(time.Hour)
-	err = database.UpdateResourceVoteMessage(resource.ID, voteMsg.ID, i.ChannelID, expiresAt)
+	err = store.UpdateResourceVoteMessage(resource.ID, voteMsg.ID, i.ChannelID, expiresAt)
 	if err != nil {
 		log.Printf("Error updating vote message: %v", err)
 	}
 }
 
 // handleResourceList handles /resource list
-func handleResourceList(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleResourceList(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	// Extract parameters
 	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
 	for _, opt := range options {
@@ -330,7 +333,7 @@ func handleResourceList(s *discordgo.Session, i *discordgo.InteractionCreate, op
 	}
 
 	// Get approved resources
-	resources, err := database.GetApprovedPublicResources(i.GuildID, category, search)
+	resources, err := store.GetApprovedPublicResources(i.GuildID, category, search)
 	if err != nil {
 		log.Printf("Error fetching resources: %v", err)
 		respondError(s, i, "Failed to fetch resources. Please try again.")
@@ -394,7 +397,7 @@ func handleResourceList(s *discordgo.Session, i *discordgo.InteractionCreate, op
 }
 
 // handleResourcePrivate routes private resource subcommands
-func handleResourcePrivate(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleResourcePrivate(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	if len(options) == 0 {
 		respondError(s, i, "No subcommand provided")
 		return
@@ -402,18 +405,18 @@ func handleResourcePrivate(s *discordgo.Session, i *discordgo.InteractionCreate,
 
 	switch options[0].Name {
 	case "add":
-		handleResourcePrivateAdd(s, i, options[0].Options)
+		handleResourcePrivateAdd(s, i, store, options[0].Options)
 	case "list":
-		handleResourcePrivateList(s, i, options[0].Options)
+		handleResourcePrivateList(s, i, store, options[0].Options)
 	case "remove":
-		handleResourcePrivateRemove(s, i, options[0].Options)
+		handleResourcePrivateRemove(s, i, store, options[0].Options)
 	default:
 		respondError(s, i, "Unknown private subcommand")
 	}
 }
 
 // handleResourcePrivateAdd handles /resource private add
-func handleResourcePrivateAdd(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleResourcePrivateAdd(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	// Extract parameters
 	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
 	for _, opt := range options {
@@ -508,7 +511,7 @@ func handleResourcePrivateAdd(s *discordgo.Session, i *discordgo.InteractionCrea
 	userID := i.Member.User.ID
 	username := i.Member.User.Username
 
-	resource, err := database.CreatePrivateResourceWithRoles(i.GuildID, userID, username, urlStr, title, description, category, tags, validatedRoles)
+	resource, err := store.CreatePrivateResourceWithRoles(i.GuildID, userID, username, urlStr, title, description, category, tags, validatedRoles)
 	if err != nil {
 		log.Printf("Error creating private resource: %v", err)
 		respondError(s, i, "Failed to create private resource. Please try again.")
@@ -561,7 +564,7 @@ func handleResourcePrivateAdd(s *discordgo.Session, i *discordgo.InteractionCrea
 }
 
 // handleResourcePrivateList handles /resource private list
-func handleResourcePrivateList(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleResourcePrivateList(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	// Extract parameters
 	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
 	for _, opt := range options {
@@ -582,7 +585,7 @@ func handleResourcePrivateList(s *discordgo.Session, i *discordgo.InteractionCre
 	userRoleIDs := i.Member.Roles
 
 	// Get accessible resources
-	resources, err := database.GetPrivateResourcesForUser(i.GuildID, userRoleIDs, category, search)
+	resources, err := store.GetPrivateResourcesForUser(i.GuildID, userRoleIDs, category, search)
 	if err != nil {
 		log.Printf("Error fetching private resources: %v", err)
 		respondError(s, i, "Failed to fetch resources. Please try again.")
@@ -654,7 +657,7 @@ func handleResourcePrivateList(s *discordgo.Session, i *discordgo.InteractionCre
 }
 
 // handleResourcePrivateRemove handles /resource private remove
-func handleResourcePrivateRemove(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleResourcePrivateRemove(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	// Extract parameters
 	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
 	for _, opt := range options {
@@ -665,7 +668,7 @@ func handleResourcePrivateRemove(s *discordgo.Session, i *discordgo.InteractionC
 	userID := i.Member.User.ID
 
 	// Delete the resource
-	err := database.DeletePrivateResource(resourceID, userID)
+	err := store.DeletePrivateResource(resourceID, userID)
 	if err != nil {
 		log.Printf("Error deleting resource: %v", err)
 		respondError(s, i, fmt.Sprintf("Failed to delete resource: %s", err.Error()))
@@ -717,7 +720,7 @@ func extractRoleIDs(str string) []string {
 }
 
 // respondError sends an error message to the user
-func respondError(s *discordgo.Session, i *discordgo.InteractionCreate, message string) {
+func respondError(s discord.Session, i *discordgo.InteractionCreate, message string) {
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
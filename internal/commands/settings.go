@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
+	"github.com/bwmarrin/discordgo"
+)
+
+// settingsCommand creates the /settings command for personal preferences
+func settingsCommand(store *database.Store) *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name:        "settings",
+			Description: "Manage your personal bot settings",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "timezone",
+					Description: "View or set the timezone used for your standups and reminders",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "zone",
+							Description: "IANA timezone such as Asia/Singapore, or \"default\" to use the server's",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Required:    false,
+						},
+					},
+				},
+			},
+		},
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleSettingsCommand(s, i, store)
+		},
+	}
+}
+
+func handleSettingsCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		respondWithError(s, i, "Invalid command usage")
+		return
+	}
+
+	if i.Member == nil || i.GuildID == "" {
+		respondWithError(s, i, "This command can only be used in a server.")
+		return
+	}
+
+	user, err := store.GetOrCreateUser(i.Member.User.ID, i.GuildID, i.Member.User.Username)
+	if err != nil {
+		log.Printf("Error getting/creating user: %v", err)
+		respondWithError(s, i, "Failed to process your request. Please try again.")
+		return
+	}
+
+	switch options[0].Name {
+	case "timezone":
+		zone := ""
+		if len(options[0].Options) > 0 {
+			zone = strings.TrimSpace(options[0].Options[0].StringValue())
+		}
+		handleSettingsTimezone(s, i, store, user, i.GuildID, zone)
+	default:
+		respondWithError(s, i, "Unknown subcommand")
+	}
+}
+
+func handleSettingsTimezone(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID, zone string) {
+	title := "Your Timezone"
+	if zone != "" {
+		if strings.EqualFold(zone, "default") {
+			zone = ""
+		} else if _, err := database.LoadTimezone(zone); err != nil {
+			respondWithError(s, i, fmt.Sprintf("Unknown timezone `%s`. Use a name from the IANA timezone database, e.g. `America/New_York` or `Asia/Singapore`.", zone))
+			return
+		}
+
+		if err := store.UpdateUserTimezone(user.ID, zone); err != nil {
+			log.Printf("Error updating timezone: %v", err)
+			respondWithError(s, i, "Failed to update your timezone.")
+			return
+		}
+		title = "Timezone Updated"
+	}
+
+	loc := store.UserLocation(user.ID, guildID)
+	localTime := time.Now().In(loc)
+
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: fmt.Sprintf("Your days and reminders use **%s**.\nIt's currently **%s** for you.", loc.String(), localTime.Format("Mon Jan 2, 15:04")),
+		Color:       0x5865F2, // Discord blurple
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Standup streaks, Focus Period days and reminders follow this timezone",
+		},
+	}
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord/discordtest"
+)
+
+func TestSettingsTimezone(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+	admin := newTestUser("user-admin", "admin")
+
+	resp := h.run(alice, nil, "settings", discordtest.SubCommand("timezone", discordtest.String("zone", "Moon/Base")))
+	assertError(t, resp, "Unknown timezone `Moon/Base`")
+
+	resp = h.run(alice, nil, "settings", discordtest.SubCommand("timezone", discordtest.String("zone", "Asia/Singapore")))
+	embed := assertTitle(t, resp, "Timezone Updated")
+	if !strings.Contains(embed.Description, "Asia/Singapore") {
+		t.Errorf("Expected the new timezone in the response, got %q", embed.Description)
+	}
+	if !isEphemeral(resp) {
+		t.Error("Expected settings responses to be ephemeral")
+	}
+
+	// Resetting falls back to the server default set by an admin
+	resp = h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommand("timezone", discordtest.String("zone", "Europe/Berlin")))
+	assertTitle(t, resp, "Configuration Updated")
+
+	h.run(alice, nil, "settings", discordtest.SubCommand("timezone", discordtest.String("zone", "default")))
+	resp = h.run(alice, nil, "settings", discordtest.SubCommand("timezone"))
+	embed = assertTitle(t, resp, "Your Timezone")
+	if !strings.Contains(embed.Description, "Europe/Berlin") {
+		t.Errorf("Expected the server default timezone, got %q", embed.Description)
+	}
+}
@@ -3,14 +3,17 @@ package commands
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
 	"github.com/bwmarrin/discordgo"
 )
 
 // standupCommand creates the /standup command group
-func standupCommand() *Command {
+func standupCommand(store *database.Store) *Command {
 	return &Command{
 		Definition: &discordgo.ApplicationCommand{
 			Name:        "standup",
@@ -20,32 +23,17 @@ func standupCommand() *Command {
 					Name:        "post",
 					Description: "Post your daily standup",
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
-					Options: []*discordgo.ApplicationCommandOption{
-						{
-							Name:        "working-on",
-							Description: "What are you working on today?",
-							Type:        discordgo.ApplicationCommandOptionString,
-							Required:    true,
-						},
-						{
-							Name:        "accomplished",
-							Description: "What did you accomplish since your last standup?",
-							Type:        discordgo.ApplicationCommandOptionString,
-							Required:    false,
-						},
-						{
-							Name:        "blockers",
-							Description: "Any blockers or challenges?",
-							Type:        discordgo.ApplicationCommandOptionString,
-							Required:    false,
-						},
-					},
 				},
 				{
 					Name:        "streak",
 					Description: "View your standup streak stats",
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 				},
+				{
+					Name:        "buy-freeze",
+					Description: fmt.Sprintf("Spend %d points on a freeze that covers one missed day", database.FreezeTokenCost),
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+				},
 				{
 					Name:        "leaderboard",
 					Description: "View the streak leaderboard",
@@ -68,11 +56,13 @@ func standupCommand() *Command {
 				},
 			},
 		},
-		Handler: handleStandupCommand,
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleStandupCommand(s, i, store)
+		},
 	}
 }
 
-func handleStandupCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func handleStandupCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
 	options := i.ApplicationCommandData().Options
 	if len(options) == 0 {
 		respondWithError(s, i, "Invalid command usage")
@@ -94,7 +84,7 @@ func handleStandupCommand(s *discordgo.Session, i *discordgo.InteractionCreate)
 	}
 
 	// Get or create user in database
-	user, err := database.GetOrCreateUser(userID, guildID, username)
+	user, err := store.GetOrCreateUser(userID, guildID, username)
 	if err != nil {
 		log.Printf("Error getting/creating user: %v", err)
 		respondWithError(s, i, "Failed to process your request. Please try again.")
@@ -103,45 +93,153 @@ func handleStandupCommand(s *discordgo.Session, i *discordgo.InteractionCreate)
 
 	switch subCommand {
 	case "post":
-		handleStandupPost(s, i, user, guildID, options[0].Options)
+		handleStandupPost(s, i, store, user, guildID)
 	case "streak":
-		handleStandupStreak(s, i, user, guildID)
+		handleStandupStreak(s, i, store, user, guildID)
+	case "buy-freeze":
+		handleStandupBuyFreeze(s, i, store, user, guildID)
 	case "leaderboard":
-		handleStandupLeaderboard(s, i, guildID)
+		handleStandupLeaderboard(s, i, store, guildID)
 	case "history":
 		days := 7
 		if len(options[0].Options) > 0 {
 			days = int(options[0].Options[0].IntValue())
 		}
-		handleStandupHistory(s, i, user, guildID, days)
+		handleStandupHistory(s, i, store, user, guildID, days)
 	default:
 		respondWithError(s, i, "Unknown subcommand")
 	}
 }
 
-func handleStandupPost(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
-	var workingOn, accomplished, blockers string
-
-	for _, opt := range options {
-		switch opt.Name {
-		case "working-on":
-			workingOn = opt.StringValue()
-		case "accomplished":
-			accomplished = opt.StringValue()
-		case "blockers":
-			blockers = opt.StringValue()
+// Custom IDs of the standup form and its inputs
+const (
+	standupModalID      = "standup_post"
+	standupWorkingOnID  = "working_on"
+	standupAccomplished = "accomplished"
+	standupBlockersID   = "blockers"
+)
+
+// maxStandupFieldLength bounds each section of a standup
+const maxStandupFieldLength = 1000
+
+// alreadyPostedEmbed tells a member they've already checked in today
+func alreadyPostedEmbed() *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       "Already Posted Today",
+		Description: "You've already posted a standup today. Come back tomorrow to keep your streak going!",
+		Color:       0xFFA500, // Orange
+	}
+}
+
+// handleStandupPost opens the standup form, unless the member has already checked in today
+func handleStandupPost(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string) {
+	posted, err := store.HasPostedStandupToday(user.ID, guildID)
+	if err != nil {
+		log.Printf("Error checking today's standup: %v", err)
+		respondWithError(s, i, "Failed to post your standup.")
+		return
+	}
+	if posted {
+		respondWithEmbedEphemeral(s, i, alreadyPostedEmbed(), true)
+		return
+	}
+
+	paragraph := func(customID, label, placeholder string, required bool) discordgo.MessageComponent {
+		return discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID:    customID,
+					Label:       label,
+					Style:       discordgo.TextInputParagraph,
+					Placeholder: placeholder,
+					Required:    required,
+					MaxLength:   maxStandupFieldLength,
+				},
+			},
 		}
 	}
 
-	standup, streak, bonusPoints, err := database.CreateStandup(user.ID, guildID, workingOn, accomplished, blockers)
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: standupModalID,
+			Title:    "Daily Standup",
+			Components: []discordgo.MessageComponent{
+				paragraph(standupWorkingOnID, "What are you working on today?", "One item per line works well", true),
+				paragraph(standupAccomplished, "What did you accomplish since last time?", "Optional", false),
+				paragraph(standupBlockersID, "Any blockers or challenges?", "Optional", false),
+			},
+		},
+	})
 	if err != nil {
-		if strings.Contains(err.Error(), "already posted") {
-			embed := &discordgo.MessageEmbed{
-				Title:       "Already Posted Today",
-				Description: "You've already posted a standup today. Come back tomorrow to keep your streak going!",
-				Color:       0xFFA500, // Orange
+		log.Printf("Error opening standup form: %v", err)
+	}
+}
+
+// IsStandupModal reports whether a submitted modal is the standup form
+func IsStandupModal(customID string) bool {
+	return customID == standupModalID
+}
+
+// HandleStandupModal posts the standup submitted through the standup form
+func HandleStandupModal(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
+	var userID, username, guildID string
+	if i.Member != nil {
+		userID = i.Member.User.ID
+		username = i.Member.User.Username
+		guildID = i.GuildID
+	} else if i.User != nil {
+		userID = i.User.ID
+		username = i.User.Username
+		guildID = "DM"
+	}
+
+	user, err := store.GetOrCreateUser(userID, guildID, username)
+	if err != nil {
+		log.Printf("Error getting/creating user: %v", err)
+		respondWithError(s, i, "Failed to process your request. Please try again.")
+		return
+	}
+
+	values := modalValues(i.ModalSubmitData())
+	workingOn := strings.TrimSpace(values[standupWorkingOnID])
+	if workingOn == "" {
+		respondWithError(s, i, "Tell us what you're working on today.")
+		return
+	}
+
+	submitStandup(s, i, store, user, guildID, workingOn, strings.TrimSpace(values[standupAccomplished]), strings.TrimSpace(values[standupBlockersID]))
+}
+
+// modalValues returns the values of a submitted modal's text inputs by custom ID
+func modalValues(data discordgo.ModalSubmitInteractionData) map[string]string {
+	values := make(map[string]string)
+	for _, component := range data.Components {
+		var inputs []discordgo.MessageComponent
+		switch row := component.(type) {
+		case *discordgo.ActionsRow:
+			inputs = row.Components
+		case discordgo.ActionsRow:
+			inputs = row.Components
+		}
+		for _, input := range inputs {
+			switch text := input.(type) {
+			case *discordgo.TextInput:
+				values[text.CustomID] = text.Value
+			case discordgo.TextInput:
+				values[text.CustomID] = text.Value
 			}
-			respondWithEmbed(s, i, embed)
+		}
+	}
+	return values
+}
+
+// submitStandup records a standup and shows it with the member's streak
+func submitStandup(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID, workingOn, accomplished, blockers string) {
+	standup, streak, bonusPoints, err := store.CreateStandup(user.ID, guildID, workingOn, accomplished, blockers)
+	if err != nil {
+		if strings.Contains(err.Error(), "already posted") {
+			respondWithEmbedEphemeral(s, i, alreadyPostedEmbed(), true)
 			return
 		}
 		log.Printf("Error creating standup: %v", err)
@@ -184,6 +282,21 @@ func handleStandupPost(s *discordgo.Session, i *discordgo.InteractionCreate, use
 		},
 	}
 
+	if streak.FrozenDays > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Streak Saved",
+			Value:  fmt.Sprintf("🧊 %s covered your missed days (%d left)", pluralize(streak.FrozenDays, "freeze"), streak.FreezeTokens),
+			Inline: false,
+		})
+	}
+	if streak.EarnedFreeze {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Freeze Earned",
+			Value:  fmt.Sprintf("🧊 You now hold %d/%d streak freezes", streak.FreezeTokens, database.MaxFreezeTokens),
+			Inline: false,
+		})
+	}
+
 	// Add bonus points field if earned
 	if bonusPoints > 0 {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
@@ -195,10 +308,146 @@ func handleStandupPost(s *discordgo.Session, i *discordgo.InteractionCreate, use
 	}
 
 	respondWithEmbed(s, i, embed)
+
+	postBlocker(s, store, guildID, user.Username, standup.Blocker)
+}
+
+// standupHeading matches a line that starts a section of a standup reply, such as "**Blockers:** none"
+var standupHeading = regexp.MustCompile(`(?i)^[\s>*#_-]*(working on|today|plan|accomplished|done|yesterday|completed|blockers?|blocked|stuck)[\s*_]*:[\s*_]*(.*)$`)
+
+// parseStandupReply splits a standup written as a message into its sections.
+// Text before the first heading is what the member is working on.
+// ok is false when the message has no headings, so it isn't a standup at all.
+func parseStandupReply(content string) (workingOn, accomplished, blockers string, ok bool) {
+	var working, done, blocked []string
+	current := &working
+	for _, line := range strings.Split(content, "\n") {
+		if match := standupHeading.FindStringSubmatch(line); match != nil {
+			ok = true
+			switch strings.ToLower(match[1]) {
+			case "accomplished", "done", "yesterday", "completed":
+				current = &done
+			case "blocker", "blockers", "blocked", "stuck":
+				current = &blocked
+			default:
+				current = &working
+			}
+			line = match[2]
+		}
+		if strings.TrimSpace(line) != "" {
+			*current = append(*current, strings.TrimRight(line, " "))
+		}
+	}
+
+	return strings.Join(working, "\n"), strings.Join(done, "\n"), strings.Join(blocked, "\n"), ok
+}
+
+// standupThreadDay formats a standup thread's day the way its name shows it, e.g. "Mon Jan 2"
+func standupThreadDay(day string) string {
+	date, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		return day
+	}
+	return date.Format("Mon Jan 2")
+}
+
+// HandleStandupReply posts a message in today's daily standup thread as the author's standup.
+// Messages that aren't written as a standup are ordinary conversation and are left alone.
+// A standup without a Working on section, in another day's thread or after the author
+// has checked in isn't recorded, and the author is told why.
+func HandleStandupReply(s discord.Session, m *discordgo.MessageCreate, store *database.Store) {
+	if m.Author == nil || m.Author.Bot || strings.TrimSpace(m.Content) == "" {
+		return
+	}
+
+	thread, err := store.GetStandupThread(m.ChannelID)
+	if err != nil {
+		log.Printf("Error fetching standup thread: %v", err)
+		return
+	}
+	if thread == nil {
+		return
+	}
+
+	workingOn, accomplished, blockers, ok := parseStandupReply(m.Content)
+	if !ok {
+		return
+	}
+
+	reply := func(embed *discordgo.MessageEmbed) {
+		if _, err := s.ChannelMessageSendEmbed(m.ChannelID, embed); err != nil {
+			log.Printf("Error replying in standup thread: %v", err)
+		}
+	}
+
+	if workingOn == "" {
+		reply(&discordgo.MessageEmbed{
+			Description: fmt.Sprintf("<@%s> add a **Working on:** section so we know what you're up to today.", m.Author.ID),
+			Color:       0xFFA500, // Orange
+		})
+		return
+	}
+
+	// Threads are opened per server day, so the day is checked in the server's timezone
+	if time.Now().In(store.GuildLocation(thread.GuildID)).Format("2006-01-02") != thread.Day {
+		reply(&discordgo.MessageEmbed{
+			Description: fmt.Sprintf("<@%s> this thread is for **%s**, so your standup wasn't recorded. Reply in today's thread or use `/standup post`.",
+				m.Author.ID, standupThreadDay(thread.Day)),
+			Color: 0xFFA500, // Orange
+		})
+		return
+	}
+
+	user, err := store.GetOrCreateUser(m.Author.ID, thread.GuildID, m.Author.Username)
+	if err != nil {
+		log.Printf("Error getting/creating user: %v", err)
+		return
+	}
+
+	posted, err := store.HasPostedStandupToday(user.ID, thread.GuildID)
+	if err != nil {
+		log.Printf("Error checking standup: %v", err)
+		return
+	}
+	if posted {
+		embed := alreadyPostedEmbed()
+		embed.Description = fmt.Sprintf("<@%s> %s", m.Author.ID, embed.Description)
+		reply(embed)
+		return
+	}
+
+	standup, streak, bonusPoints, err := store.CreateStandup(user.ID, thread.GuildID,
+		truncateString(workingOn, maxStandupFieldLength),
+		truncateString(accomplished, maxStandupFieldLength),
+		truncateString(blockers, maxStandupFieldLength))
+	if err != nil {
+		log.Printf("Error creating standup: %v", err)
+		return
+	}
+
+	points := 1 + bonusPoints
+	description := fmt.Sprintf("<@%s> checked in · %s %d day streak · +%d points",
+		m.Author.ID, getStreakEmoji(streak.CurrentStreak), streak.CurrentStreak, points)
+	if streak.FrozenDays > 0 {
+		description += fmt.Sprintf(" · 🧊 %s used", pluralize(streak.FrozenDays, "freeze"))
+	}
+	if streak.EarnedFreeze {
+		description += " · 🧊 freeze earned"
+	}
+	embed := &discordgo.MessageEmbed{
+		Description: description,
+		Color:       0x00FF00, // Green
+	}
+	if bonusPoints > 0 {
+		embed.Color = 0xFFD700 // Gold for milestone
+	}
+	reply(embed)
+
+	postBlocker(s, store, thread.GuildID, m.Author.Username, standup.Blocker)
 }
 
-func handleStandupStreak(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string) {
-	streak, err := database.GetUserStreak(user.ID, guildID)
+func handleStandupStreak(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string) {
+	streak, err := store.GetUserStreak(user.ID, guildID)
 	if err != nil {
 		log.Printf("Error getting streak: %v", err)
 		respondWithError(s, i, "Failed to get your streak info.")
@@ -255,11 +504,58 @@ func handleStandupStreak(s *discordgo.Session, i *discordgo.InteractionCreate, u
 		Inline: false,
 	})
 
+	freezes := fmt.Sprintf("🧊 **%d/%d** held · each covers one missed day\nEarn one every %d days of streak, or buy one with `/standup buy-freeze` for %d points",
+		streak.FreezeTokens, database.MaxFreezeTokens, database.FreezeEarnInterval, database.FreezeTokenCost)
+	if config, err := store.GetGuildConfig(guildID); err == nil {
+		if rest := restDaysText(config); rest != "" {
+			freezes += fmt.Sprintf("\nRest days don't count against your streak: %s", rest)
+		}
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "Streak Freezes",
+		Value:  freezes,
+		Inline: false,
+	})
+
+	respondWithEmbedEphemeral(s, i, embed, true)
+}
+
+func handleStandupBuyFreeze(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string) {
+	streak, err := store.BuyStreakFreeze(user.ID, guildID)
+	if err != nil {
+		log.Printf("Error buying streak freeze: %v", err)
+		respondWithError(s, i, fmt.Sprintf("Couldn't buy a freeze: %s.", err))
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🧊 Streak Freeze Bought",
+		Description: fmt.Sprintf("You spent **%d points** and now hold **%d/%d** freezes.\n\nIf you miss a day, a freeze is used automatically the next time you post so your streak keeps going.", database.FreezeTokenCost, streak.FreezeTokens, database.MaxFreezeTokens),
+		Color:       0x00BFFF, // Ice blue
+	}
 	respondWithEmbedEphemeral(s, i, embed, true)
 }
 
-func handleStandupLeaderboard(s *discordgo.Session, i *discordgo.InteractionCreate, guildID string) {
-	entries, err := database.GetStreakLeaderboard(guildID, 10)
+// pluralize formats a count with a noun, adding an "s" unless the count is one
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// restDaysText lists a guild's rest days, e.g. "Saturday, Sunday", or "" if it has none
+func restDaysText(config *database.GuildConfig) string {
+	days, _ := database.ParseRestDays(config.StandupRestDays)
+	names := make([]string, 0, len(days))
+	for _, day := range days {
+		names = append(names, day.String())
+	}
+	return strings.Join(names, ", ")
+}
+
+func handleStandupLeaderboard(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string) {
+	entries, err := store.GetStreakLeaderboard(guildID, 10)
 	if err != nil {
 		log.Printf("Error getting streak leaderboard: %v", err)
 		respondWithError(s, i, "Failed to get the leaderboard.")
@@ -307,8 +603,8 @@ func handleStandupLeaderboard(s *discordgo.Session, i *discordgo.InteractionCrea
 	respondWithEmbed(s, i, embed)
 }
 
-func handleStandupHistory(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, days int) {
-	standups, err := database.GetUserStandups(user.ID, guildID, days)
+func handleStandupHistory(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, days int) {
+	standups, err := store.GetUserStandups(user.ID, guildID, days)
 	if err != nil {
 		log.Printf("Error getting standup history: %v", err)
 		respondWithError(s, i, "Failed to get your standup history.")
@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord/discordtest"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestStandupForm(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+
+	resp := h.run(alice, nil, "standup", discordtest.SubCommand("post"))
+	if resp.Type != discordgo.InteractionResponseModal || resp.Data.CustomID != standupModalID {
+		t.Fatalf("Expected /standup post to open the standup form, got %+v", resp)
+	}
+	if len(resp.Data.Components) != 3 {
+		t.Errorf("Expected three inputs on the form, got %d", len(resp.Data.Components))
+	}
+
+	resp = h.submit(alice, standupModalID, map[string]string{
+		standupWorkingOnID:  "Pricing page\nOnboarding emails",
+		standupAccomplished: "Shipped billing",
+	})
+	embed := assertTitle(t, resp, "Daily Standup Posted!")
+	if !strings.Contains(embed.Description, "Pricing page\nOnboarding emails") {
+		t.Errorf("Expected multi-line updates to be kept, got %q", embed.Description)
+	}
+	if strings.Contains(embed.Description, "Blockers") {
+		t.Errorf("Expected no blockers section, got %q", embed.Description)
+	}
+
+	resp = h.run(alice, nil, "standup", discordtest.SubCommand("post"))
+	assertTitle(t, resp, "Already Posted Today")
+	if !isEphemeral(resp) {
+		t.Error("Expected the already-posted notice to be ephemeral")
+	}
+}
+
+func TestParseStandupReply(t *testing.T) {
+	tests := []struct {
+		content                           string
+		workingOn, accomplished, blockers string
+		standup                           bool
+	}{
+		{"Writing the launch post", "Writing the launch post", "", "", false},
+		{
+			"**Accomplished:** shipped billing\n**Working on:**\n- pricing page\n- onboarding\n**Blockers:** none",
+			"- pricing page\n- onboarding", "shipped billing", "none", true,
+		},
+		{"Fixing signup\nyesterday: customer calls\nBlocked: waiting on Stripe", "Fixing signup", "customer calls", "waiting on Stripe", true},
+	}
+
+	for _, tt := range tests {
+		workingOn, accomplished, blockers, standup := parseStandupReply(tt.content)
+		if workingOn != tt.workingOn || accomplished != tt.accomplished || blockers != tt.blockers || standup != tt.standup {
+			t.Errorf("parseStandupReply(%q) = %q, %q, %q, %v", tt.content, workingOn, accomplished, blockers, standup)
+		}
+	}
+}
+
+func TestStandupThreadReplies(t *testing.T) {
+	h := newTestHarness(t)
+	alice := newTestUser("user-alice", "alice")
+
+	// Threads follow the server's day, even for a member whose own day differs
+	user, _ := h.store.GetOrCreateUser(alice.ID, testGuildID, alice.Username)
+	if err := h.store.UpdateUserTimezone(user.ID, "Pacific/Kiritimati"); err != nil {
+		t.Fatalf("Failed to set timezone: %v", err)
+	}
+	today := time.Now().In(h.store.GuildLocation(testGuildID))
+	yesterdayDay := today.AddDate(0, 0, -1)
+	h.store.SaveStandupThread(testGuildID, testChannelID, "thread-old", yesterdayDay.Format("2006-01-02"))
+	if _, err := h.store.SaveStandupThread(testGuildID, testChannelID, "thread-standup", today.Format("2006-01-02")); err != nil {
+		t.Fatalf("Failed to save thread: %v", err)
+	}
+	inThread := discordtest.Invocation{GuildID: testGuildID, ChannelID: "thread-standup", User: alice}
+
+	// Messages elsewhere or that aren't standups are ignored
+	elsewhere := discordtest.Invocation{GuildID: testGuildID, ChannelID: testChannelID, User: alice}
+	HandleStandupReply(h.session, elsewhere.Message("Working on: pricing"), h.store)
+	HandleStandupReply(h.session, inThread.Message("Nice work everyone!"), h.store)
+	if len(h.session.Messages()) != 0 {
+		t.Fatalf("Expected no replies, got %+v", h.session.Messages())
+	}
+
+	// Standups that can't be recorded say why
+	yesterday := discordtest.Invocation{GuildID: testGuildID, ChannelID: "thread-old", User: alice}
+	HandleStandupReply(h.session, yesterday.Message("Working on: pricing"), h.store)
+	messages := h.session.MessagesIn("thread-old")
+	want := fmt.Sprintf("<@user-alice> this thread is for **%s**, so your standup wasn't recorded.", yesterdayDay.Format("Mon Jan 2"))
+	if len(messages) != 1 || !strings.HasPrefix(messages[0].Embed.Description, want) {
+		t.Fatalf("Expected yesterday's thread to turn the standup away, got %+v", messages)
+	}
+	HandleStandupReply(h.session, inThread.Message("Done: billing\nBlockers: none"), h.store)
+	messages = h.session.MessagesIn("thread-standup")
+	if len(messages) != 1 || !strings.Contains(messages[0].Embed.Description, "add a **Working on:** section") {
+		t.Fatalf("Expected a nudge for the missing section, got %+v", messages)
+	}
+
+	HandleStandupReply(h.session, inThread.Message("Working on: pricing page"), h.store)
+	messages = h.session.MessagesIn("thread-standup")
+	if len(messages) != 2 || messages[1].Embed.Description != "<@user-alice> checked in · 💪 1 day streak · +1 points" {
+		t.Fatalf("Expected a check-in confirmation, got %+v", messages)
+	}
+
+	// The thread and /standup post share a streak
+	resp := h.run(alice, nil, "standup", discordtest.SubCommand("post"))
+	assertTitle(t, resp, "Already Posted Today")
+
+	HandleStandupReply(h.session, inThread.Message("Working on: more pricing"), h.store)
+	messages = h.session.MessagesIn("thread-standup")
+	if len(messages) != 3 || messages[2].Embed.Title != "Already Posted Today" || !strings.HasPrefix(messages[2].Embed.Description, "<@user-alice> ") {
+		t.Errorf("Expected a second standup to be turned away, got %+v", messages)
+	}
+}
+
+func TestStandupFreezesAndRestDays(t *testing.T) {
+	h := newTestHarness(t)
+	admin := newTestUser("user-admin", "admin")
+	alice := newTestUser("user-alice", "alice")
+
+	resp := h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("standup", discordtest.SubCommand("rest-days", discordtest.String("days", "sun,sat"))))
+	embed := assertTitle(t, resp, "Configuration Updated")
+	if !strings.Contains(embed.Description, "**Sunday, Saturday**") {
+		t.Errorf("Expected the rest days, got %q", embed.Description)
+	}
+	resp = h.run(admin, []string{testAdminRoleID}, "config", discordtest.SubCommandGroup("standup", discordtest.SubCommand("rest-days", discordtest.String("days", "weekends"))))
+	assertError(t, resp, "Invalid rest days")
+
+	resp = h.run(alice, nil, "standup", discordtest.SubCommand("buy-freeze"))
+	assertError(t, resp, "a freeze costs 25 points and you have 0")
+
+	user, _ := h.store.GetOrCreateUser(alice.ID, testGuildID, alice.Username)
+	h.store.AdjustPoints(user.ID, testGuildID, 30, user.ID, "Seed points")
+	resp = h.run(alice, nil, "standup", discordtest.SubCommand("buy-freeze"))
+	embed = assertTitle(t, resp, "🧊 Streak Freeze Bought")
+	if !strings.Contains(embed.Description, "**1/3** freezes") {
+		t.Errorf("Expected one freeze held, got %q", embed.Description)
+	}
+
+	resp = h.run(alice, nil, "standup", discordtest.SubCommand("streak"))
+	embed = assertTitle(t, resp, "Your Standup Streak")
+	freezes := embed.Fields[len(embed.Fields)-1]
+	if freezes.Name != "Streak Freezes" || !strings.Contains(freezes.Value, "**1/3** held") || !strings.Contains(freezes.Value, "Sunday, Saturday") {
+		t.Errorf("Expected freezes and rest days on the streak card, got %+v", freezes)
+	}
+}
@@ -6,11 +6,12 @@ import (
 	"strings"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
 	"github.com/bwmarrin/discordgo"
 )
 
 // winCommand creates the /win command group
-func winCommand() *Command {
+func winCommand(store *database.Store) *Command {
 	return &Command{
 		Definition: &discordgo.ApplicationCommand{
 			Name:        "win",
@@ -64,11 +65,13 @@ func winCommand() *Command {
 				},
 			},
 		},
-		Handler: handleWinCommand,
+		Handler: func(s discord.Session, i *discordgo.InteractionCreate) {
+			handleWinCommand(s, i, store)
+		},
 	}
 }
 
-func handleWinCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func handleWinCommand(s discord.Session, i *discordgo.InteractionCreate, store *database.Store) {
 	options := i.ApplicationCommandData().Options
 	if len(options) == 0 {
 		respondWithError(s, i, "Invalid command usage")
@@ -90,7 +93,7 @@ func handleWinCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	}
 
 	// Get or create user in database
-	user, err := database.GetOrCreateUser(userID, guildID, username)
+	user, err := store.GetOrCreateUser(userID, guildID, username)
 	if err != nil {
 		log.Printf("Error getting/creating user: %v", err)
 		respondWithError(s, i, "Failed to process your request. Please try again.")
@@ -99,21 +102,21 @@ func handleWinCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 	switch subCommand {
 	case "share":
-		handleWinShare(s, i, user, guildID, options[0].Options)
+		handleWinShare(s, i, store, user, guildID, options[0].Options)
 	case "recent":
 		days := 7
 		if len(options[0].Options) > 0 {
 			days = int(options[0].Options[0].IntValue())
 		}
-		handleWinRecent(s, i, guildID, days)
+		handleWinRecent(s, i, store, guildID, days)
 	case "stats":
-		handleWinStats(s, i, guildID)
+		handleWinStats(s, i, store, guildID)
 	default:
 		respondWithError(s, i, "Unknown subcommand")
 	}
 }
 
-func handleWinShare(s *discordgo.Session, i *discordgo.InteractionCreate, user *database.User, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+func handleWinShare(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, user *database.User, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	var message, category string
 
 	for _, opt := range options {
@@ -125,7 +128,7 @@ func handleWinShare(s *discordgo.Session, i *discordgo.InteractionCreate, user *
 		}
 	}
 
-	win, err := database.CreateWin(user.ID, guildID, message, category)
+	win, err := store.CreateWin(user.ID, guildID, message, category)
 	if err != nil {
 		log.Printf("Error creating win: %v", err)
 		respondWithError(s, i, "Failed to share your win.")
@@ -133,7 +136,7 @@ func handleWinShare(s *discordgo.Session, i *discordgo.InteractionCreate, user *
 	}
 
 	// Get win count for user
-	winCount, _ := database.GetUserWinCount(user.ID, guildID)
+	winCount, _ := store.GetUserWinCount(user.ID, guildID)
 
 	categoryEmoji := getCategoryEmoji(win.Category)
 	categoryDisplay := getCategoryDisplay(win.Category)
@@ -162,7 +165,7 @@ func handleWinShare(s *discordgo.Session, i *discordgo.InteractionCreate, user *
 	respondWithEmbed(s, i, embed)
 
 	// Post to wins channel if configured
-	winsChannel, _ := database.GetWinsChannel(guildID)
+	winsChannel, _ := store.GetWinsChannel(guildID)
 	if winsChannel != "" {
 		celebrationEmbed := &discordgo.MessageEmbed{
 			Title:       fmt.Sprintf("%s New Win from @%s!", categoryEmoji, user.Username),
@@ -185,7 +188,7 @@ func handleWinShare(s *discordgo.Session, i *discordgo.InteractionCreate, user *
 			log.Printf("Error posting win to channel: %v", err)
 		} else {
 			// Update win with message ID
-			database.UpdateWinMessageID(win.ID, msg.ID)
+			store.UpdateWinMessageID(win.ID, msg.ID)
 			// Add celebration reactions
 			s.MessageReactionAdd(winsChannel, msg.ID, "🎉")
 			s.MessageReactionAdd(winsChannel, msg.ID, "🔥")
@@ -193,8 +196,8 @@ func handleWinShare(s *discordgo.Session, i *discordgo.InteractionCreate, user *
 	}
 }
 
-func handleWinRecent(s *discordgo.Session, i *discordgo.InteractionCreate, guildID string, days int) {
-	wins, err := database.GetRecentWins(guildID, days)
+func handleWinRecent(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string, days int) {
+	wins, err := store.GetRecentWins(guildID, days)
 	if err != nil {
 		log.Printf("Error getting recent wins: %v", err)
 		respondWithError(s, i, "Failed to get recent wins.")
@@ -235,8 +238,8 @@ func handleWinRecent(s *discordgo.Session, i *discordgo.InteractionCreate, guild
 	respondWithEmbed(s, i, embed)
 }
 
-func handleWinStats(s *discordgo.Session, i *discordgo.InteractionCreate, guildID string) {
-	stats, err := database.GetWinStats(guildID)
+func handleWinStats(s discord.Session, i *discordgo.InteractionCreate, store *database.Store, guildID string) {
+	stats, err := store.GetWinStats(guildID)
 	if err != nil {
 		log.Printf("Error getting win stats: %v", err)
 		respondWithError(s, i, "Failed to get win statistics.")
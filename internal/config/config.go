@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -15,12 +18,34 @@ type Config struct {
 	ApplicationID string
 	// GuildID is optional - if set, commands are registered to this guild only (faster for development)
 	GuildID string
+	// DatabaseDriver selects the storage backend ("sqlite" or "postgres")
+	DatabaseDriver string
 	// DatabasePath is the path to the SQLite database file
 	DatabasePath string
-	// ReminderChannelID is the Discord channel ID where reminders will be sent
+	// DatabaseURL is the PostgreSQL connection string (used when DatabaseDriver is "postgres")
+	DatabaseURL string
+	// ReminderChannelID is the fallback channel for guilds that haven't run /config reminders channel
 	ReminderChannelID string
+	// EstimatorProvider selects how goal points are estimated ("openai", "openai-compatible" or "heuristic").
+	// Empty picks OpenAI when OpenAIAPIKey is set and the offline heuristic otherwise.
+	EstimatorProvider string
 	// OpenAIAPIKey is the OpenAI API key for point calculation
 	OpenAIAPIKey string
+	// OpenAIBaseURL is the API root of an OpenAI-compatible server, such as a local model
+	OpenAIBaseURL string
+	// OpenAIModel overrides the model used for point calculation
+	OpenAIModel string
+	// EstimatorTimeout caps each call to the estimation model
+	EstimatorTimeout time.Duration
+	// EstimatorRetries is how many times a failed estimate is retried
+	EstimatorRetries int
+	// EstimatorGuildPerMinute caps model estimates per guild per minute (0 for no limit)
+	EstimatorGuildPerMinute int
+	// EstimatorGuildPerDay caps model estimates per guild per UTC day (0 for no limit)
+	EstimatorGuildPerDay int
+	// SummaryProvider selects how weekly standup digests are summarized ("openai", "openai-compatible" or "plain").
+	// Empty picks plain, so standups are only sent to a model when the operator opts in.
+	SummaryProvider string
 }
 
 // Load loads the configuration from environment variables
@@ -34,13 +59,29 @@ func Load() (*Config, error) {
 		dbPath = "data/bootstrap_hub.db"
 	}
 
+	// Default to SQLite unless a PostgreSQL URL is provided
+	dbURL := os.Getenv("DATABASE_URL")
+	dbDriver := strings.ToLower(os.Getenv("DATABASE_DRIVER"))
+	if dbDriver == "" {
+		dbDriver = "sqlite"
+		if strings.HasPrefix(dbURL, "postgres://") || strings.HasPrefix(dbURL, "postgresql://") {
+			dbDriver = "postgres"
+		}
+	}
+
 	config := &Config{
 		BotToken:          os.Getenv("DISCORD_BOT_TOKEN"),
 		ApplicationID:     os.Getenv("DISCORD_APPLICATION_ID"),
 		GuildID:           os.Getenv("DISCORD_GUILD_ID"),
+		DatabaseDriver:    dbDriver,
 		DatabasePath:      dbPath,
+		DatabaseURL:       dbURL,
 		ReminderChannelID: os.Getenv("DISCORD_REMINDER_CHANNEL_ID"),
+		EstimatorProvider: strings.ToLower(os.Getenv("ESTIMATOR_PROVIDER")),
 		OpenAIAPIKey:      os.Getenv("OPENAI_API_KEY"),
+		OpenAIBaseURL:     os.Getenv("OPENAI_BASE_URL"),
+		OpenAIModel:       os.Getenv("OPENAI_MODEL"),
+		SummaryProvider:   strings.ToLower(os.Getenv("SUMMARY_PROVIDER")),
 	}
 
 	if config.BotToken == "" {
@@ -51,5 +92,93 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("DISCORD_APPLICATION_ID environment variable is required")
 	}
 
+	switch config.DatabaseDriver {
+	case "sqlite":
+	case "postgres":
+		if config.DatabaseURL == "" {
+			return nil, fmt.Errorf("DATABASE_URL environment variable is required when DATABASE_DRIVER is postgres")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported DATABASE_DRIVER %q (expected sqlite or postgres)", config.DatabaseDriver)
+	}
+
+	var err error
+	if config.EstimatorTimeout, err = durationEnv("ESTIMATOR_TIMEOUT", 10*time.Second); err != nil {
+		return nil, err
+	}
+	if config.EstimatorRetries, err = intEnv("ESTIMATOR_RETRIES", 2); err != nil {
+		return nil, err
+	}
+	if config.EstimatorGuildPerMinute, err = intEnv("ESTIMATOR_GUILD_PER_MINUTE", 10); err != nil {
+		return nil, err
+	}
+	if config.EstimatorGuildPerDay, err = intEnv("ESTIMATOR_GUILD_PER_DAY", 200); err != nil {
+		return nil, err
+	}
+
+	switch config.EstimatorProvider {
+	case "", "heuristic":
+	case "openai":
+		if config.OpenAIAPIKey == "" {
+			return nil, fmt.Errorf("OPENAI_API_KEY environment variable is required when ESTIMATOR_PROVIDER is openai")
+		}
+	case "openai-compatible":
+		if config.OpenAIBaseURL == "" {
+			return nil, fmt.Errorf("OPENAI_BASE_URL environment variable is required when ESTIMATOR_PROVIDER is openai-compatible")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported ESTIMATOR_PROVIDER %q (expected openai, openai-compatible or heuristic)", config.EstimatorProvider)
+	}
+
+	switch config.SummaryProvider {
+	case "", "plain":
+	case "openai":
+		if config.OpenAIAPIKey == "" {
+			return nil, fmt.Errorf("OPENAI_API_KEY environment variable is required when SUMMARY_PROVIDER is openai")
+		}
+	case "openai-compatible":
+		if config.OpenAIBaseURL == "" {
+			return nil, fmt.Errorf("OPENAI_BASE_URL environment variable is required when SUMMARY_PROVIDER is openai-compatible")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported SUMMARY_PROVIDER %q (expected openai, openai-compatible or plain)", config.SummaryProvider)
+	}
+
 	return config, nil
 }
+
+// durationEnv reads a duration such as "10s" from an environment variable, or returns fallback if it is unset
+func durationEnv(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration such as 10s, got %q", key, value)
+	}
+	return duration, nil
+}
+
+// intEnv reads a non-negative integer from an environment variable, or returns fallback if it is unset
+func intEnv(key string, fallback int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("%s must be a whole number of 0 or more, got %q", key, value)
+	}
+	return number, nil
+}
+
+// DatabaseDSN returns the connection string for the configured database driver
+func (c *Config) DatabaseDSN() string {
+	if c.DatabaseDriver == "postgres" {
+		return c.DatabaseURL
+	}
+	return c.DatabasePath
+}
@@ -0,0 +1,163 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrAppealPending is returned when a goal already has an appeal waiting for review
+var ErrAppealPending = errors.New("this goal already has an appeal waiting for review")
+
+// CreatePointAppeal asks for a task in a focus period to be re-scored to points
+func (s *Store) CreatePointAppeal(period *FocusPeriod, position, points int, reason string) (*PointAppeal, error) {
+	var appeal *PointAppeal
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		task, err := findTask(tx, period.ID, position)
+		if err != nil {
+			return err
+		}
+
+		if task.Points == points {
+			return fmt.Errorf("task #%d is already worth %d points", position, points)
+		}
+
+		var pending int64
+		if err := tx.Model(&PointAppeal{}).Where("task_id = ? AND status = ?", task.ID, AppealPending).Count(&pending).Error; err != nil {
+			return fmt.Errorf("failed to check appeals: %w", err)
+		}
+		if pending > 0 {
+			return ErrAppealPending
+		}
+
+		appeal = &PointAppeal{
+			TaskID:          task.ID,
+			Task:            *task,
+			UserID:          period.UserID,
+			GuildID:         period.GuildID,
+			Reason:          reason,
+			PreviousPoints:  task.Points,
+			RequestedPoints: points,
+			Status:          AppealPending,
+		}
+		if err := tx.Create(appeal).Error; err != nil {
+			return fmt.Errorf("failed to create appeal: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return appeal, nil
+}
+
+// GetPointAppeal returns an appeal with its task and member, or nil if it doesn't exist
+func (s *Store) GetPointAppeal(id uint) (*PointAppeal, error) {
+	var appeal PointAppeal
+	result := s.db.Preload("Task").Preload("User").First(&appeal, id)
+
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch appeal: %w", result.Error)
+	}
+
+	return &appeal, nil
+}
+
+// ResolvePointAppeal records a reviewer's decision on a pending appeal.
+// Approving re-scores the task and applies the change in points it has already earned
+// to the member's sprint and total points, which is returned.
+func (s *Store) ResolvePointAppeal(appeal *PointAppeal, reviewerID uint, approve bool) (int, error) {
+	config, err := s.GetGuildConfig(appeal.GuildID)
+	if err != nil {
+		return 0, err
+	}
+
+	status := AppealRejected
+	if approve {
+		status = AppealApproved
+	}
+
+	var pointsChange int
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		// Only the first decision counts if two reviewers click at once
+		now := time.Now()
+		result := tx.Model(&PointAppeal{}).Where("id = ? AND status = ?", appeal.ID, AppealPending).
+			Updates(map[string]interface{}{"status": status, "reviewer_id": reviewerID, "reviewed_at": now})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update appeal: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("this appeal has already been decided")
+		}
+		appeal.Status = status
+		appeal.ReviewerID = &reviewerID
+		appeal.ReviewedAt = &now
+
+		if !approve {
+			return nil
+		}
+
+		var task Task
+		if err := tx.First(&task, appeal.TaskID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("the goal has since been removed")
+			}
+			return fmt.Errorf("failed to fetch task: %w", err)
+		}
+		var period FocusPeriod
+		if err := tx.First(&period, task.FocusPeriodID).Error; err != nil {
+			return fmt.Errorf("failed to fetch focus period: %w", err)
+		}
+
+		before := task.CreditedPoints()
+		task.Points = appeal.RequestedPoints
+		task.WithheldPoints = min(task.WithheldPoints, task.Points)
+		if increase := task.CreditedPoints() - before; task.Completed && increase > 0 {
+			// Extra points count towards the period cap like any others
+			allowed, err := capPeriodPoints(tx, config, &period, increase)
+			if err != nil {
+				return err
+			}
+			task.WithheldPoints += increase - allowed
+		}
+		if err := tx.Save(&task).Error; err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+
+		if task.Completed {
+			pointsChange = task.CreditedPoints() - before
+			if pointsChange != 0 {
+				source := PointsSource{Type: PointsSourceAppeal, ID: appeal.ID, Note: task.Title}
+				if err := addMemberPoints(tx, period.UserID, period.GuildID, pointsChange, source); err != nil {
+					return err
+				}
+				if err := addSprintPoints(tx, period.UserID, period.ID, pointsChange, period.GuildID, period.StartDate, period.EndDate); err != nil {
+					return err
+				}
+			}
+		} else {
+			if task.Steps, err = orderedSteps(tx, task.ID); err != nil {
+				return err
+			}
+			if pointsChange, err = syncStepPoints(tx, config, &period, &task); err != nil {
+				return err
+			}
+		}
+		appeal.Task = task
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return pointsChange, nil
+}
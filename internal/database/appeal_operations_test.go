@@ -0,0 +1,98 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+)
+
+func TestPointAppeals(t *testing.T) {
+	store := setupTestDB(t)
+	if err := store.db.AutoMigrate(&PointAppeal{}); err != nil {
+		t.Fatalf("Failed to migrate appeals: %v", err)
+	}
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	buddy, _ := store.GetOrCreateUser("user-2", guildID, "bob")
+	period, _ := store.CreateFocusPeriod(user.ID, guildID)
+	store.AddTask(period.ID, "Launch the beta", "", estimator.Estimate{Points: 5}, nil)
+	store.AddTask(period.ID, "Write the changelog", "", estimator.Estimate{Points: 4}, nil)
+
+	task, _ := store.CompleteTask(period.ID, 1)
+	store.AddPointsToUser(user.ID, period.ID, task.Points, guildID, period.StartDate, period.EndDate)
+
+	if _, err := store.CreatePointAppeal(period, 1, 5, "Same score"); err == nil {
+		t.Error("Expected an appeal for the current points to be rejected")
+	}
+	appeal, err := store.CreatePointAppeal(period, 1, 8, "Took a whole week of payments work")
+	if err != nil {
+		t.Fatalf("Failed to appeal: %v", err)
+	}
+	if _, err := store.CreatePointAppeal(period, 1, 9, "Again"); !errors.Is(err, ErrAppealPending) {
+		t.Errorf("Expected ErrAppealPending for a second appeal, got %v", err)
+	}
+
+	// Approving a completed goal credits the difference
+	appeal, _ = store.GetPointAppeal(appeal.ID)
+	change, err := store.ResolvePointAppeal(appeal, buddy.ID, true)
+	if err != nil {
+		t.Fatalf("Failed to approve appeal: %v", err)
+	}
+	if change != 3 || appeal.Status != AppealApproved || appeal.Task.Points != 8 {
+		t.Errorf("Expected +3 and an approved appeal, got %+d, %s and %d points", change, appeal.Status, appeal.Task.Points)
+	}
+	if _, err := store.ResolvePointAppeal(appeal, buddy.ID, false); err == nil {
+		t.Error("Expected a decided appeal to stay decided")
+	}
+
+	member, _ := store.GetGuildMember(user.ID, guildID)
+	sprint, _ := store.GetOrCreateSprintPoints(period.ID, user.ID, guildID, period.StartDate, period.EndDate)
+	if member.TotalPoints != 8 || sprint.Points != 8 {
+		t.Errorf("Expected 8 total and sprint points, got %d and %d", member.TotalPoints, sprint.Points)
+	}
+
+	// Rejecting, or approving a goal that hasn't earned anything yet, leaves points alone
+	appeal, _ = store.CreatePointAppeal(period, 2, 2, "Only a few lines")
+	if change, err := store.ResolvePointAppeal(appeal, buddy.ID, false); err != nil || change != 0 || appeal.Status != AppealRejected {
+		t.Errorf("Expected a rejection without points, got %+d, %s (%v)", change, appeal.Status, err)
+	}
+	appeal, _ = store.CreatePointAppeal(period, 2, 2, "Only a few lines, really")
+	if change, err := store.ResolvePointAppeal(appeal, buddy.ID, true); err != nil || change != 0 {
+		t.Errorf("Expected a pending goal to be re-scored without points, got %+d (%v)", change, err)
+	}
+	tasks, _ := store.GetTasksByFocusPeriod(period.ID)
+	if tasks[1].Points != 2 {
+		t.Errorf("Expected the goal to be worth 2 points, got %d", tasks[1].Points)
+	}
+}
+
+func TestApprovedAppealRespectsPointCap(t *testing.T) {
+	store := setupTestDB(t)
+	if err := store.db.AutoMigrate(&PointAppeal{}); err != nil {
+		t.Fatalf("Failed to migrate appeals: %v", err)
+	}
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	buddy, _ := store.GetOrCreateUser("user-2", guildID, "bob")
+	period, _ := store.CreateFocusPeriod(user.ID, guildID)
+	store.UpdateGuardrails(guildID, 0, 10, false)
+
+	store.AddTask(period.ID, "Launch the beta", "", estimator.Estimate{Points: 8}, nil)
+	store.AwardTaskCompletion(period, 1)
+
+	// Only the room left under the cap is credited; the rest is withheld like any capped points
+	appeal, _ := store.CreatePointAppeal(period, 1, 14, "Took the whole period")
+	change, err := store.ResolvePointAppeal(appeal, buddy.ID, true)
+	if err != nil {
+		t.Fatalf("Failed to approve appeal: %v", err)
+	}
+	if change != 2 || appeal.Task.Points != 14 || appeal.Task.WithheldPoints != 4 {
+		t.Errorf("Expected +2 up to the cap with 4 withheld, got %+d, %d points and %d withheld",
+			change, appeal.Task.Points, appeal.Task.WithheldPoints)
+	}
+	member, _ := store.GetGuildMember(user.ID, guildID)
+	if member.TotalPoints != 10 {
+		t.Errorf("Expected 10 points at the cap, got %d", member.TotalPoints)
+	}
+}
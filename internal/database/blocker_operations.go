@@ -0,0 +1,151 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BlockerHelpPoints are credited to a member who helped resolve someone else's blocker
+const BlockerHelpPoints = 5
+
+// noBlockerAnswers are standup answers meaning there is nothing blocking
+var noBlockerAnswers = map[string]bool{
+	"": true, "-": true, "no": true, "none": true, "nope": true, "nothing": true,
+	"n/a": true, "na": true, "nil": true, "no blockers": true, "all good": true,
+}
+
+// isNoBlocker reports whether a standup's blockers answer says nothing is blocking
+func isNoBlocker(text string) bool {
+	return noBlockerAnswers[strings.Trim(strings.ToLower(strings.TrimSpace(text)), ".!*_ ")]
+}
+
+// trackBlocker records a standup's blockers as an open Blocker, unless they say nothing is blocking
+func trackBlocker(tx *gorm.DB, standup *Standup) error {
+	if isNoBlocker(standup.Blockers) {
+		return nil
+	}
+
+	blocker := &Blocker{
+		UserID:      standup.UserID,
+		GuildID:     standup.GuildID,
+		StandupID:   &standup.ID,
+		Description: strings.TrimSpace(standup.Blockers),
+		Status:      BlockerOpen,
+	}
+	if err := tx.Create(blocker).Error; err != nil {
+		return fmt.Errorf("failed to track blocker: %w", err)
+	}
+	standup.Blocker = blocker
+	return nil
+}
+
+// UpdateHelpChannel sets the channel new blockers are posted to, or "" to stop posting them
+func (s *Store) UpdateHelpChannel(guildID, channelID string) error {
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	config.HelpChannel = channelID
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update help channel: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBlockerMessage records where a blocker was posted for help
+func (s *Store) UpdateBlockerMessage(blockerID uint, channelID, messageID string) error {
+	result := s.db.Model(&Blocker{}).Where("id = ?", blockerID).
+		Updates(map[string]interface{}{"channel_id": channelID, "message_id": messageID})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update blocker message: %w", result.Error)
+	}
+	return nil
+}
+
+// GetBlocker returns a guild's blocker by ID, or nil if there is none
+func (s *Store) GetBlocker(guildID string, blockerID uint) (*Blocker, error) {
+	var blocker Blocker
+	result := s.db.Preload("User").Where("guild_id = ?", guildID).Limit(1).Find(&blocker, blockerID)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch blocker: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &blocker, nil
+}
+
+// GetOpenBlockers returns a guild's unresolved blockers, oldest first
+func (s *Store) GetOpenBlockers(guildID string) ([]Blocker, error) {
+	var blockers []Blocker
+	result := s.db.Preload("User").
+		Where("guild_id = ? AND status = ?", guildID, BlockerOpen).
+		Order("created_at ASC").
+		Find(&blockers)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch open blockers: %w", result.Error)
+	}
+
+	return blockers, nil
+}
+
+// GetGuildsWithOpenBlockers returns the IDs of guilds with unresolved blockers
+func (s *Store) GetGuildsWithOpenBlockers() ([]string, error) {
+	var guildIDs []string
+	result := s.db.Model(&Blocker{}).Where("status = ?", BlockerOpen).Distinct().Pluck("guild_id", &guildIDs)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch guilds with open blockers: %w", result.Error)
+	}
+
+	return guildIDs, nil
+}
+
+// ResolveBlocker marks a blocker resolved. A helper other than the blocked member is credited
+// with BlockerHelpPoints. It returns the blocker and the points credited.
+func (s *Store) ResolveBlocker(guildID string, blockerID uint, helperID *uint) (*Blocker, int, error) {
+	var blocker Blocker
+	var credited int
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Preload("User").Where("guild_id = ?", guildID).First(&blocker, blockerID)
+		if result.Error == gorm.ErrRecordNotFound {
+			return fmt.Errorf("blocker %d not found", blockerID)
+		}
+		if result.Error != nil {
+			return fmt.Errorf("failed to fetch blocker: %w", result.Error)
+		}
+		if blocker.Status != BlockerOpen {
+			return fmt.Errorf("blocker %d is already resolved", blockerID)
+		}
+
+		now := time.Now()
+		blocker.Status = BlockerResolved
+		blocker.ResolvedAt = &now
+		if helperID != nil && *helperID != blocker.UserID {
+			blocker.HelperID = helperID
+		}
+		if err := tx.Omit("User", "Helper").Save(&blocker).Error; err != nil {
+			return fmt.Errorf("failed to update blocker: %w", err)
+		}
+
+		if blocker.HelperID == nil {
+			return nil
+		}
+		credited = BlockerHelpPoints
+		source := PointsSource{Type: PointsSourceBlockerHelp, ID: blocker.ID, Note: blocker.Description}
+		return addMemberPoints(tx, *blocker.HelperID, guildID, credited, source)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &blocker, credited, nil
+}
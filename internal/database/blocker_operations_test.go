@@ -0,0 +1,71 @@
+package database
+
+import (
+	"testing"
+)
+
+func TestBlockers(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	alice, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	bob, _ := store.GetOrCreateUser("user-2", guildID, "bob")
+
+	standup, _, _, err := store.CreateStandup(alice.ID, guildID, "Pricing page", "", "Stripe webhooks keep failing")
+	if err != nil {
+		t.Fatalf("Failed to create standup: %v", err)
+	}
+	if standup.Blocker == nil || standup.Blocker.Status != BlockerOpen || *standup.Blocker.StandupID != standup.ID {
+		t.Fatalf("Expected the standup's blockers to be tracked, got %+v", standup.Blocker)
+	}
+
+	// Saying nothing is blocking doesn't open a blocker
+	standup, _, _, _ = store.CreateStandup(bob.ID, guildID, "Landing page", "", "None.")
+	if standup.Blocker != nil {
+		t.Errorf("Expected no blocker for \"None.\", got %+v", standup.Blocker)
+	}
+
+	open, err := store.GetOpenBlockers(guildID)
+	if err != nil || len(open) != 1 || open[0].User.Username != "alice" {
+		t.Fatalf("Expected alice's open blocker, got %+v (%v)", open, err)
+	}
+	if guilds, _ := store.GetGuildsWithOpenBlockers(); len(guilds) != 1 || guilds[0] != guildID {
+		t.Errorf("Expected the guild to have open blockers, got %v", guilds)
+	}
+
+	blocker, credited, err := store.ResolveBlocker(guildID, open[0].ID, &bob.ID)
+	if err != nil {
+		t.Fatalf("Failed to resolve blocker: %v", err)
+	}
+	if blocker.Status != BlockerResolved || blocker.ResolvedAt == nil || *blocker.HelperID != bob.ID || credited != BlockerHelpPoints {
+		t.Errorf("Expected bob credited for resolving it, got %+v (%d points)", blocker, credited)
+	}
+	history, _ := store.GetPointsHistory(bob.ID, guildID, 1)
+	if len(history) != 1 || history[0].SourceType != PointsSourceBlockerHelp || history[0].Amount != BlockerHelpPoints {
+		t.Errorf("Expected bob's help in the ledger, got %+v", history)
+	}
+
+	if _, _, err := store.ResolveBlocker(guildID, open[0].ID, nil); err == nil {
+		t.Error("Expected resolving twice to fail")
+	}
+	if open, _ := store.GetOpenBlockers(guildID); len(open) != 0 {
+		t.Errorf("Expected no open blockers, got %d", len(open))
+	}
+}
+
+func TestResolveOwnBlockerEarnsNothing(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	alice, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+
+	standup, _, _, _ := store.CreateStandup(alice.ID, guildID, "Pricing page", "", "Waiting on design")
+	blocker, credited, err := store.ResolveBlocker(guildID, standup.Blocker.ID, &alice.ID)
+	if err != nil {
+		t.Fatalf("Failed to resolve blocker: %v", err)
+	}
+	if credited != 0 || blocker.HelperID != nil {
+		t.Errorf("Expected no credit for helping yourself, got %d points and helper %v", credited, blocker.HelperID)
+	}
+	if _, _, err := store.ResolveBlocker("other-guild", standup.Blocker.ID, nil); err == nil {
+		t.Error("Expected a blocker from another guild not to be found")
+	}
+}
@@ -8,9 +8,9 @@ import (
 )
 
 // CreateBuddyRequest creates a new buddy request
-func CreateBuddyRequest(requesterID, receiverID uint, guildID string) (*BuddyRequest, error) {
+func (s *Store) CreateBuddyRequest(requesterID, receiverID uint, guildID string) (*BuddyRequest, error) {
 	// Check if requester has reached max buddies
-	count, err := GetBuddyCount(requesterID, guildID)
+	count, err := s.GetBuddyCount(requesterID, guildID)
 	if err != nil {
 		return nil, err
 	}
@@ -19,7 +19,7 @@ func CreateBuddyRequest(requesterID, receiverID uint, guildID string) (*BuddyReq
 	}
 
 	// Check if receiver has reached max buddies
-	count, err = GetBuddyCount(receiverID, guildID)
+	count, err = s.GetBuddyCount(receiverID, guildID)
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +28,7 @@ func CreateBuddyRequest(requesterID, receiverID uint, guildID string) (*BuddyReq
 	}
 
 	// Check if they're already buddies
-	isBuddy, err := AreBuddies(requesterID, receiverID, guildID)
+	isBuddy, err := s.AreBuddies(requesterID, receiverID, guildID)
 	if err != nil {
 		return nil, err
 	}
@@ -38,14 +38,14 @@ func CreateBuddyRequest(requesterID, receiverID uint, guildID string) (*BuddyReq
 
 	// Check if there's a pending request
 	var existingRequest BuddyRequest
-	result := DB.Where("requester_id = ? AND receiver_id = ? AND guild_id = ? AND status = ?",
+	result := s.db.Where("requester_id = ? AND receiver_id = ? AND guild_id = ? AND status = ?",
 		requesterID, receiverID, guildID, BuddyRequestStatusPending).First(&existingRequest)
 	if result.Error == nil {
 		return nil, fmt.Errorf("you already have a pending request to this user")
 	}
 
 	// Check if there's a pending request from them to you
-	result = DB.Where("requester_id = ? AND receiver_id = ? AND guild_id = ? AND status = ?",
+	result = s.db.Where("requester_id = ? AND receiver_id = ? AND guild_id = ? AND status = ?",
 		receiverID, requesterID, guildID, BuddyRequestStatusPending).First(&existingRequest)
 	if result.Error == nil {
 		return nil, fmt.Errorf("this user already sent you a request - use `/buddy accept` to accept it")
@@ -59,7 +59,7 @@ func CreateBuddyRequest(requesterID, receiverID uint, guildID string) (*BuddyReq
 		ExpiresAt:   time.Now().Add(7 * 24 * time.Hour), // 7 days to accept
 	}
 
-	if err := DB.Create(request).Error; err != nil {
+	if err := s.db.Create(request).Error; err != nil {
 		return nil, fmt.Errorf("failed to create buddy request: %w", err)
 	}
 
@@ -67,9 +67,9 @@ func CreateBuddyRequest(requesterID, receiverID uint, guildID string) (*BuddyReq
 }
 
 // AcceptBuddyRequest accepts a buddy request and creates a buddy pair
-func AcceptBuddyRequest(requesterID, receiverID uint, guildID string) (*BuddyPair, error) {
+func (s *Store) AcceptBuddyRequest(requesterID, receiverID uint, guildID string) (*BuddyPair, error) {
 	var request BuddyRequest
-	result := DB.Where("requester_id = ? AND receiver_id = ? AND guild_id = ? AND status = ?",
+	result := s.db.Where("requester_id = ? AND receiver_id = ? AND guild_id = ? AND status = ?",
 		requesterID, receiverID, guildID, BuddyRequestStatusPending).First(&request)
 
 	if result.Error == gorm.ErrRecordNotFound {
@@ -82,12 +82,12 @@ func AcceptBuddyRequest(requesterID, receiverID uint, guildID string) (*BuddyPai
 	// Check if request has expired
 	if time.Now().After(request.ExpiresAt) {
 		request.Status = BuddyRequestStatusDeclined
-		DB.Save(&request)
+		s.db.Save(&request)
 		return nil, fmt.Errorf("this buddy request has expired")
 	}
 
 	var pair *BuddyPair
-	err := DB.Transaction(func(tx *gorm.DB) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
 		// Update request status
 		request.Status = BuddyRequestStatusAccepted
 		if err := tx.Save(&request).Error; err != nil {
@@ -116,8 +116,8 @@ func AcceptBuddyRequest(requesterID, receiverID uint, guildID string) (*BuddyPai
 }
 
 // DeclineBuddyRequest declines a buddy request
-func DeclineBuddyRequest(requesterID, receiverID uint, guildID string) error {
-	result := DB.Model(&BuddyRequest{}).
+func (s *Store) DeclineBuddyRequest(requesterID, receiverID uint, guildID string) error {
+	result := s.db.Model(&BuddyRequest{}).
 		Where("requester_id = ? AND receiver_id = ? AND guild_id = ? AND status = ?",
 			requesterID, receiverID, guildID, BuddyRequestStatusPending).
 		Update("status", BuddyRequestStatusDeclined)
@@ -133,8 +133,8 @@ func DeclineBuddyRequest(requesterID, receiverID uint, guildID string) error {
 }
 
 // RemoveBuddy removes a buddy relationship
-func RemoveBuddy(userID1, userID2 uint, guildID string) error {
-	result := DB.Where(
+func (s *Store) RemoveBuddy(userID1, userID2 uint, guildID string) error {
+	result := s.db.Where(
 		"((user1_id = ? AND user2_id = ?) OR (user1_id = ? AND user2_id = ?)) AND guild_id = ?",
 		userID1, userID2, userID2, userID1, guildID,
 	).Delete(&BuddyPair{})
@@ -150,9 +150,9 @@ func RemoveBuddy(userID1, userID2 uint, guildID string) error {
 }
 
 // GetBuddyCount returns the number of buddies a user has
-func GetBuddyCount(userID uint, guildID string) (int, error) {
+func (s *Store) GetBuddyCount(userID uint, guildID string) (int, error) {
 	var count int64
-	result := DB.Model(&BuddyPair{}).
+	result := s.db.Model(&BuddyPair{}).
 		Where("(user1_id = ? OR user2_id = ?) AND guild_id = ?", userID, userID, guildID).
 		Count(&count)
 
@@ -164,9 +164,9 @@ func GetBuddyCount(userID uint, guildID string) (int, error) {
 }
 
 // AreBuddies checks if two users are buddies
-func AreBuddies(userID1, userID2 uint, guildID string) (bool, error) {
+func (s *Store) AreBuddies(userID1, userID2 uint, guildID string) (bool, error) {
 	var count int64
-	result := DB.Model(&BuddyPair{}).
+	result := s.db.Model(&BuddyPair{}).
 		Where("((user1_id = ? AND user2_id = ?) OR (user1_id = ? AND user2_id = ?)) AND guild_id = ?",
 			userID1, userID2, userID2, userID1, guildID).
 		Count(&count)
@@ -179,12 +179,12 @@ func AreBuddies(userID1, userID2 uint, guildID string) (bool, error) {
 }
 
 // GetUserBuddies returns all buddies for a user
-func GetUserBuddies(userID uint, guildID string) ([]User, error) {
+func (s *Store) GetUserBuddies(userID uint, guildID string) ([]User, error) {
 	var buddies []User
 
 	// Get buddy pairs where user is either user1 or user2
 	var pairs []BuddyPair
-	result := DB.Where("(user1_id = ? OR user2_id = ?) AND guild_id = ?", userID, userID, guildID).Find(&pairs)
+	result := s.db.Where("(user1_id = ? OR user2_id = ?) AND guild_id = ?", userID, userID, guildID).Find(&pairs)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to fetch buddy pairs: %w", result.Error)
 	}
@@ -204,7 +204,7 @@ func GetUserBuddies(userID uint, guildID string) ([]User, error) {
 	}
 
 	// Fetch buddy users
-	result = DB.Where("id IN ?", buddyIDs).Find(&buddies)
+	result = s.db.Where("id IN ?", buddyIDs).Find(&buddies)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to fetch buddies: %w", result.Error)
 	}
@@ -213,10 +213,10 @@ func GetUserBuddies(userID uint, guildID string) ([]User, error) {
 }
 
 // GetPendingBuddyRequests returns pending requests sent to a user
-func GetPendingBuddyRequests(userID uint, guildID string) ([]BuddyRequest, error) {
+func (s *Store) GetPendingBuddyRequests(userID uint, guildID string) ([]BuddyRequest, error) {
 	var requests []BuddyRequest
 
-	result := DB.Preload("Requester").
+	result := s.db.Preload("Requester").
 		Where("receiver_id = ? AND guild_id = ? AND status = ? AND expires_at > ?",
 			userID, guildID, BuddyRequestStatusPending, time.Now()).
 		Find(&requests)
@@ -229,10 +229,10 @@ func GetPendingBuddyRequests(userID uint, guildID string) ([]BuddyRequest, error
 }
 
 // GetSentBuddyRequests returns pending requests sent by a user
-func GetSentBuddyRequests(userID uint, guildID string) ([]BuddyRequest, error) {
+func (s *Store) GetSentBuddyRequests(userID uint, guildID string) ([]BuddyRequest, error) {
 	var requests []BuddyRequest
 
-	result := DB.Preload("Receiver").
+	result := s.db.Preload("Receiver").
 		Where("requester_id = ? AND guild_id = ? AND status = ? AND expires_at > ?",
 			userID, guildID, BuddyRequestStatusPending, time.Now()).
 		Find(&requests)
@@ -245,9 +245,9 @@ func GetSentBuddyRequests(userID uint, guildID string) ([]BuddyRequest, error) {
 }
 
 // GetBuddyPair gets the buddy pair between two users
-func GetBuddyPair(userID1, userID2 uint, guildID string) (*BuddyPair, error) {
+func (s *Store) GetBuddyPair(userID1, userID2 uint, guildID string) (*BuddyPair, error) {
 	var pair BuddyPair
-	result := DB.Where(
+	result := s.db.Where(
 		"((user1_id = ? AND user2_id = ?) OR (user1_id = ? AND user2_id = ?)) AND guild_id = ?",
 		userID1, userID2, userID2, userID1, guildID,
 	).First(&pair)
@@ -263,8 +263,8 @@ func GetBuddyPair(userID1, userID2 uint, guildID string) (*BuddyPair, error) {
 }
 
 // UpdateBuddyNotification updates the notification setting for a buddy pair
-func UpdateBuddyNotification(userID1, userID2 uint, guildID string, notify bool) error {
-	result := DB.Model(&BuddyPair{}).
+func (s *Store) UpdateBuddyNotification(userID1, userID2 uint, guildID string, notify bool) error {
+	result := s.db.Model(&BuddyPair{}).
 		Where("((user1_id = ? AND user2_id = ?) OR (user1_id = ? AND user2_id = ?)) AND guild_id = ?",
 			userID1, userID2, userID2, userID1, guildID).
 		Update("notify_on_completion", notify)
@@ -277,10 +277,10 @@ func UpdateBuddyNotification(userID1, userID2 uint, guildID string, notify bool)
 }
 
 // GetBuddiesWithNotifications returns buddies who should be notified when a user completes a task
-func GetBuddiesWithNotifications(userID uint, guildID string) ([]User, error) {
+func (s *Store) GetBuddiesWithNotifications(userID uint, guildID string) ([]User, error) {
 	var buddies []User
 
-	rows, err := DB.Raw(`
+	rows, err := s.db.Raw(`
 		SELECT u.*
 		FROM users u
 		JOIN buddy_pairs bp ON (
@@ -297,7 +297,7 @@ func GetBuddiesWithNotifications(userID uint, guildID string) ([]User, error) {
 
 	for rows.Next() {
 		var buddy User
-		if err := DB.ScanRows(rows, &buddy); err != nil {
+		if err := s.db.ScanRows(rows, &buddy); err != nil {
 			continue
 		}
 		buddies = append(buddies, buddy)
@@ -307,8 +307,8 @@ func GetBuddiesWithNotifications(userID uint, guildID string) ([]User, error) {
 }
 
 // CleanupExpiredRequests removes expired buddy requests
-func CleanupExpiredRequests() error {
-	result := DB.Where("status = ? AND expires_at < ?", BuddyRequestStatusPending, time.Now()).
+func (s *Store) CleanupExpiredRequests() error {
+	result := s.db.Where("status = ? AND expires_at < ?", BuddyRequestStatusPending, time.Now()).
 		Delete(&BuddyRequest{})
 
 	if result.Error != nil {
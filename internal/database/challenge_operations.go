@@ -8,7 +8,7 @@ import (
 )
 
 // CreateChallenge creates a new challenge with participants
-func CreateChallenge(creatorID uint, guildID, title, description string, days int, participantIDs []uint, multiplier float64) (*Challenge, error) {
+func (s *Store) CreateChallenge(creatorID uint, guildID, title, description string, days int, participantIDs []uint, multiplier float64) (*Challenge, error) {
 	if multiplier <= 0 {
 		multiplier = 1.5
 	}
@@ -21,7 +21,7 @@ func CreateChallenge(creatorID uint, guildID, title, description string, days in
 	endDate := startDate.Add(time.Duration(days) * 24 * time.Hour)
 
 	var challenge *Challenge
-	err := DB.Transaction(func(tx *gorm.DB) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
 		challenge = &Challenge{
 			CreatorID:        creatorID,
 			GuildID:          guildID,
@@ -62,9 +62,9 @@ func CreateChallenge(creatorID uint, guildID, title, description string, days in
 }
 
 // GetChallenge gets a challenge by ID
-func GetChallenge(challengeID uint) (*Challenge, error) {
+func (s *Store) GetChallenge(challengeID uint) (*Challenge, error) {
 	var challenge Challenge
-	result := DB.Preload("Creator").First(&challenge, challengeID)
+	result := s.db.Preload("Creator").First(&challenge, challengeID)
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, fmt.Errorf("challenge not found")
 	}
@@ -75,9 +75,9 @@ func GetChallenge(challengeID uint) (*Challenge, error) {
 }
 
 // GetChallengeWithParticipants gets a challenge with its participants
-func GetChallengeWithParticipants(challengeID uint) (*Challenge, []ChallengeParticipant, error) {
+func (s *Store) GetChallengeWithParticipants(challengeID uint) (*Challenge, []ChallengeParticipant, error) {
 	var challenge Challenge
-	result := DB.Preload("Creator").First(&challenge, challengeID)
+	result := s.db.Preload("Creator").First(&challenge, challengeID)
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, nil, fmt.Errorf("challenge not found")
 	}
@@ -86,7 +86,7 @@ func GetChallengeWithParticipants(challengeID uint) (*Challenge, []ChallengePart
 	}
 
 	var participants []ChallengeParticipant
-	result = DB.Preload("User").Where("challenge_id = ?", challengeID).Find(&participants)
+	result = s.db.Preload("User").Where("challenge_id = ?", challengeID).Find(&participants)
 	if result.Error != nil {
 		return nil, nil, fmt.Errorf("failed to fetch participants: %w", result.Error)
 	}
@@ -95,10 +95,10 @@ func GetChallengeWithParticipants(challengeID uint) (*Challenge, []ChallengePart
 }
 
 // GetUserChallenges gets challenges for a user
-func GetUserChallenges(userID uint, guildID string, status string) ([]Challenge, error) {
+func (s *Store) GetUserChallenges(userID uint, guildID string, status string) ([]Challenge, error) {
 	var challenges []Challenge
 
-	query := DB.Preload("Creator").
+	query := s.db.Preload("Creator").
 		Joins("JOIN challenge_participants cp ON cp.challenge_id = challenges.id").
 		Where("cp.user_id = ? AND challenges.guild_id = ?", userID, guildID)
 
@@ -115,11 +115,11 @@ func GetUserChallenges(userID uint, guildID string, status string) ([]Challenge,
 }
 
 // GetActiveChallenges gets all active challenges in a guild
-func GetActiveChallenges(guildID string) ([]Challenge, error) {
+func (s *Store) GetActiveChallenges(guildID string) ([]Challenge, error) {
 	var challenges []Challenge
 	now := time.Now()
 
-	result := DB.Preload("Creator").
+	result := s.db.Preload("Creator").
 		Where("guild_id = ? AND status = ? AND end_date > ?", guildID, ChallengeStatusActive, now).
 		Order("end_date ASC").
 		Find(&challenges)
@@ -132,10 +132,10 @@ func GetActiveChallenges(guildID string) ([]Challenge, error) {
 }
 
 // AddChallengeProgress adds a progress update to a challenge
-func AddChallengeProgress(challengeID, userID uint, update string) (*ChallengeProgress, error) {
+func (s *Store) AddChallengeProgress(challengeID, userID uint, update string) (*ChallengeProgress, error) {
 	// Verify user is a participant
 	var participant ChallengeParticipant
-	result := DB.Where("challenge_id = ? AND user_id = ?", challengeID, userID).First(&participant)
+	result := s.db.Where("challenge_id = ? AND user_id = ?", challengeID, userID).First(&participant)
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, fmt.Errorf("you're not a participant in this challenge")
 	}
@@ -153,7 +153,7 @@ func AddChallengeProgress(challengeID, userID uint, update string) (*ChallengePr
 		Update:      update,
 	}
 
-	if err := DB.Create(progress).Error; err != nil {
+	if err := s.db.Create(progress).Error; err != nil {
 		return nil, fmt.Errorf("failed to add progress: %w", err)
 	}
 
@@ -161,9 +161,9 @@ func AddChallengeProgress(challengeID, userID uint, update string) (*ChallengePr
 }
 
 // GetChallengeProgress gets progress updates for a challenge
-func GetChallengeProgress(challengeID uint) ([]ChallengeProgress, error) {
+func (s *Store) GetChallengeProgress(challengeID uint) ([]ChallengeProgress, error) {
 	var progress []ChallengeProgress
-	result := DB.Preload("User").
+	result := s.db.Preload("User").
 		Where("challenge_id = ?", challengeID).
 		Order("created_at DESC").
 		Find(&progress)
@@ -176,9 +176,9 @@ func GetChallengeProgress(challengeID uint) ([]ChallengeProgress, error) {
 }
 
 // SubmitChallengeCompletion marks a participant as pending validation
-func SubmitChallengeCompletion(challengeID, userID uint, proofURL string) (*ChallengeParticipant, error) {
+func (s *Store) SubmitChallengeCompletion(challengeID, userID uint, proofURL string) (*ChallengeParticipant, error) {
 	var participant ChallengeParticipant
-	result := DB.Where("challenge_id = ? AND user_id = ?", challengeID, userID).First(&participant)
+	result := s.db.Where("challenge_id = ? AND user_id = ?", challengeID, userID).First(&participant)
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, fmt.Errorf("you're not a participant in this challenge")
 	}
@@ -193,7 +193,7 @@ func SubmitChallengeCompletion(challengeID, userID uint, proofURL string) (*Chal
 	participant.Status = ChallengeParticipantStatusPendingValidation
 	participant.ProofURL = proofURL
 
-	if err := DB.Save(&participant).Error; err != nil {
+	if err := s.db.Save(&participant).Error; err != nil {
 		return nil, fmt.Errorf("failed to submit completion: %w", err)
 	}
 
@@ -201,10 +201,10 @@ func SubmitChallengeCompletion(challengeID, userID uint, proofURL string) (*Chal
 }
 
 // ValidateChallengeCompletion validates or rejects a completion
-func ValidateChallengeCompletion(challengeID, validatorID, targetUserID uint, approved bool) error {
+func (s *Store) ValidateChallengeCompletion(challengeID, validatorID, targetUserID uint, approved bool) error {
 	// Get the participant
 	var participant ChallengeParticipant
-	result := DB.Where("challenge_id = ? AND user_id = ?", challengeID, targetUserID).First(&participant)
+	result := s.db.Where("challenge_id = ? AND user_id = ?", challengeID, targetUserID).First(&participant)
 	if result.Error == gorm.ErrRecordNotFound {
 		return fmt.Errorf("participant not found")
 	}
@@ -218,7 +218,7 @@ func ValidateChallengeCompletion(challengeID, validatorID, targetUserID uint, ap
 
 	// Verify validator is a participant
 	var validatorParticipant ChallengeParticipant
-	result = DB.Where("challenge_id = ? AND user_id = ?", challengeID, validatorID).First(&validatorParticipant)
+	result = s.db.Where("challenge_id = ? AND user_id = ?", challengeID, validatorID).First(&validatorParticipant)
 	if result.Error != nil {
 		return fmt.Errorf("you're not a participant in this challenge")
 	}
@@ -228,7 +228,7 @@ func ValidateChallengeCompletion(challengeID, validatorID, targetUserID uint, ap
 		return fmt.Errorf("you can't validate your own submission")
 	}
 
-	err := DB.Transaction(func(tx *gorm.DB) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
 		// Create validation record
 		validation := ChallengeValidation{
 			ParticipantID: participant.ID,
@@ -260,10 +260,9 @@ func ValidateChallengeCompletion(challengeID, validatorID, targetUserID uint, ap
 				tx.First(&challenge, challengeID)
 				points := int(10 * challenge.PointsMultiplier) // Base 10 points * multiplier
 
-				var user User
-				if err := tx.First(&user, targetUserID).Error; err == nil {
-					user.TotalPoints += points
-					tx.Save(&user)
+				source := PointsSource{Type: PointsSourceChallenge, ID: challenge.ID, Note: challenge.Title}
+				if err := addMemberPoints(tx, targetUserID, challenge.GuildID, points, source); err != nil {
+					return err
 				}
 			}
 		} else {
@@ -282,9 +281,9 @@ func ValidateChallengeCompletion(challengeID, validatorID, targetUserID uint, ap
 }
 
 // GetChallengeParticipant gets a participant's status
-func GetChallengeParticipant(challengeID, userID uint) (*ChallengeParticipant, error) {
+func (s *Store) GetChallengeParticipant(challengeID, userID uint) (*ChallengeParticipant, error) {
 	var participant ChallengeParticipant
-	result := DB.Preload("User").Where("challenge_id = ? AND user_id = ?", challengeID, userID).First(&participant)
+	result := s.db.Preload("User").Where("challenge_id = ? AND user_id = ?", challengeID, userID).First(&participant)
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -295,18 +294,18 @@ func GetChallengeParticipant(challengeID, userID uint) (*ChallengeParticipant, e
 }
 
 // CheckAndFailExpiredChallenges marks expired challenges as failed
-func CheckAndFailExpiredChallenges() error {
+func (s *Store) CheckAndFailExpiredChallenges() error {
 	now := time.Now()
 
 	// Find active challenges that have ended
 	var challenges []Challenge
-	result := DB.Where("status = ? AND end_date < ?", ChallengeStatusActive, now).Find(&challenges)
+	result := s.db.Where("status = ? AND end_date < ?", ChallengeStatusActive, now).Find(&challenges)
 	if result.Error != nil {
 		return fmt.Errorf("failed to fetch expired challenges: %w", result.Error)
 	}
 
 	for _, challenge := range challenges {
-		err := DB.Transaction(func(tx *gorm.DB) error {
+		err := s.db.Transaction(func(tx *gorm.DB) error {
 			// Mark incomplete participants as failed
 			tx.Model(&ChallengeParticipant{}).
 				Where("challenge_id = ? AND status IN ?", challenge.ID,
@@ -337,11 +336,11 @@ func CheckAndFailExpiredChallenges() error {
 }
 
 // GetChallengesNeedingReminder gets active challenges for reminder
-func GetChallengesNeedingReminder(guildID string) ([]Challenge, error) {
+func (s *Store) GetChallengesNeedingReminder(guildID string) ([]Challenge, error) {
 	now := time.Now()
 	var challenges []Challenge
 
-	result := DB.Preload("Creator").
+	result := s.db.Preload("Creator").
 		Where("guild_id = ? AND status = ? AND end_date > ?", guildID, ChallengeStatusActive, now).
 		Find(&challenges)
 
@@ -353,9 +352,9 @@ func GetChallengesNeedingReminder(guildID string) ([]Challenge, error) {
 }
 
 // IsUserInChallenge checks if a user is a participant in a challenge
-func IsUserInChallenge(challengeID, userID uint) (bool, error) {
+func (s *Store) IsUserInChallenge(challengeID, userID uint) (bool, error) {
 	var count int64
-	result := DB.Model(&ChallengeParticipant{}).
+	result := s.db.Model(&ChallengeParticipant{}).
 		Where("challenge_id = ? AND user_id = ?", challengeID, userID).
 		Count(&count)
 
@@ -3,108 +3,277 @@ package database
 import (
 	"fmt"
 	"log"
+	"slices"
 	"time"
 
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-// DB is the global database connection
-var DB *gorm.DB
+// Supported database drivers
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+// Store owns a database connection and exposes every persistence operation.
+// Construct one per process (or per test) and pass it to the subsystems that need it.
+type Store struct {
+	db *gorm.DB
+}
+
+// NewStore wraps an existing gorm connection
+func NewStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Open connects to the database without running migrations.
+// dsn is a file path for SQLite or a connection string for PostgreSQL.
+func Open(driver, dsn string) (*Store, error) {
+	dialector, err := dialectorFor(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
 
-// Initialize sets up the database connection and runs migrations
-func Initialize(dbPath string) error {
-	var err error
-	DB, err = gorm.Open(sqlite.Open(dbPath), &gorm.Config{
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Warn),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
-	}
-
-	// Run migrations
-	err = DB.AutoMigrate(
-		&User{},
-		&FocusPeriod{},
-		&Task{},
-		&PublicResource{},
-		&PrivateResource{},
-		&PrivateResourceRole{},
-		&GuildConfig{},
-		&SprintPoints{},
-		// Phase 1: Daily Standups + Streaks
-		&Standup{},
-		&UserStreak{},
-		// Phase 2: Win Sharing
-		&Win{},
-		// Phase 3: Accountability Buddies
-		&BuddyRequest{},
-		&BuddyPair{},
-		// Phase 4: Challenge System
-		&Challenge{},
-		&ChallengeParticipant{},
-		&ChallengeProgress{},
-		&ChallengeValidation{},
-		// Phase 5: MRR Tracking
-		&MRREntry{},
-		&MRRSettings{},
-		// Phase 6: Project Channel Management
-		&ProjectMapping{},
-		&ProjectChannel{},
-	)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return NewStore(db), nil
+}
+
+// Initialize sets up the database connection and runs pending migrations
+func Initialize(driver, dsn string) (*Store, error) {
+	store, err := Open(driver, dsn)
 	if err != nil {
-		return fmt.Errorf("failed to run migrations: %w", err)
+		return nil, err
 	}
 
-	// Create composite unique index for PrivateResourceRole
-	err = DB.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_private_resource_role_unique ON private_resource_roles(private_resource_id, role_id)").Error
+	if err := Migrate(store.db); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	log.Printf("Database initialized successfully (%s)", driver)
+	return store, nil
+}
+
+// OpenInMemory returns a fully migrated store backed by a private in-memory SQLite database.
+// It is intended for tests that need an isolated store.
+func OpenInMemory() (*Store, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create unique index: %w", err)
+		return nil, fmt.Errorf("failed to open in-memory database: %w", err)
 	}
 
-	log.Println("Database initialized successfully")
-	return nil
+	// Every pooled connection to :memory: is a separate database
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := Migrate(db); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+	return NewStore(db), nil
 }
 
-// GetOrCreateUser gets an existing user or creates a new one
-func GetOrCreateUser(discordID, guildID, username string) (*User, error) {
+// DB returns the underlying gorm connection
+func (s *Store) DB() *gorm.DB {
+	return s.db
+}
+
+// Close releases the underlying connection pool
+func (s *Store) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// dialectorFor returns the gorm dialector for a driver name
+func dialectorFor(driver, dsn string) (gorm.Dialector, error) {
+	switch driver {
+	case DriverSQLite, "":
+		return sqlite.Open(dsn), nil
+	case DriverPostgres:
+		return postgres.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+}
+
+// migrateLegacyUsers moves guild_id/total_points from the users table into guild_members.
+// Older databases stored one user row per Discord ID with a single guild attached.
+func migrateLegacyUsers(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&v1User{}, "guild_id") {
+		return nil
+	}
+
+	log.Println("Migrating legacy users to guild memberships...")
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO guild_members (created_at, updated_at, user_id, guild_id, username, total_points)
+			SELECT u.created_at, u.updated_at, u.id, u.guild_id, u.username, COALESCE(u.total_points, 0)
+			FROM users u
+			WHERE u.deleted_at IS NULL
+			  AND u.guild_id IS NOT NULL AND u.guild_id != ''
+			  AND NOT EXISTS (
+				SELECT 1 FROM guild_members gm WHERE gm.user_id = u.id AND gm.guild_id = u.guild_id
+			  )
+		`).Error
+		if err != nil {
+			return fmt.Errorf("failed to copy memberships: %w", err)
+		}
+
+		if tx.Migrator().HasIndex(&v1User{}, "idx_users_guild_id") {
+			if err := tx.Migrator().DropIndex(&v1User{}, "idx_users_guild_id"); err != nil {
+				return fmt.Errorf("failed to drop legacy guild index: %w", err)
+			}
+		}
+
+		for _, column := range []string{"guild_id", "total_points"} {
+			if tx.Migrator().HasColumn(&v1User{}, column) {
+				if err := dropColumns(tx, &v1User{}, column); err != nil {
+					return fmt.Errorf("failed to drop legacy column %s: %w", column, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetOrCreateUser gets an existing user or creates a new one, and ensures
+// the user has a membership in the given guild
+func (s *Store) GetOrCreateUser(discordID, guildID, username string) (*User, error) {
 	var user User
-	result := DB.Where("discord_id = ? AND guild_id = ?", discordID, guildID).First(&user)
+	result := s.db.Where("discord_id = ?", discordID).First(&user)
 
 	if result.Error == gorm.ErrRecordNotFound {
 		user = User{
 			DiscordID: discordID,
-			GuildID:   guildID,
 			Username:  username,
 		}
-		if err := DB.Create(&user).Error; err != nil {
+		if err := s.db.Create(&user).Error; err != nil {
 			return nil, fmt.Errorf("failed to create user: %w", err)
 		}
-		return &user, nil
+	} else if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", result.Error)
+	} else if user.Username != username {
+		// Update username if changed
+		user.Username = username
+		s.db.Save(&user)
+	}
+
+	if _, err := s.GetOrCreateGuildMember(user.ID, guildID, username); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// GetOrCreateGuildMember gets or creates a user's membership in a guild
+func (s *Store) GetOrCreateGuildMember(userID uint, guildID, username string) (*GuildMember, error) {
+	var member GuildMember
+	result := s.db.Where("user_id = ? AND guild_id = ?", userID, guildID).First(&member)
+
+	if result.Error == gorm.ErrRecordNotFound {
+		member = GuildMember{
+			UserID:   userID,
+			GuildID:  guildID,
+			Username: username,
+		}
+		if err := s.db.Create(&member).Error; err != nil {
+			return nil, fmt.Errorf("failed to create guild member: %w", err)
+		}
+		return &member, nil
 	}
 
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to fetch user: %w", result.Error)
+		return nil, fmt.Errorf("failed to fetch guild member: %w", result.Error)
 	}
 
 	// Update username if changed
-	if user.Username != username {
-		user.Username = username
-		DB.Save(&user)
+	if username != "" && member.Username != username {
+		member.Username = username
+		s.db.Save(&member)
 	}
 
-	return &user, nil
+	return &member, nil
 }
 
-// GetCurrentFocusPeriod returns the active focus period for a user, if any
-func GetCurrentFocusPeriod(userID uint) (*FocusPeriod, error) {
+// GetGuildMember returns a user's membership in a guild, or nil if they aren't a member
+func (s *Store) GetGuildMember(userID uint, guildID string) (*GuildMember, error) {
+	var member GuildMember
+	result := s.db.Where("user_id = ? AND guild_id = ?", userID, guildID).First(&member)
+
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch guild member: %w", result.Error)
+	}
+
+	return &member, nil
+}
+
+// addMemberPoints adds points to a user's total in a guild, creating the membership if needed,
+// and records the change in the points ledger
+func addMemberPoints(tx *gorm.DB, userID uint, guildID string, points int, source PointsSource) error {
+	if err := recordPoints(tx, userID, guildID, points, source); err != nil {
+		return err
+	}
+
+	// Increment in the database so concurrent awards can't overwrite each other
+	result := tx.Model(&GuildMember{}).Where("user_id = ? AND guild_id = ?", userID, guildID).
+		Update("total_points", gorm.Expr("total_points + ?", points))
+	if result.Error != nil {
+		return fmt.Errorf("failed to update user points: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var user User
+	if err := tx.Where("id = ?", userID).First(&user).Error; err != nil {
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+	member := GuildMember{
+		UserID:      userID,
+		GuildID:     guildID,
+		Username:    user.Username,
+		TotalPoints: points,
+	}
+	if err := tx.Create(&member).Error; err != nil {
+		return fmt.Errorf("failed to create guild member: %w", err)
+	}
+
+	return nil
+}
+
+// GetCurrentFocusPeriod returns the active focus period for a user in a guild, if any
+func (s *Store) GetCurrentFocusPeriod(userID uint, guildID string) (*FocusPeriod, error) {
 	var period FocusPeriod
 	now := time.Now()
 
-	result := DB.Preload("Tasks", func(db *gorm.DB) *gorm.DB {
+	result := s.db.Preload("Tasks", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).Preload("Tasks.Steps", func(db *gorm.DB) *gorm.DB {
 		return db.Order("position ASC")
-	}).Where("user_id = ? AND start_date <= ? AND end_date >= ?", userID, now, now).First(&period)
+	}).Where("user_id = ? AND guild_id = ? AND start_date <= ? AND end_date >= ?", userID, guildID, now, now).First(&period)
 
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, nil
@@ -117,31 +286,55 @@ func GetCurrentFocusPeriod(userID uint) (*FocusPeriod, error) {
 	return &period, nil
 }
 
-// CreateFocusPeriod creates a new focus period for a user
-func CreateFocusPeriod(userID uint, guildID string) (*FocusPeriod, error) {
-	now := time.Now()
-	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	endDate := startDate.Add(FocusPeriodDuration)
+// CreateFocusPeriod creates a new focus period for a user, starting at midnight in their timezone
+// and running for the guild's configured number of days. In cohort mode the period joins the
+// guild's current sprint instead, returning ErrNoCohortSprint if none is running.
+func (s *Store) CreateFocusPeriod(userID uint, guildID string) (*FocusPeriod, error) {
+	config, err := s.GetGuildConfig(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var period FocusPeriod
+	if config.CohortSprints {
+		sprint, err := s.GetCurrentCohortSprint(guildID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if sprint == nil {
+			return nil, ErrNoCohortSprint
+		}
 
-	period := FocusPeriod{
-		UserID:    userID,
-		GuildID:   guildID,
-		StartDate: startDate,
-		EndDate:   endDate,
+		period = FocusPeriod{
+			UserID:    userID,
+			GuildID:   guildID,
+			StartDate: sprint.StartDate,
+			EndDate:   sprint.EndDate,
+			SprintID:  &sprint.ID,
+		}
+	} else {
+		start := StartOfDay(time.Now().In(s.UserLocation(userID, guildID)))
+
+		period = FocusPeriod{
+			UserID:    userID,
+			GuildID:   guildID,
+			StartDate: start.Local(),
+			EndDate:   start.AddDate(0, 0, config.FocusLength()).Local(),
+		}
 	}
 
-	if err := DB.Create(&period).Error; err != nil {
+	if err := s.db.Create(&period).Error; err != nil {
 		return nil, fmt.Errorf("failed to create focus period: %w", err)
 	}
 
 	return &period, nil
 }
 
-// AddTask adds a task to a focus period
-func AddTask(focusPeriodID uint, title, description string, points int) (*Task, error) {
+// AddTask adds a task to a focus period. dueDate may be nil for tasks without a deadline.
+func (s *Store) AddTask(focusPeriodID uint, title, description string, estimate estimator.Estimate, dueDate *time.Time) (*Task, error) {
 	// Get the next position
 	var maxPosition int
-	DB.Model(&Task{}).Where("focus_period_id = ?", focusPeriodID).Select("COALESCE(MAX(position), 0)").Scan(&maxPosition)
+	s.db.Model(&Task{}).Where("focus_period_id = ?", focusPeriodID).Select("COALESCE(MAX(position), 0)").Scan(&maxPosition)
 
 	task := Task{
 		FocusPeriodID: focusPeriodID,
@@ -149,10 +342,12 @@ func AddTask(focusPeriodID uint, title, description string, points int) (*Task,
 		Description:   description,
 		Completed:     false,
 		Position:      maxPosition + 1,
-		Points:        points,
+		Points:        estimate.Points,
+		DueDate:       dueDate,
+		Rationale:     estimate.Rationale,
 	}
 
-	if err := DB.Create(&task).Error; err != nil {
+	if err := s.db.Create(&task).Error; err != nil {
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 
@@ -160,9 +355,9 @@ func AddTask(focusPeriodID uint, title, description string, points int) (*Task,
 }
 
 // CompleteTask marks a task as completed
-func CompleteTask(focusPeriodID uint, position int) (*Task, error) {
+func (s *Store) CompleteTask(focusPeriodID uint, position int) (*Task, error) {
 	var task Task
-	result := DB.Where("focus_period_id = ? AND position = ?", focusPeriodID, position).First(&task)
+	result := s.db.Where("focus_period_id = ? AND position = ?", focusPeriodID, position).First(&task)
 
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, fmt.Errorf("task #%d not found", position)
@@ -180,17 +375,19 @@ func CompleteTask(focusPeriodID uint, position int) (*Task, error) {
 	task.Completed = true
 	task.CompletedAt = &now
 
-	if err := DB.Save(&task).Error; err != nil {
+	if err := s.db.Save(&task).Error; err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
 	return &task, nil
 }
 
-// GetTasksByFocusPeriod returns all tasks for a focus period
-func GetTasksByFocusPeriod(focusPeriodID uint) ([]Task, error) {
+// GetTasksByFocusPeriod returns all tasks for a focus period along with their steps
+func (s *Store) GetTasksByFocusPeriod(focusPeriodID uint) ([]Task, error) {
 	var tasks []Task
-	result := DB.Where("focus_period_id = ?", focusPeriodID).Order("position ASC").Find(&tasks)
+	result := s.db.Preload("Steps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).Where("focus_period_id = ?", focusPeriodID).Order("position ASC").Find(&tasks)
 
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to fetch tasks: %w", result.Error)
@@ -200,11 +397,11 @@ func GetTasksByFocusPeriod(focusPeriodID uint) ([]Task, error) {
 }
 
 // GetUsersWithActiveFocusPeriods returns all users who have active focus periods in a guild
-func GetUsersWithActiveFocusPeriods(guildID string) ([]FocusPeriod, error) {
+func (s *Store) GetUsersWithActiveFocusPeriods(guildID string) ([]FocusPeriod, error) {
 	var periods []FocusPeriod
 	now := time.Now()
 
-	result := DB.Preload("User").Preload("Tasks").
+	result := s.db.Preload("User").Preload("Tasks").
 		Where("guild_id = ? AND start_date <= ? AND end_date >= ?", guildID, now, now).
 		Find(&periods)
 
@@ -216,8 +413,8 @@ func GetUsersWithActiveFocusPeriods(guildID string) ([]FocusPeriod, error) {
 }
 
 // GetUsersWithInsufficientTasks returns users who have less than minimum required tasks
-func GetUsersWithInsufficientTasks(guildID string) ([]FocusPeriod, error) {
-	periods, err := GetUsersWithActiveFocusPeriods(guildID)
+func (s *Store) GetUsersWithInsufficientTasks(guildID string) ([]FocusPeriod, error) {
+	periods, err := s.GetUsersWithActiveFocusPeriods(guildID)
 	if err != nil {
 		return nil, err
 	}
@@ -233,11 +430,11 @@ func GetUsersWithInsufficientTasks(guildID string) ([]FocusPeriod, error) {
 }
 
 // GetAllGuildsWithActivePeriods returns all guild IDs that have active focus periods
-func GetAllGuildsWithActivePeriods() ([]string, error) {
+func (s *Store) GetAllGuildsWithActivePeriods() ([]string, error) {
 	var guildIDs []string
 	now := time.Now()
 
-	result := DB.Model(&FocusPeriod{}).
+	result := s.db.Model(&FocusPeriod{}).
 		Distinct("guild_id").
 		Where("start_date <= ? AND end_date >= ?", now, now).
 		Pluck("guild_id", &guildIDs)
@@ -249,16 +446,27 @@ func GetAllGuildsWithActivePeriods() ([]string, error) {
 	return guildIDs, nil
 }
 
-// GetFocusPeriodsForReminder returns focus periods that need a reminder on the current day
-func GetFocusPeriodsForReminder(guildID string, dayNumber int) ([]FocusPeriod, error) {
-	periods, err := GetUsersWithActiveFocusPeriods(guildID)
+// GetFocusPeriodsForReminder returns focus periods with pending tasks whose current day,
+// in each member's timezone, is one of the guild's reminder days for that period's length
+func (s *Store) GetFocusPeriodsForReminder(guildID string, now time.Time) ([]FocusPeriod, error) {
+	config, err := s.GetGuildConfig(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	periods, err := s.GetUsersWithActiveFocusPeriods(guildID)
 	if err != nil {
 		return nil, err
 	}
 
+	points := config.ReminderPoints()
 	var needsReminder []FocusPeriod
 	for _, period := range periods {
-		if period.DayNumber() == dayNumber && period.PendingTaskCount() > 0 {
+		if period.PendingTaskCount() == 0 {
+			continue
+		}
+		dayNumber := period.DayNumberAt(now.In(s.UserLocation(period.UserID, guildID)))
+		if slices.Contains(ReminderDaysFor(period.LengthDays(), points), dayNumber) {
 			needsReminder = append(needsReminder, period)
 		}
 	}
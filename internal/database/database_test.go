@@ -0,0 +1,113 @@
+package database
+
+import (
+	"os"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// openTestDB opens an isolated database for a test.
+// Tests run against in-memory SQLite by default; set TEST_DATABASE_URL to a
+// throwaway PostgreSQL database to run the suite against PostgreSQL instead.
+// The public schema of that database is wiped before every test.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	driver, dsn := DriverSQLite, ":memory:"
+	if url := os.Getenv("TEST_DATABASE_URL"); url != "" {
+		driver, dsn = DriverPostgres, url
+	}
+
+	dialector, err := dialectorFor(driver, dsn)
+	if err != nil {
+		t.Fatalf("Failed to build dialector: %v", err)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+
+	if driver == DriverPostgres {
+		if err := db.Exec("DROP SCHEMA public CASCADE; CREATE SCHEMA public").Error; err != nil {
+			t.Fatalf("Failed to reset test schema: %v", err)
+		}
+	} else {
+		// Every pooled connection to :memory: is a separate database
+		sqlDB, err := db.DB()
+		if err != nil {
+			t.Fatalf("Failed to get database handle: %v", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	return db
+}
+
+func TestDialectorFor(t *testing.T) {
+	tests := []struct {
+		driver   string
+		expected string
+		hasError bool
+	}{
+		{driver: DriverSQLite, expected: "sqlite"},
+		{driver: "", expected: "sqlite"},
+		{driver: DriverPostgres, expected: "postgres"},
+		{driver: "mysql", hasError: true},
+	}
+
+	for _, tt := range tests {
+		dialector, err := dialectorFor(tt.driver, "dsn")
+		if tt.hasError {
+			if err == nil {
+				t.Errorf("Expected error for driver %q", tt.driver)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for driver %q: %v", tt.driver, err)
+			continue
+		}
+		if dialector.Name() != tt.expected {
+			t.Errorf("Expected dialector %s for driver %q, got %s", tt.expected, tt.driver, dialector.Name())
+		}
+	}
+}
+
+func TestOpenInMemoryStoresAreIsolated(t *testing.T) {
+	storeA, err := OpenInMemory()
+	if err != nil {
+		t.Fatalf("Failed to open store A: %v", err)
+	}
+	defer storeA.Close()
+
+	storeB, err := OpenInMemory()
+	if err != nil {
+		t.Fatalf("Failed to open store B: %v", err)
+	}
+	defer storeB.Close()
+
+	if err := storeA.UpdateLeaderboardChannel("guild-1", "channel-a"); err != nil {
+		t.Fatalf("Failed to update leaderboard channel: %v", err)
+	}
+
+	channelB, err := storeB.GetLeaderboardChannel("guild-1")
+	if err != nil {
+		t.Fatalf("Failed to get leaderboard channel: %v", err)
+	}
+	if channelB != "" {
+		t.Errorf("Expected store B to be empty, got channel %q", channelB)
+	}
+
+	channelA, _ := storeA.GetLeaderboardChannel("guild-1")
+	if channelA != "channel-a" {
+		t.Errorf("Expected channel-a in store A, got %q", channelA)
+	}
+}
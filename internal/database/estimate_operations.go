@@ -0,0 +1,40 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetPointEstimate returns the cached estimate for a goal's cache key, with ok false on a miss
+func (s *Store) GetPointEstimate(key string) (estimator.Estimate, bool, error) {
+	var cached PointEstimate
+	result := s.db.Where("cache_key = ?", key).First(&cached)
+
+	if result.Error == gorm.ErrRecordNotFound {
+		return estimator.Estimate{}, false, nil
+	}
+
+	if result.Error != nil {
+		return estimator.Estimate{}, false, fmt.Errorf("failed to fetch point estimate: %w", result.Error)
+	}
+
+	return estimator.Estimate{Points: cached.Points, Rationale: cached.Rationale}, true, nil
+}
+
+// SavePointEstimate caches the estimate for a goal's cache key, replacing any earlier estimate
+func (s *Store) SavePointEstimate(key string, estimate estimator.Estimate) error {
+	cached := PointEstimate{CacheKey: key, Points: estimate.Points, Rationale: estimate.Rationale}
+	result := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cache_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"points", "rationale_time", "rationale_complexity", "rationale_impact", "updated_at"}),
+	}).Create(&cached)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to save point estimate: %w", result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+)
+
+func TestPointEstimateCache(t *testing.T) {
+	store := setupTestDB(t)
+	if err := store.db.AutoMigrate(&PointEstimate{}); err != nil {
+		t.Fatalf("Failed to migrate point estimates: %v", err)
+	}
+
+	if _, ok, err := store.GetPointEstimate("key-1"); err != nil || ok {
+		t.Fatalf("Expected a miss for an unknown key, got ok=%v err=%v", ok, err)
+	}
+
+	if err := store.SavePointEstimate("key-1", estimator.Estimate{Points: 6}); err != nil {
+		t.Fatalf("Failed to save estimate: %v", err)
+	}
+	if err := store.SavePointEstimate("key-1", estimator.Estimate{Points: 8, Rationale: estimator.Rationale{Impact: "Unlocks revenue"}}); err != nil {
+		t.Fatalf("Failed to replace estimate: %v", err)
+	}
+
+	estimate, ok, err := store.GetPointEstimate("key-1")
+	if err != nil || !ok {
+		t.Fatalf("Expected a hit, got ok=%v err=%v", ok, err)
+	}
+	if estimate.Points != 8 || estimate.Impact != "Unlocks revenue" {
+		t.Errorf("Expected the latest estimate of 8 with its rationale, got %+v", estimate)
+	}
+
+	var count int64
+	store.db.Model(&PointEstimate{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected one cached row per key, got %d", count)
+	}
+}
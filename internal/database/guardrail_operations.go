@@ -0,0 +1,333 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// Bounds for the guardrail settings
+const (
+	MaxMinCompleteMinutes = 24 * 60
+	MaxPeriodPointCap     = 1000
+)
+
+// duplicateSimilarity is how much of two goals' wording must overlap for them to count as duplicates
+const duplicateSimilarity = 0.8
+
+// UpdateGuardrails sets a guild's guardrails against farming points
+func (s *Store) UpdateGuardrails(guildID string, minCompleteMinutes, periodPointCap int, duplicateCheck bool) error {
+	if minCompleteMinutes < 0 || minCompleteMinutes > MaxMinCompleteMinutes {
+		return fmt.Errorf("minimum time must be between 0 and %d minutes", MaxMinCompleteMinutes)
+	}
+	if periodPointCap < 0 || periodPointCap > MaxPeriodPointCap {
+		return fmt.Errorf("point cap must be between 0 and %d", MaxPeriodPointCap)
+	}
+
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	config.MinCompleteMinutes = minCompleteMinutes
+	config.PeriodPointCap = periodPointCap
+	config.DuplicateGoalCheck = duplicateCheck
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update guardrails: %w", err)
+	}
+
+	return nil
+}
+
+// Completion is the outcome of completing a task under a guild's guardrails
+type Completion struct {
+	Task   *Task
+	Earned int                // Points credited now
+	Capped int                // Points not credited because of the period cap
+	Flag   *FlaggedCompletion // Set when the points were held for review
+}
+
+// AwardTaskCompletion marks a task as completed and credits the points it has left to earn.
+// If the completion trips a guardrail the points are held for an admin to review instead,
+// and points that would take the member past the period cap are not credited.
+func (s *Store) AwardTaskCompletion(period *FocusPeriod, position int) (*Completion, error) {
+	config, err := s.GetGuildConfig(period.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	completion := &Completion{}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		task, err := findTask(tx, period.ID, position)
+		if err != nil {
+			return err
+		}
+		if task.Completed {
+			return fmt.Errorf("task #%d is already completed", position)
+		}
+
+		now := time.Now()
+		task.Completed = true
+		task.CompletedAt = &now
+		completion.Task = task
+
+		reasons, err := suspiciousCompletion(tx, config, period, task, now)
+		if err != nil {
+			return err
+		}
+
+		// A flagged goal holds all of its points, including any already credited for its steps
+		if len(reasons) > 0 && task.Points > 0 {
+			if _, err := setStepPoints(tx, config, period, task, 0); err != nil {
+				return err
+			}
+		}
+
+		remaining := task.Points - task.StepPoints
+		if len(reasons) > 0 && remaining > 0 {
+			completion.Flag = &FlaggedCompletion{
+				TaskID:     task.ID,
+				UserID:     period.UserID,
+				GuildID:    period.GuildID,
+				Reasons:    strings.Join(reasons, "\n"),
+				HeldPoints: remaining,
+				Status:     FlagPending,
+			}
+			if err := tx.Create(completion.Flag).Error; err != nil {
+				return fmt.Errorf("failed to flag completion: %w", err)
+			}
+			task.WithheldPoints = remaining
+		} else {
+			if completion.Earned, err = capPeriodPoints(tx, config, period, remaining); err != nil {
+				return err
+			}
+			completion.Capped = remaining - completion.Earned
+			task.WithheldPoints = completion.Capped
+		}
+
+		if err := tx.Save(task).Error; err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+
+		if completion.Earned == 0 {
+			return nil
+		}
+		source := PointsSource{Type: PointsSourceTaskCompleted, ID: task.ID, Note: task.Title}
+		if err := addMemberPoints(tx, period.UserID, period.GuildID, completion.Earned, source); err != nil {
+			return err
+		}
+		return addSprintPoints(tx, period.UserID, period.ID, completion.Earned, period.GuildID, period.StartDate, period.EndDate)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return completion, nil
+}
+
+// capPeriodPoints returns how many of points a member can still earn in a period under the guild's point cap
+func capPeriodPoints(tx *gorm.DB, config *GuildConfig, period *FocusPeriod, points int) (int, error) {
+	if config.PeriodPointCap <= 0 || points <= 0 {
+		return points, nil
+	}
+
+	var earned int
+	if err := tx.Model(&SprintPoints{}).Where("focus_period_id = ? AND user_id = ?", period.ID, period.UserID).
+		Select("COALESCE(SUM(points), 0)").Scan(&earned).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch sprint points: %w", err)
+	}
+	return min(points, max(config.PeriodPointCap-earned, 0)), nil
+}
+
+// suspiciousCompletion returns why completing a task looks like farming points, if it does
+func suspiciousCompletion(tx *gorm.DB, config *GuildConfig, period *FocusPeriod, task *Task, now time.Time) ([]string, error) {
+	var reasons []string
+
+	// Carried-over goals were started in an earlier period, so they may be finished straight away
+	if config.MinCompleteMinutes > 0 && task.CarriedFromID == nil {
+		if elapsed := now.Sub(task.CreatedAt); elapsed < time.Duration(config.MinCompleteMinutes)*time.Minute {
+			reasons = append(reasons, fmt.Sprintf("Completed %s after it was added (minimum %d min)", roundElapsed(elapsed), config.MinCompleteMinutes))
+		}
+	}
+
+	if config.DuplicateGoalCheck {
+		match, err := similarCompletedGoal(tx, period.UserID, period.GuildID, task.Title, task.ID)
+		if err != nil {
+			return nil, err
+		}
+		if match != nil {
+			reasons = append(reasons, fmt.Sprintf("Matches \"%s\", completed %s", match.Title, match.CompletedAt.Format("Jan 2, 2006")))
+		}
+	}
+
+	return reasons, nil
+}
+
+// roundElapsed describes a short duration in whole seconds or minutes
+func roundElapsed(elapsed time.Duration) string {
+	if elapsed < time.Minute {
+		return fmt.Sprintf("%ds", int(elapsed.Seconds()))
+	}
+	return fmt.Sprintf("%dm", int(elapsed.Minutes()))
+}
+
+// FindSimilarCompletedGoal returns a goal the user already completed in a guild that matches title, or nil if there is none
+func (s *Store) FindSimilarCompletedGoal(userID uint, guildID, title string) (*Task, error) {
+	return similarCompletedGoal(s.db, userID, guildID, title, 0)
+}
+
+// similarCompletedGoal returns the user's most recently completed goal matching title, skipping excludeTaskID
+func similarCompletedGoal(tx *gorm.DB, userID uint, guildID, title string, excludeTaskID uint) (*Task, error) {
+	words := goalWords(title)
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	var completed []Task
+	if err := tx.Select("tasks.id, tasks.title, tasks.completed_at").
+		Joins("JOIN focus_periods ON focus_periods.id = tasks.focus_period_id").
+		Where("focus_periods.user_id = ? AND focus_periods.guild_id = ? AND tasks.completed = ? AND tasks.id <> ?", userID, guildID, true, excludeTaskID).
+		Order("tasks.completed_at DESC").
+		Find(&completed).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch completed tasks: %w", err)
+	}
+
+	for idx := range completed {
+		if wordOverlap(words, goalWords(completed[idx].Title)) >= duplicateSimilarity {
+			return &completed[idx], nil
+		}
+	}
+	return nil, nil
+}
+
+// fillerWords are ignored when comparing goals
+var fillerWords = map[string]bool{
+	"a": true, "an": true, "the": true, "to": true, "and": true, "of": true,
+	"for": true, "my": true, "on": true, "in": true, "with": true,
+}
+
+// goalWords returns the distinct meaningful words of a goal, lowercased
+func goalWords(title string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		if !fillerWords[word] {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// wordOverlap returns the share of words two goals have in common, from 0 to 1
+func wordOverlap(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// GetPendingFlaggedCompletions returns a guild's completions awaiting review, oldest first
+func (s *Store) GetPendingFlaggedCompletions(guildID string) ([]FlaggedCompletion, error) {
+	var flags []FlaggedCompletion
+	result := s.db.Preload("Task").Preload("User").
+		Where("guild_id = ? AND status = ?", guildID, FlagPending).
+		Order("created_at ASC").
+		Find(&flags)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch flagged completions: %w", result.Error)
+	}
+
+	return flags, nil
+}
+
+// ReviewFlaggedCompletion records an admin's decision on a flagged completion.
+// Approving credits the held points up to the period cap, leaving the rest withheld; rejecting leaves them all withheld.
+// It returns the flag, the points credited and the points not credited because of the cap.
+func (s *Store) ReviewFlaggedCompletion(guildID string, flagID, reviewerID uint, approve bool) (*FlaggedCompletion, int, int, error) {
+	config, err := s.GetGuildConfig(guildID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	var flag FlaggedCompletion
+	var credited, capped int
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Preload("Task").Preload("User").Where("guild_id = ?", guildID).First(&flag, flagID)
+		if result.Error == gorm.ErrRecordNotFound {
+			return fmt.Errorf("flagged completion %d not found", flagID)
+		}
+		if result.Error != nil {
+			return fmt.Errorf("failed to fetch flagged completion: %w", result.Error)
+		}
+		if flag.Status != FlagPending {
+			return fmt.Errorf("flagged completion %d has already been reviewed", flagID)
+		}
+
+		now := time.Now()
+		flag.Status = FlagRejected
+		if approve {
+			flag.Status = FlagApproved
+		}
+		flag.ReviewerID = &reviewerID
+		flag.ReviewedAt = &now
+		if err := tx.Save(&flag).Error; err != nil {
+			return fmt.Errorf("failed to update flagged completion: %w", err)
+		}
+
+		if !approve {
+			return nil
+		}
+
+		var period FocusPeriod
+		if err := tx.First(&period, flag.Task.FocusPeriodID).Error; err != nil {
+			return fmt.Errorf("failed to fetch focus period: %w", err)
+		}
+
+		// The goal may have been re-scored since, so never credit more than is still withheld
+		held := min(flag.HeldPoints, flag.Task.WithheldPoints)
+		if held <= 0 {
+			return nil
+		}
+		var err error
+		if credited, err = capPeriodPoints(tx, config, &period, held); err != nil {
+			return err
+		}
+		capped = held - credited
+		if credited == 0 {
+			return nil
+		}
+		flag.Task.WithheldPoints -= credited
+		if err := tx.Model(&flag.Task).Update("withheld_points", flag.Task.WithheldPoints).Error; err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+		source := PointsSource{Type: PointsSourceReview, ID: flag.ID, Note: flag.Task.Title}
+		if err := addMemberPoints(tx, period.UserID, period.GuildID, credited, source); err != nil {
+			return err
+		}
+		return addSprintPoints(tx, period.UserID, period.ID, credited, period.GuildID, period.StartDate, period.EndDate)
+	})
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return &flag, credited, capped, nil
+}
+
+// clearTaskFlags removes a task's pending flags once its completion is undone or the task is deleted
+func clearTaskFlags(tx *gorm.DB, taskID uint) error {
+	if err := tx.Unscoped().Where("task_id = ? AND status = ?", taskID, FlagPending).Delete(&FlaggedCompletion{}).Error; err != nil {
+		return fmt.Errorf("failed to clear flagged completions: %w", err)
+	}
+	return nil
+}
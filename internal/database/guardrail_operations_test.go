@@ -0,0 +1,180 @@
+package database
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+)
+
+func TestAwardTaskCompletionGuardrails(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	admin, _ := store.GetOrCreateUser("user-2", guildID, "admin")
+	period, _ := store.CreateFocusPeriod(user.ID, guildID)
+
+	if err := store.UpdateGuardrails(guildID, 10, 12, true); err != nil {
+		t.Fatalf("Failed to update guardrails: %v", err)
+	}
+	if err := store.UpdateGuardrails(guildID, -1, 0, true); err == nil {
+		t.Error("Expected a negative minimum to be rejected")
+	}
+
+	memberPoints := func() int {
+		t.Helper()
+		member, _ := store.GetGuildMember(user.ID, guildID)
+		return member.TotalPoints
+	}
+	addAged := func(title string, points int) {
+		t.Helper()
+		task, _ := store.AddTask(period.ID, title, "", estimator.Estimate{Points: points}, nil)
+		store.db.Model(task).Update("created_at", time.Now().Add(-time.Hour))
+	}
+
+	// Completing straight after adding holds the points
+	store.AddTask(period.ID, "Reply to an email", "", estimator.Estimate{Points: 2}, nil)
+	completion, err := store.AwardTaskCompletion(period, 1)
+	if err != nil {
+		t.Fatalf("Failed to complete task: %v", err)
+	}
+	if completion.Flag == nil || completion.Earned != 0 || !strings.Contains(completion.Flag.Reasons, "minimum 10 min") {
+		t.Fatalf("Expected the quick completion to be held, got %+v", completion)
+	}
+	if _, err := store.AwardTaskCompletion(period, 1); err == nil {
+		t.Error("Expected completing twice to be rejected")
+	}
+
+	// A goal given time is awarded in full
+	addAged("Launch the pricing page", 8)
+	completion, _ = store.AwardTaskCompletion(period, 2)
+	if completion.Flag != nil || completion.Earned != 8 {
+		t.Errorf("Expected 8 points awarded, got %+v", completion)
+	}
+
+	// Rewording a completed goal counts as a duplicate, and the cap limits what's left
+	addAged("Launch pricing page!", 8)
+	completion, _ = store.AwardTaskCompletion(period, 3)
+	if completion.Flag == nil || !strings.Contains(completion.Flag.Reasons, "Launch the pricing page") {
+		t.Errorf("Expected a near-duplicate to be held, got %+v", completion)
+	}
+	addAged("Interview five customers", 6)
+	completion, _ = store.AwardTaskCompletion(period, 4)
+	if completion.Earned != 4 || completion.Capped != 2 || completion.Task.WithheldPoints != 2 {
+		t.Errorf("Expected 4 points up to the cap and 2 capped, got %+v", completion)
+	}
+	if got := memberPoints(); got != 12 {
+		t.Errorf("Expected 12 points, got %d", got)
+	}
+
+	flags, _ := store.GetPendingFlaggedCompletions(guildID)
+	if len(flags) != 2 || flags[0].Task.Title != "Reply to an email" {
+		t.Fatalf("Expected two held completions, oldest first, got %+v", flags)
+	}
+
+	// Admins decide what's owed: approving credits the held points, up to the cap
+	store.UpdateGuardrails(guildID, 10, 13, true)
+	flag, credited, capped, err := store.ReviewFlaggedCompletion(guildID, flags[0].ID, admin.ID, true)
+	if err != nil || credited != 1 || capped != 1 || flag.Task.WithheldPoints != 1 {
+		t.Errorf("Expected 1 point credited up to the cap and 1 left withheld, got %d, %d capped (%v)", credited, capped, err)
+	}
+	if _, _, _, err := store.ReviewFlaggedCompletion(guildID, flags[0].ID, admin.ID, false); err == nil {
+		t.Error("Expected a reviewed completion to stay reviewed")
+	}
+	if _, credited, _, _ := store.ReviewFlaggedCompletion(guildID, flags[1].ID, admin.ID, false); credited != 0 {
+		t.Errorf("Expected a rejection to credit nothing, got %d", credited)
+	}
+	if got := memberPoints(); got != 13 {
+		t.Errorf("Expected 13 points after review, got %d", got)
+	}
+
+	// Reopening only takes back what was credited
+	if _, _, err := store.ReopenTask(period, 3); err != nil {
+		t.Fatalf("Failed to reopen task: %v", err)
+	}
+	if got := memberPoints(); got != 13 {
+		t.Errorf("Expected the rejected goal's withheld points not to be taken back, got %d", got)
+	}
+	if _, lost, err := store.ReopenTask(period, 4); err != nil || lost != 4 {
+		t.Errorf("Expected reopening the capped goal to take back 4 points, got %d (%v)", lost, err)
+	}
+	if got := memberPoints(); got != 9 {
+		t.Errorf("Expected 9 points after reopening, got %d", got)
+	}
+}
+
+func TestStepPointsFollowGuardrails(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	period, _ := store.CreateFocusPeriod(user.ID, guildID)
+	store.SetStepPointsEnabled(guildID, true)
+	store.UpdateGuardrails(guildID, 10, 6, false)
+
+	memberPoints := func() int {
+		t.Helper()
+		member, _ := store.GetGuildMember(user.ID, guildID)
+		return member.TotalPoints
+	}
+
+	// Checking every step of a fresh goal earns no more than the cap
+	store.AddTask(period.ID, "Ship the onboarding flow", "", estimator.Estimate{Points: 8}, nil)
+	store.AddTaskStep(period, 1, "Design")
+	store.AddTaskStep(period, 1, "Build")
+	store.SetTaskStepCompleted(period, 1, 1, true)
+	task, change, err := store.SetTaskStepCompleted(period, 1, 2, true)
+	if err != nil {
+		t.Fatalf("Failed to check step: %v", err)
+	}
+	if change != 2 || task.StepPoints != 6 || memberPoints() != 6 {
+		t.Errorf("Expected the steps capped at 6 points, got %+d, %d step points and %d total", change, task.StepPoints, memberPoints())
+	}
+
+	// Completing it straight away holds every point, including those credited for steps
+	completion, err := store.AwardTaskCompletion(period, 1)
+	if err != nil {
+		t.Fatalf("Failed to complete task: %v", err)
+	}
+	if completion.Flag == nil || completion.Flag.HeldPoints != 8 || completion.Earned != 0 {
+		t.Fatalf("Expected the step-only completion to be held in full, got %+v", completion)
+	}
+	if got := memberPoints(); got != 0 {
+		t.Errorf("Expected the step points taken back while held, got %d", got)
+	}
+	if credited := completion.Task.CreditedPoints(); credited != 0 {
+		t.Errorf("Expected nothing credited for the held goal, got %d", credited)
+	}
+}
+
+func TestFindSimilarCompletedGoal(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	period, _ := store.CreateFocusPeriod(user.ID, guildID)
+	store.AddTask(period.ID, "Write the launch blog post", "", estimator.Estimate{Points: 3}, nil)
+	store.CompleteTask(period.ID, 1)
+
+	tests := []struct {
+		title string
+		want  bool
+	}{
+		{"write launch blog post", true},
+		{"Write the launch blog post.", true},
+		{"Write the pricing blog post", false},
+		{"Launch", false},
+	}
+	for _, tt := range tests {
+		match, err := store.FindSimilarCompletedGoal(user.ID, guildID, tt.title)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if (match != nil) != tt.want {
+			t.Errorf("FindSimilarCompletedGoal(%q) matched %v, want %v", tt.title, match != nil, tt.want)
+		}
+	}
+
+	if match, _ := store.FindSimilarCompletedGoal(user.ID, "other-guild", "Write the launch blog post"); match != nil {
+		t.Error("Expected other guilds' goals to be ignored")
+	}
+}
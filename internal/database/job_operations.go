@@ -0,0 +1,91 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// RegisterScheduledJob creates the job's record if needed and refreshes its description
+func (s *Store) RegisterScheduledJob(name, description, schedule string) error {
+	job := ScheduledJob{Name: name}
+	if err := s.db.Where(ScheduledJob{Name: name}).FirstOrCreate(&job).Error; err != nil {
+		return fmt.Errorf("failed to register job %s: %w", name, err)
+	}
+
+	return s.db.Model(&job).Updates(map[string]interface{}{
+		"description": description,
+		"schedule":    schedule,
+	}).Error
+}
+
+// ClaimScheduledJob marks a job as started for a period.
+// It returns false if the period was already claimed, so concurrent or
+// restarted schedulers never deliver the same period twice.
+func (s *Store) ClaimScheduledJob(name, period string, startedAt time.Time) (bool, error) {
+	result := s.db.Model(&ScheduledJob{}).
+		Where("name = ? AND last_period <> ?", name, period).
+		Updates(map[string]interface{}{
+			"last_period":     period,
+			"last_started_at": startedAt,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim job %s: %w", name, result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// FinishScheduledJob records the outcome of a claimed run.
+// A failed run releases its claim so the period is retried on the next check.
+func (s *Store) FinishScheduledJob(name, period string, finishedAt time.Time, runErr error) error {
+	updates := map[string]interface{}{
+		"last_run_at": finishedAt,
+		"last_error":  "",
+	}
+	if runErr != nil {
+		updates = map[string]interface{}{
+			"last_period": "",
+			"last_error":  runErr.Error(),
+		}
+	}
+
+	result := s.db.Model(&ScheduledJob{}).
+		Where("name = ? AND last_period = ?", name, period).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to record job %s: %w", name, result.Error)
+	}
+
+	return nil
+}
+
+// GetScheduledJobs returns every registered job ordered by name
+func (s *Store) GetScheduledJobs() ([]ScheduledJob, error) {
+	var jobs []ScheduledJob
+	result := s.db.Order("name ASC").Find(&jobs)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch jobs: %w", result.Error)
+	}
+
+	return jobs, nil
+}
+
+// ClaimReminder marks a reminder as delivered to one recipient for a period.
+// It returns false if that recipient already got the reminder for the period.
+func (s *Store) ClaimReminder(kind string, userID uint, guildID, period string) (bool, error) {
+	delivery := ReminderDelivery{
+		Kind:    kind,
+		UserID:  userID,
+		GuildID: guildID,
+		Period:  period,
+	}
+
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&delivery)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim reminder %s: %w", kind, result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
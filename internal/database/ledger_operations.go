@@ -0,0 +1,145 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// PointsSource says why a member's points changed
+type PointsSource struct {
+	Type string // One of the PointsSource constants
+	ID   uint   // Record the points came from, e.g. the task or standup
+	Note string
+}
+
+// recordPoints writes a change to a member's points to the ledger
+func recordPoints(tx *gorm.DB, userID uint, guildID string, points int, source PointsSource) error {
+	if points == 0 {
+		return nil
+	}
+
+	entry := PointsTransaction{
+		UserID:     userID,
+		GuildID:    guildID,
+		Amount:     points,
+		SourceType: source.Type,
+		SourceID:   source.ID,
+		Note:       source.Note,
+	}
+	if err := tx.Create(&entry).Error; err != nil {
+		return fmt.Errorf("failed to record points: %w", err)
+	}
+	return nil
+}
+
+// GetPointsHistory returns a member's most recent points transactions in a guild, newest first
+func (s *Store) GetPointsHistory(userID uint, guildID string, limit int) ([]PointsTransaction, error) {
+	var entries []PointsTransaction
+	result := s.db.Where("user_id = ? AND guild_id = ?", userID, guildID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&entries)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch points history: %w", result.Error)
+	}
+
+	return entries, nil
+}
+
+// PointsBreakdownEntry totals a member's points from one source
+type PointsBreakdownEntry struct {
+	SourceType string
+	Points     int
+	Count      int
+}
+
+// GetPointsBreakdown totals a member's points in a guild by source, largest first
+func (s *Store) GetPointsBreakdown(userID uint, guildID string) ([]PointsBreakdownEntry, error) {
+	var entries []PointsBreakdownEntry
+	result := s.db.Model(&PointsTransaction{}).
+		Select("source_type, SUM(amount) as points, COUNT(*) as count").
+		Where("user_id = ? AND guild_id = ?", userID, guildID).
+		Group("source_type").
+		Order("points DESC, source_type ASC").
+		Scan(&entries)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch points breakdown: %w", result.Error)
+	}
+
+	return entries, nil
+}
+
+// ReconcilePoints sets every member's TotalPoints to the sum of their ledger,
+// returning how many members were corrected
+func (s *Store) ReconcilePoints() (int, error) {
+	type memberBalance struct {
+		ID          uint
+		TotalPoints int
+		Balance     int
+	}
+
+	var drifted []memberBalance
+	result := s.db.Raw(`
+		SELECT gm.id, gm.total_points, COALESCE(SUM(pt.amount), 0) as balance
+		FROM guild_members gm
+		LEFT JOIN points_transactions pt ON pt.user_id = gm.user_id AND pt.guild_id = gm.guild_id AND pt.deleted_at IS NULL
+		WHERE gm.deleted_at IS NULL
+		GROUP BY gm.id, gm.total_points
+		HAVING gm.total_points <> COALESCE(SUM(pt.amount), 0)
+	`).Scan(&drifted)
+
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to compare points with the ledger: %w", result.Error)
+	}
+
+	for _, member := range drifted {
+		if err := s.db.Model(&GuildMember{}).Where("id = ?", member.ID).Update("total_points", member.Balance).Error; err != nil {
+			return 0, fmt.Errorf("failed to reconcile points: %w", err)
+		}
+	}
+
+	return len(drifted), nil
+}
+
+// MaxPointsAdjustment bounds how many points an admin can grant or revoke at once
+const MaxPointsAdjustment = 1000
+
+// AdjustPoints grants (positive amount) or revokes (negative amount) a member's points on an admin's say-so.
+// The reason is kept in the ledger. A member can't be taken below zero.
+// It returns the member's new total.
+func (s *Store) AdjustPoints(userID uint, guildID string, amount int, adminID uint, reason string) (int, error) {
+	if amount == 0 || amount > MaxPointsAdjustment || amount < -MaxPointsAdjustment {
+		return 0, fmt.Errorf("points must be between 1 and %d", MaxPointsAdjustment)
+	}
+	if strings.TrimSpace(reason) == "" {
+		return 0, fmt.Errorf("a reason is required")
+	}
+
+	var total int
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var member GuildMember
+		result := tx.Where("user_id = ? AND guild_id = ?", userID, guildID).First(&member)
+		if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
+			return fmt.Errorf("failed to fetch guild member: %w", result.Error)
+		}
+		if member.TotalPoints+amount < 0 {
+			return fmt.Errorf("only %d points can be revoked", member.TotalPoints)
+		}
+
+		source := PointsSource{Type: PointsSourceAdjustment, ID: adminID, Note: strings.TrimSpace(reason)}
+		if err := addMemberPoints(tx, userID, guildID, amount, source); err != nil {
+			return err
+		}
+		total = member.TotalPoints + amount
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
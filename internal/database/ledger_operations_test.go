@@ -0,0 +1,163 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+)
+
+func TestPointsLedger(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	period, _ := store.CreateFocusPeriod(user.ID, guildID)
+
+	store.AddTask(period.ID, "Launch the pricing page", "", estimator.Estimate{Points: 8}, nil)
+	store.AddTask(period.ID, "Interview five customers", "", estimator.Estimate{Points: 6}, nil)
+	store.AwardTaskCompletion(period, 1)
+	store.AwardTaskCompletion(period, 2)
+	if _, _, err := store.ReopenTask(period, 2); err != nil {
+		t.Fatalf("Failed to reopen task: %v", err)
+	}
+	if err := store.AddPointsToUser(user.ID, period.ID, 3, guildID, period.StartDate, period.EndDate); err != nil {
+		t.Fatalf("Failed to add points: %v", err)
+	}
+
+	history, err := store.GetPointsHistory(user.ID, guildID, 10)
+	if err != nil {
+		t.Fatalf("Failed to get points history: %v", err)
+	}
+	if len(history) != 4 {
+		t.Fatalf("Expected 4 transactions, got %d", len(history))
+	}
+	if history[0].SourceType != PointsSourceFocusPeriod || history[0].Amount != 3 {
+		t.Errorf("Expected the newest transaction first, got %+v", history[0])
+	}
+	revoked := history[1]
+	if revoked.SourceType != PointsSourceTaskRevoked || revoked.Amount != -6 || revoked.Note != "Interview five customers" {
+		t.Errorf("Expected the reopened goal's points taken back, got %+v", revoked)
+	}
+
+	breakdown, err := store.GetPointsBreakdown(user.ID, guildID)
+	if err != nil {
+		t.Fatalf("Failed to get points breakdown: %v", err)
+	}
+	total := 0
+	for _, entry := range breakdown {
+		total += entry.Points
+		if entry.SourceType == PointsSourceTaskCompleted && (entry.Points != 14 || entry.Count != 2) {
+			t.Errorf("Expected 14 points from 2 completed goals, got %+v", entry)
+		}
+	}
+	member, _ := store.GetGuildMember(user.ID, guildID)
+	if total != member.TotalPoints || total != 11 {
+		t.Errorf("Expected the ledger to sum to the member's 11 points, got %d (total %d)", total, member.TotalPoints)
+	}
+
+	// Other members and guilds have their own ledgers
+	if history, _ := store.GetPointsHistory(user.ID, "other-guild", 10); len(history) != 0 {
+		t.Errorf("Expected no transactions in another guild, got %d", len(history))
+	}
+}
+
+func TestReconcilePoints(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	alice, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	bob, _ := store.GetOrCreateUser("user-2", guildID, "bob")
+	period, _ := store.CreateFocusPeriod(alice.ID, guildID)
+	store.AddPointsToUser(alice.ID, period.ID, 5, guildID, period.StartDate, period.EndDate)
+
+	// Totals edited outside the ledger drift from it
+	store.db.Model(&GuildMember{}).Where("user_id = ?", alice.ID).Update("total_points", 50)
+	store.db.Model(&GuildMember{}).Where("user_id = ?", bob.ID).Update("total_points", 7)
+
+	corrected, err := store.ReconcilePoints()
+	if err != nil {
+		t.Fatalf("Failed to reconcile points: %v", err)
+	}
+	if corrected != 2 {
+		t.Errorf("Expected 2 members corrected, got %d", corrected)
+	}
+	for userID, want := range map[uint]int{alice.ID: 5, bob.ID: 0} {
+		member, _ := store.GetGuildMember(userID, guildID)
+		if member.TotalPoints != want {
+			t.Errorf("Expected user %d to have %d points, got %d", userID, want, member.TotalPoints)
+		}
+	}
+
+	if corrected, _ := store.ReconcilePoints(); corrected != 0 {
+		t.Errorf("Expected nothing left to correct, got %d", corrected)
+	}
+}
+
+func TestLedgerMigrationOpensBalances(t *testing.T) {
+	db := openTestDB(t)
+	if err := db.AutoMigrate(&User{}, &GuildMember{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	store := NewStore(db)
+	user, _ := store.GetOrCreateUser("user-1", "guild-1", "alice")
+	store.GetOrCreateUser("user-2", "guild-1", "bob")
+	db.Model(&GuildMember{}).Where("user_id = ?", user.ID).Update("total_points", 42)
+
+	for _, migration := range migrations {
+		if migration.Description == "points ledger" {
+			if err := migration.Up(db); err != nil {
+				t.Fatalf("Failed to run ledger migration: %v", err)
+			}
+		}
+	}
+
+	history, err := store.GetPointsHistory(user.ID, "guild-1", 10)
+	if err != nil {
+		t.Fatalf("Failed to get points history: %v", err)
+	}
+	if len(history) != 1 || history[0].Amount != 42 || history[0].SourceType != PointsSourceOpeningBalance {
+		t.Fatalf("Expected a 42 point opening balance, got %+v", history)
+	}
+	if time.Since(history[0].CreatedAt) > time.Hour {
+		t.Errorf("Expected the opening balance to be dated now, got %v", history[0].CreatedAt)
+	}
+	if corrected, _ := store.ReconcilePoints(); corrected != 0 {
+		t.Errorf("Expected opening balances to match totals, got %d corrected", corrected)
+	}
+}
+
+func TestAdjustPoints(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	admin, _ := store.GetOrCreateUser("user-2", guildID, "admin")
+
+	total, err := store.AdjustPoints(user.ID, guildID, 10, admin.ID, "Ran the demo day")
+	if err != nil || total != 10 {
+		t.Fatalf("Expected 10 points after a grant, got %d (%v)", total, err)
+	}
+	if _, err := store.AdjustPoints(user.ID, guildID, -11, admin.ID, "Too many"); err == nil {
+		t.Error("Expected revoking below zero to be rejected")
+	}
+	if _, err := store.AdjustPoints(user.ID, guildID, 5, admin.ID, "  "); err == nil {
+		t.Error("Expected a reason to be required")
+	}
+	if _, err := store.AdjustPoints(user.ID, guildID, MaxPointsAdjustment+1, admin.ID, "Too many"); err == nil {
+		t.Error("Expected an oversized grant to be rejected")
+	}
+	total, err = store.AdjustPoints(user.ID, guildID, -4, admin.ID, "Duplicate goal")
+	if err != nil || total != 6 {
+		t.Fatalf("Expected 6 points after a revoke, got %d (%v)", total, err)
+	}
+
+	history, _ := store.GetPointsHistory(user.ID, guildID, 10)
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 adjustments in the history, got %d", len(history))
+	}
+	if history[0].SourceType != PointsSourceAdjustment || history[0].SourceID != admin.ID || history[0].Note != "Duplicate goal" {
+		t.Errorf("Expected the revoke recorded with its admin and reason, got %+v", history[0])
+	}
+	member, _ := store.GetGuildMember(user.ID, guildID)
+	if member.TotalPoints != 6 {
+		t.Errorf("Expected 6 total points, got %d", member.TotalPoints)
+	}
+}
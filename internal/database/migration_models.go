@@ -0,0 +1,625 @@
+package database
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Schema snapshots used by the migrations in migrations.go.
+// Each migration works from the models as they were when it shipped, so replaying
+// the history always builds the same schema whatever the live models look like now.
+// Never change a snapshot once its migration has shipped.
+
+// Version 1: initial schema
+
+type v1User struct {
+	gorm.Model
+	DiscordID   string `gorm:"uniqueIndex;not null"`
+	GuildID     string `gorm:"index;not null"`
+	Username    string
+	TotalPoints int `gorm:"default:0"`
+}
+
+func (v1User) TableName() string { return "users" }
+
+type v1FocusPeriod struct {
+	gorm.Model
+	UserID            uint      `gorm:"index;not null"`
+	User              v1User    `gorm:"foreignKey:UserID"`
+	GuildID           string    `gorm:"index;not null"`
+	StartDate         time.Time `gorm:"not null"`
+	EndDate           time.Time `gorm:"not null"`
+	LeaderboardPosted bool      `gorm:"default:false"`
+}
+
+func (v1FocusPeriod) TableName() string { return "focus_periods" }
+
+type v1Task struct {
+	gorm.Model
+	FocusPeriodID uint          `gorm:"index;not null"`
+	FocusPeriod   v1FocusPeriod `gorm:"foreignKey:FocusPeriodID"`
+	Title         string        `gorm:"not null"`
+	Description   string
+	Completed     bool
+	CompletedAt   *time.Time
+	Position      int
+	Points        int `gorm:"default:0"`
+}
+
+func (v1Task) TableName() string { return "tasks" }
+
+type v1PublicResource struct {
+	gorm.Model
+	GuildID           string `gorm:"index;not null"`
+	SubmitterID       string `gorm:"not null"`
+	SubmitterUsername string
+	URL               string `gorm:"not null"`
+	Title             string `gorm:"not null"`
+	Description       string `gorm:"type:text"`
+	Category          string `gorm:"index"`
+	Tags              string
+	VoteMessageID     string `gorm:"uniqueIndex"`
+	VoteChannelID     string
+	VoteExpiresAt     time.Time
+	UsefulVotes       int    `gorm:"default:0"`
+	NotUsefulVotes    int    `gorm:"default:0"`
+	Status            string `gorm:"index;default:'pending'"`
+	ProcessedAt       *time.Time
+}
+
+func (v1PublicResource) TableName() string { return "public_resources" }
+
+type v1PrivateResource struct {
+	gorm.Model
+	GuildID       string `gorm:"index;not null"`
+	OwnerID       string `gorm:"not null"`
+	OwnerUsername string
+	URL           string `gorm:"not null"`
+	Title         string `gorm:"not null"`
+	Description   string `gorm:"type:text"`
+	Category      string `gorm:"index"`
+	Tags          string
+}
+
+func (v1PrivateResource) TableName() string { return "private_resources" }
+
+type v1PrivateResourceRole struct {
+	gorm.Model
+	PrivateResourceID uint              `gorm:"index;not null"`
+	PrivateResource   v1PrivateResource `gorm:"foreignKey:PrivateResourceID"`
+	RoleID            string            `gorm:"index;not null"`
+	RoleName          string
+}
+
+func (v1PrivateResourceRole) TableName() string { return "private_resource_roles" }
+
+type v1GuildConfig struct {
+	gorm.Model
+	GuildID            string `gorm:"uniqueIndex;not null"`
+	LeaderboardChannel string
+	WinsChannel        string
+	MRRChannel         string
+}
+
+func (v1GuildConfig) TableName() string { return "guild_configs" }
+
+type v1SprintPoints struct {
+	gorm.Model
+	FocusPeriodID uint          `gorm:"index;not null"`
+	FocusPeriod   v1FocusPeriod `gorm:"foreignKey:FocusPeriodID"`
+	UserID        uint          `gorm:"index;not null"`
+	User          v1User        `gorm:"foreignKey:UserID"`
+	GuildID       string        `gorm:"index;not null"`
+	Points        int           `gorm:"default:0"`
+	StartDate     time.Time     `gorm:"not null;index"`
+	EndDate       time.Time     `gorm:"not null;index"`
+}
+
+func (v1SprintPoints) TableName() string { return "sprint_points" }
+
+type v1Standup struct {
+	gorm.Model
+	UserID       uint      `gorm:"index;not null"`
+	User         v1User    `gorm:"foreignKey:UserID"`
+	GuildID      string    `gorm:"index;not null"`
+	Date         time.Time `gorm:"index;not null"`
+	Accomplished string    `gorm:"type:text"`
+	WorkingOn    string    `gorm:"type:text;not null"`
+	Blockers     string    `gorm:"type:text"`
+}
+
+func (v1Standup) TableName() string { return "standups" }
+
+type v1UserStreak struct {
+	gorm.Model
+	UserID          uint   `gorm:"uniqueIndex:idx_user_guild_streak;not null"`
+	User            v1User `gorm:"foreignKey:UserID"`
+	GuildID         string `gorm:"uniqueIndex:idx_user_guild_streak;not null"`
+	CurrentStreak   int    `gorm:"default:0"`
+	LongestStreak   int    `gorm:"default:0"`
+	LastStandupDate *time.Time
+	TotalStandups   int `gorm:"default:0"`
+}
+
+func (v1UserStreak) TableName() string { return "user_streaks" }
+
+type v1Win struct {
+	gorm.Model
+	UserID    uint   `gorm:"index;not null"`
+	User      v1User `gorm:"foreignKey:UserID"`
+	GuildID   string `gorm:"index;not null"`
+	Message   string `gorm:"type:text;not null"`
+	MessageID string
+	Category  string
+	CreatedAt time.Time `gorm:"index"`
+}
+
+func (v1Win) TableName() string { return "wins" }
+
+type v1BuddyRequest struct {
+	gorm.Model
+	RequesterID uint   `gorm:"index;not null"`
+	Requester   v1User `gorm:"foreignKey:RequesterID"`
+	ReceiverID  uint   `gorm:"index;not null"`
+	Receiver    v1User `gorm:"foreignKey:ReceiverID"`
+	GuildID     string `gorm:"index;not null"`
+	Status      string `gorm:"default:'pending'"`
+	ExpiresAt   time.Time
+}
+
+func (v1BuddyRequest) TableName() string { return "buddy_requests" }
+
+type v1BuddyPair struct {
+	gorm.Model
+	User1ID            uint   `gorm:"index;not null"`
+	User1              v1User `gorm:"foreignKey:User1ID"`
+	User2ID            uint   `gorm:"index;not null"`
+	User2              v1User `gorm:"foreignKey:User2ID"`
+	GuildID            string `gorm:"index;not null"`
+	NotifyOnCompletion bool   `gorm:"default:true"`
+}
+
+func (v1BuddyPair) TableName() string { return "buddy_pairs" }
+
+type v1Challenge struct {
+	gorm.Model
+	CreatorID        uint   `gorm:"index;not null"`
+	Creator          v1User `gorm:"foreignKey:CreatorID"`
+	GuildID          string `gorm:"index;not null"`
+	Title            string `gorm:"not null"`
+	Description      string `gorm:"type:text"`
+	StartDate        time.Time
+	EndDate          time.Time `gorm:"index"`
+	Status           string    `gorm:"default:'active'"`
+	PointsMultiplier float64   `gorm:"default:1.5"`
+}
+
+func (v1Challenge) TableName() string { return "challenges" }
+
+type v1ChallengeParticipant struct {
+	gorm.Model
+	ChallengeID uint        `gorm:"index;not null"`
+	Challenge   v1Challenge `gorm:"foreignKey:ChallengeID"`
+	UserID      uint        `gorm:"index;not null"`
+	User        v1User      `gorm:"foreignKey:UserID"`
+	Status      string      `gorm:"default:'active'"`
+	ProofURL    string
+	CompletedAt *time.Time
+}
+
+func (v1ChallengeParticipant) TableName() string { return "challenge_participants" }
+
+type v1ChallengeProgress struct {
+	gorm.Model
+	ChallengeID uint        `gorm:"index;not null"`
+	Challenge   v1Challenge `gorm:"foreignKey:ChallengeID"`
+	UserID      uint        `gorm:"index;not null"`
+	User        v1User      `gorm:"foreignKey:UserID"`
+	Update      string      `gorm:"type:text;not null"`
+}
+
+func (v1ChallengeProgress) TableName() string { return "challenge_progresses" }
+
+type v1ChallengeValidation struct {
+	gorm.Model
+	ParticipantID uint                   `gorm:"index;not null"`
+	Participant   v1ChallengeParticipant `gorm:"foreignKey:ParticipantID"`
+	ValidatorID   uint                   `gorm:"index;not null"`
+	Validator     v1User                 `gorm:"foreignKey:ValidatorID"`
+	Approved      bool
+}
+
+func (v1ChallengeValidation) TableName() string { return "challenge_validations" }
+
+type v1MRREntry struct {
+	gorm.Model
+	UserID   uint      `gorm:"index;not null"`
+	User     v1User    `gorm:"foreignKey:UserID"`
+	GuildID  string    `gorm:"index;not null"`
+	Amount   float64   `gorm:"not null"`
+	Currency string    `gorm:"default:'USD'"`
+	Date     time.Time `gorm:"index"`
+	Note     string
+}
+
+func (v1MRREntry) TableName() string { return "mrr_entries" }
+
+type v1MRRSettings struct {
+	gorm.Model
+	UserID               uint   `gorm:"uniqueIndex:idx_user_guild_mrr;not null"`
+	User                 v1User `gorm:"foreignKey:UserID"`
+	GuildID              string `gorm:"uniqueIndex:idx_user_guild_mrr;not null"`
+	IsPublic             bool   `gorm:"default:false"`
+	LastMilestoneReached int    `gorm:"default:0"`
+	ProjectChannelID     string
+}
+
+func (v1MRRSettings) TableName() string { return "mrr_settings" }
+
+type v1ProjectMapping struct {
+	gorm.Model
+	GuildID      string `gorm:"uniqueIndex:idx_guild_role_mapping;not null"`
+	RoleID       string `gorm:"uniqueIndex:idx_guild_role_mapping;not null"`
+	RoleName     string
+	CategoryID   string `gorm:"not null"`
+	CategoryName string
+	MaxChannels  int `gorm:"default:5"`
+}
+
+func (v1ProjectMapping) TableName() string { return "project_mappings" }
+
+type v1ProjectChannel struct {
+	gorm.Model
+	GuildID    string `gorm:"index;not null"`
+	UserID     string `gorm:"index;not null"`
+	ChannelID  string `gorm:"uniqueIndex;not null"`
+	CategoryID string `gorm:"index;not null"`
+	RoleID     string `gorm:"index;not null"`
+	Name       string
+	Type       string
+}
+
+func (v1ProjectChannel) TableName() string { return "project_channels" }
+
+// Version 3: per-guild memberships
+
+type v3GuildMember struct {
+	gorm.Model
+	UserID      uint   `gorm:"uniqueIndex:idx_guild_member;not null"`
+	User        v1User `gorm:"foreignKey:UserID"`
+	GuildID     string `gorm:"uniqueIndex:idx_guild_member;not null"`
+	Username    string
+	TotalPoints int `gorm:"default:0"`
+}
+
+func (v3GuildMember) TableName() string { return "guild_members" }
+
+// Version 4: scheduled jobs
+
+type v4ScheduledJob struct {
+	Name          string `gorm:"primaryKey"`
+	Description   string
+	Schedule      string
+	LastPeriod    string `gorm:"not null;default:''"`
+	LastStartedAt *time.Time
+	LastRunAt     *time.Time
+	LastError     string
+	UpdatedAt     time.Time
+}
+
+func (v4ScheduledJob) TableName() string { return "scheduled_jobs" }
+
+// Version 5: timezones and reminder deliveries
+
+type v5User struct {
+	Timezone string
+}
+
+func (v5User) TableName() string { return "users" }
+
+type v5GuildConfig struct {
+	Timezone string
+}
+
+func (v5GuildConfig) TableName() string { return "guild_configs" }
+
+type v5ReminderDelivery struct {
+	ID        uint   `gorm:"primaryKey"`
+	Kind      string `gorm:"uniqueIndex:idx_reminder_delivery;not null"`
+	UserID    uint   `gorm:"uniqueIndex:idx_reminder_delivery;not null"`
+	GuildID   string `gorm:"uniqueIndex:idx_reminder_delivery;not null"`
+	Period    string `gorm:"uniqueIndex:idx_reminder_delivery;not null"`
+	CreatedAt time.Time
+}
+
+func (v5ReminderDelivery) TableName() string { return "reminder_deliveries" }
+
+// Version 6: guild reminder settings
+
+type v6GuildConfig struct {
+	ReminderChannel           string
+	ReminderHour              int  `gorm:"not null;default:9"`
+	FocusRemindersEnabled     bool `gorm:"not null;default:true"`
+	StandupRemindersEnabled   bool `gorm:"not null;default:true"`
+	ChallengeRemindersEnabled bool `gorm:"not null;default:true"`
+	MRRRemindersEnabled       bool `gorm:"not null;default:true"`
+}
+
+func (v6GuildConfig) TableName() string { return "guild_configs" }
+
+// Version 7: notification settings
+
+type v7NotificationSettings struct {
+	gorm.Model
+	UserID            uint   `gorm:"uniqueIndex:idx_notification_settings;not null"`
+	GuildID           string `gorm:"uniqueIndex:idx_notification_settings;not null"`
+	FocusDelivery     string
+	StandupDelivery   string
+	ChallengeDelivery string
+	MRRDelivery       string
+	QuietHoursStart   *int
+	QuietHoursEnd     *int
+}
+
+func (v7NotificationSettings) TableName() string { return "notification_settings" }
+
+// Version 8: focus period length and reminder points
+
+type v8GuildConfig struct {
+	FocusPeriodDays     int `gorm:"not null;default:14"`
+	FocusReminderPoints string
+}
+
+func (v8GuildConfig) TableName() string { return "guild_configs" }
+
+// Version 9: cohort sprints
+
+type v9CohortSprint struct {
+	gorm.Model
+	GuildID     string    `gorm:"index;not null"`
+	Name        string    `gorm:"not null"`
+	StartDate   time.Time `gorm:"not null;index"`
+	EndDate     time.Time `gorm:"not null;index"`
+	RecapPosted bool      `gorm:"not null;default:false"`
+}
+
+func (v9CohortSprint) TableName() string { return "cohort_sprints" }
+
+type v9FocusPeriod struct {
+	SprintID *uint `gorm:"index"`
+}
+
+func (v9FocusPeriod) TableName() string { return "focus_periods" }
+
+type v9GuildConfig struct {
+	CohortSprints bool `gorm:"not null;default:false"`
+}
+
+func (v9GuildConfig) TableName() string { return "guild_configs" }
+
+// Version 10: task carry-over
+
+type v10Task struct {
+	CarriedFromID *uint `gorm:"index"`
+}
+
+func (v10Task) TableName() string { return "tasks" }
+
+// Version 11: task steps
+
+type v11TaskStep struct {
+	gorm.Model
+	TaskID      uint   `gorm:"index;not null"`
+	Title       string `gorm:"not null"`
+	Position    int
+	Completed   bool
+	CompletedAt *time.Time
+}
+
+func (v11TaskStep) TableName() string { return "task_steps" }
+
+type v11Task struct {
+	StepPoints int `gorm:"not null;default:0"`
+}
+
+func (v11Task) TableName() string { return "tasks" }
+
+type v11GuildConfig struct {
+	StepPointsEnabled bool `gorm:"not null;default:false"`
+}
+
+func (v11GuildConfig) TableName() string { return "guild_configs" }
+
+// Version 12: task due dates
+
+type v12Task struct {
+	DueDate *time.Time
+}
+
+func (v12Task) TableName() string { return "tasks" }
+
+// Version 13: point estimate cache
+
+type v13PointEstimate struct {
+	gorm.Model
+	CacheKey string `gorm:"uniqueIndex;not null"`
+	Points   int    `gorm:"not null"`
+}
+
+func (v13PointEstimate) TableName() string { return "point_estimates" }
+
+// Version 14: estimate rationale and point appeals
+
+type v14Rationale struct {
+	Time       string
+	Complexity string
+	Impact     string
+}
+
+type v14Task struct {
+	Rationale v14Rationale `gorm:"embedded;embeddedPrefix:rationale_"`
+}
+
+func (v14Task) TableName() string { return "tasks" }
+
+type v14PointEstimate struct {
+	Rationale v14Rationale `gorm:"embedded;embeddedPrefix:rationale_"`
+}
+
+func (v14PointEstimate) TableName() string { return "point_estimates" }
+
+type v14PointAppeal struct {
+	gorm.Model
+	TaskID          uint   `gorm:"index;not null"`
+	Task            v1Task `gorm:"foreignKey:TaskID"`
+	UserID          uint   `gorm:"index;not null"`
+	User            v1User `gorm:"foreignKey:UserID"`
+	GuildID         string `gorm:"index;not null"`
+	Reason          string `gorm:"not null"`
+	PreviousPoints  int    `gorm:"not null"`
+	RequestedPoints int    `gorm:"not null"`
+	Status          string `gorm:"not null;default:pending"`
+	ReviewerID      *uint
+	ReviewedAt      *time.Time
+}
+
+func (v14PointAppeal) TableName() string { return "point_appeals" }
+
+// Version 15: point guardrails
+
+type v15GuildConfig struct {
+	MinCompleteMinutes int  `gorm:"not null;default:0"`
+	PeriodPointCap     int  `gorm:"not null;default:0"`
+	DuplicateGoalCheck bool `gorm:"not null;default:true"`
+}
+
+func (v15GuildConfig) TableName() string { return "guild_configs" }
+
+type v15Task struct {
+	WithheldPoints int `gorm:"not null;default:0"`
+}
+
+func (v15Task) TableName() string { return "tasks" }
+
+type v15FlaggedCompletion struct {
+	gorm.Model
+	TaskID     uint   `gorm:"index;not null"`
+	Task       v1Task `gorm:"foreignKey:TaskID"`
+	UserID     uint   `gorm:"index;not null"`
+	User       v1User `gorm:"foreignKey:UserID"`
+	GuildID    string `gorm:"index;not null"`
+	Reasons    string `gorm:"not null"`
+	HeldPoints int    `gorm:"not null"`
+	Status     string `gorm:"not null;default:pending"`
+	ReviewerID *uint
+	ReviewedAt *time.Time
+}
+
+func (v15FlaggedCompletion) TableName() string { return "flagged_completions" }
+
+// Version 16: points ledger
+
+type v16PointsTransaction struct {
+	gorm.Model
+	UserID     uint   `gorm:"index:idx_points_member;not null"`
+	User       v1User `gorm:"foreignKey:UserID"`
+	GuildID    string `gorm:"index:idx_points_member;not null"`
+	Amount     int    `gorm:"not null"`
+	SourceType string `gorm:"index:idx_points_source;not null"`
+	SourceID   uint   `gorm:"index:idx_points_source"`
+	Note       string
+}
+
+func (v16PointsTransaction) TableName() string { return "points_transactions" }
+
+// Version 17: leaderboard seasons
+
+type v17Season struct {
+	gorm.Model
+	GuildID      string `gorm:"uniqueIndex:idx_season_name;not null"`
+	Name         string `gorm:"uniqueIndex:idx_season_name;not null"`
+	StartedAt    *time.Time
+	EndedAt      time.Time `gorm:"not null"`
+	ArchivedByID uint      `gorm:"not null"`
+}
+
+func (v17Season) TableName() string { return "seasons" }
+
+type v17SeasonStanding struct {
+	ID         uint   `gorm:"primaryKey"`
+	SeasonID   uint   `gorm:"index;not null"`
+	DiscordID  string `gorm:"not null"`
+	Username   string
+	Rank       int `gorm:"not null"`
+	Points     int `gorm:"not null"`
+	TasksCount int `gorm:"not null"`
+}
+
+func (v17SeasonStanding) TableName() string { return "season_standings" }
+
+// Version 18: standup threads
+
+type v18GuildConfig struct {
+	StandupThreadChannel string
+}
+
+func (v18GuildConfig) TableName() string { return "guild_configs" }
+
+type v18StandupThread struct {
+	gorm.Model
+	GuildID   string `gorm:"index;not null"`
+	ChannelID string `gorm:"not null"`
+	ThreadID  string `gorm:"uniqueIndex;not null"`
+	Day       string `gorm:"not null"`
+}
+
+func (v18StandupThread) TableName() string { return "standup_threads" }
+
+// Version 19: streak rest days and freezes
+
+type v19GuildConfig struct {
+	StandupRestDays string
+}
+
+func (v19GuildConfig) TableName() string { return "guild_configs" }
+
+type v19UserStreak struct {
+	FreezeTokens int `gorm:"not null;default:0"`
+	FreezesUsed  int `gorm:"not null;default:0"`
+}
+
+func (v19UserStreak) TableName() string { return "user_streaks" }
+
+// Version 20: blockers
+
+type v20GuildConfig struct {
+	HelpChannel string
+}
+
+func (v20GuildConfig) TableName() string { return "guild_configs" }
+
+type v20Blocker struct {
+	gorm.Model
+	UserID      uint   `gorm:"index;not null"`
+	User        v1User `gorm:"foreignKey:UserID"`
+	GuildID     string `gorm:"index;not null"`
+	StandupID   *uint  `gorm:"index"`
+	Description string `gorm:"type:text;not null"`
+	Status      string `gorm:"not null;default:open"`
+	ChannelID   string
+	MessageID   string
+	HelperID    *uint
+	Helper      *v1User `gorm:"foreignKey:HelperID"`
+	ResolvedAt  *time.Time
+}
+
+func (v20Blocker) TableName() string { return "blockers" }
+
+// Version 21: standup digests
+
+type v21GuildConfig struct {
+	StandupDigestChannel string
+}
+
+func (v21GuildConfig) TableName() string { return "guild_configs" }
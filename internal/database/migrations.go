@@ -0,0 +1,582 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Migration is a single versioned schema change.
+// Migrations are applied in ascending Version order and each runs in its own transaction.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(tx *gorm.DB) error
+	Down        func(tx *gorm.DB) error
+}
+
+// SchemaMigration records a migration that has been applied to the database
+type SchemaMigration struct {
+	Version     int `gorm:"primaryKey;autoIncrement:false"`
+	Description string
+	AppliedAt   time.Time `gorm:"not null"`
+}
+
+// MigrationState describes whether a known migration has been applied
+type MigrationState struct {
+	Version     int
+	Description string
+	Applied     bool
+	AppliedAt   *time.Time
+}
+
+// migrations is the ordered list of all schema migrations.
+// Never edit or reorder a migration once it has shipped - add a new one instead.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "initial schema",
+		Up: func(tx *gorm.DB) error {
+			// Databases from before versioned migrations already have these tables
+			return tx.AutoMigrate(
+				&v1User{},
+				&v1FocusPeriod{},
+				&v1Task{},
+				&v1PublicResource{},
+				&v1PrivateResource{},
+				&v1PrivateResourceRole{},
+				&v1GuildConfig{},
+				&v1SprintPoints{},
+				// Phase 1: Daily Standups + Streaks
+				&v1Standup{},
+				&v1UserStreak{},
+				// Phase 2: Win Sharing
+				&v1Win{},
+				// Phase 3: Accountability Buddies
+				&v1BuddyRequest{},
+				&v1BuddyPair{},
+				// Phase 4: Challenge System
+				&v1Challenge{},
+				&v1ChallengeParticipant{},
+				&v1ChallengeProgress{},
+				&v1ChallengeValidation{},
+				// Phase 5: MRR Tracking
+				&v1MRREntry{},
+				&v1MRRSettings{},
+				// Phase 6: Project Channel Management
+				&v1ProjectMapping{},
+				&v1ProjectChannel{},
+			)
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(
+				&v1ProjectChannel{},
+				&v1ProjectMapping{},
+				&v1MRRSettings{},
+				&v1MRREntry{},
+				&v1ChallengeValidation{},
+				&v1ChallengeProgress{},
+				&v1ChallengeParticipant{},
+				&v1Challenge{},
+				&v1BuddyPair{},
+				&v1BuddyRequest{},
+				&v1Win{},
+				&v1UserStreak{},
+				&v1Standup{},
+				&v1SprintPoints{},
+				&v1GuildConfig{},
+				&v1PrivateResourceRole{},
+				&v1PrivateResource{},
+				&v1PublicResource{},
+				&v1Task{},
+				&v1FocusPeriod{},
+				&v1User{},
+			)
+		},
+	},
+	{
+		Version:     2,
+		Description: "unique private resource roles",
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_private_resource_role_unique ON private_resource_roles(private_resource_id, role_id)").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Exec("DROP INDEX IF EXISTS idx_private_resource_role_unique").Error
+		},
+	},
+	{
+		Version:     3,
+		Description: "per-guild memberships",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().CreateTable(&v3GuildMember{}); err != nil {
+				return err
+			}
+			return migrateLegacyUsers(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			// Restore the single guild/points columns from each user's oldest membership
+			statements := []string{
+				"ALTER TABLE users ADD COLUMN guild_id text NOT NULL DEFAULT ''",
+				"ALTER TABLE users ADD COLUMN total_points integer DEFAULT 0",
+				`UPDATE users SET
+					guild_id = COALESCE((SELECT gm.guild_id FROM guild_members gm WHERE gm.user_id = users.id ORDER BY gm.id LIMIT 1), ''),
+					total_points = COALESCE((SELECT gm.total_points FROM guild_members gm WHERE gm.user_id = users.id ORDER BY gm.id LIMIT 1), 0)`,
+				"CREATE INDEX IF NOT EXISTS idx_users_guild_id ON users(guild_id)",
+			}
+			for _, statement := range statements {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().DropTable(&v3GuildMember{})
+		},
+	},
+	{
+		Version:     4,
+		Description: "scheduled jobs",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&v4ScheduledJob{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&v4ScheduledJob{})
+		},
+	},
+	{
+		Version:     5,
+		Description: "timezones and reminder deliveries",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&v5User{}, &v5GuildConfig{}); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateTable(&v5ReminderDelivery{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&v5ReminderDelivery{}); err != nil {
+				return err
+			}
+			if err := dropColumns(tx, &v5GuildConfig{}, "timezone"); err != nil {
+				return err
+			}
+			return dropColumns(tx, &v5User{}, "timezone")
+		},
+	},
+	{
+		Version:     6,
+		Description: "guild reminder settings",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&v6GuildConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &v6GuildConfig{},
+				"reminder_channel",
+				"reminder_hour",
+				"focus_reminders_enabled",
+				"standup_reminders_enabled",
+				"challenge_reminders_enabled",
+				"mrr_reminders_enabled",
+			)
+		},
+	},
+	{
+		Version:     7,
+		Description: "notification settings",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&v7NotificationSettings{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&v7NotificationSettings{})
+		},
+	},
+	{
+		Version:     8,
+		Description: "focus period length and reminder points",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&v8GuildConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &v8GuildConfig{}, "focus_reminder_points", "focus_period_days")
+		},
+	},
+	{
+		Version:     9,
+		Description: "cohort sprints",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&v9FocusPeriod{}, &v9GuildConfig{}); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateTable(&v9CohortSprint{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropColumns(tx, &v9GuildConfig{}, "cohort_sprints"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropIndex(&v9FocusPeriod{}, "idx_focus_periods_sprint_id"); err != nil {
+				return err
+			}
+			if err := dropColumns(tx, &v9FocusPeriod{}, "sprint_id"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&v9CohortSprint{})
+		},
+	},
+	{
+		Version:     10,
+		Description: "task carry-over",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&v10Task{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropIndex(&v10Task{}, "idx_tasks_carried_from_id"); err != nil {
+				return err
+			}
+			return dropColumns(tx, &v10Task{}, "carried_from_id")
+		},
+	},
+	{
+		Version:     11,
+		Description: "task steps",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&v11Task{}, &v11GuildConfig{}); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateTable(&v11TaskStep{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropColumns(tx, &v11GuildConfig{}, "step_points_enabled"); err != nil {
+				return err
+			}
+			if err := dropColumns(tx, &v11Task{}, "step_points"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&v11TaskStep{})
+		},
+	},
+	{
+		Version:     12,
+		Description: "task due dates",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&v12Task{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &v12Task{}, "due_date")
+		},
+	},
+	{
+		Version:     13,
+		Description: "point estimate cache",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&v13PointEstimate{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&v13PointEstimate{})
+		},
+	},
+	{
+		Version:     14,
+		Description: "estimate rationale and point appeals",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&v14Task{}, &v14PointEstimate{}); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateTable(&v14PointAppeal{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&v14PointAppeal{}); err != nil {
+				return err
+			}
+			columns := []string{"rationale_time", "rationale_complexity", "rationale_impact"}
+			if err := dropColumns(tx, &v14Task{}, columns...); err != nil {
+				return err
+			}
+			return dropColumns(tx, &v14PointEstimate{}, columns...)
+		},
+	},
+	{
+		Version:     15,
+		Description: "point guardrails",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&v15GuildConfig{}, &v15Task{}); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateTable(&v15FlaggedCompletion{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&v15FlaggedCompletion{}); err != nil {
+				return err
+			}
+			if err := dropColumns(tx, &v15Task{}, "withheld_points"); err != nil {
+				return err
+			}
+			return dropColumns(tx, &v15GuildConfig{}, "min_complete_minutes", "period_point_cap", "duplicate_goal_check")
+		},
+	},
+	{
+		Version:     16,
+		Description: "points ledger",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().CreateTable(&v16PointsTransaction{}); err != nil {
+				return err
+			}
+			// Open each member's ledger with the points they already have, so the ledger sums to their total
+			return tx.Exec(`INSERT INTO points_transactions (created_at, updated_at, user_id, guild_id, amount, source_type, source_id, note)
+				SELECT CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, user_id, guild_id, total_points, 'opening_balance', 0, 'Points earned before the ledger'
+				FROM guild_members
+				WHERE total_points <> 0 AND deleted_at IS NULL`).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&v16PointsTransaction{})
+		},
+	},
+	{
+		Version:     17,
+		Description: "leaderboard seasons",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&v17Season{}, &v17SeasonStanding{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&v17SeasonStanding{}, &v17Season{})
+		},
+	},
+	{
+		Version:     18,
+		Description: "standup threads",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&v18GuildConfig{}); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateTable(&v18StandupThread{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&v18StandupThread{}); err != nil {
+				return err
+			}
+			return dropColumns(tx, &v18GuildConfig{}, "standup_thread_channel")
+		},
+	},
+	{
+		Version:     19,
+		Description: "streak rest days and freezes",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&v19GuildConfig{}, &v19UserStreak{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropColumns(tx, &v19UserStreak{}, "freeze_tokens", "freezes_used"); err != nil {
+				return err
+			}
+			return dropColumns(tx, &v19GuildConfig{}, "standup_rest_days")
+		},
+	},
+	{
+		Version:     20,
+		Description: "blockers",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&v20GuildConfig{}); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateTable(&v20Blocker{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&v20Blocker{}); err != nil {
+				return err
+			}
+			return dropColumns(tx, &v20GuildConfig{}, "help_channel")
+		},
+	},
+	{
+		Version:     21,
+		Description: "standup digests",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&v21GuildConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &v21GuildConfig{}, "standup_digest_channel")
+		},
+	},
+}
+
+// Migrate applies all pending migrations in version order
+func Migrate(db *gorm.DB) error {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range sortedMigrations() {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		log.Printf("Applying migration %d: %s", migration.Version, migration.Description)
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{
+				Version:     migration.Version,
+				Description: migration.Description,
+				AppliedAt:   time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+	}
+
+	return nil
+}
+
+// Rollback reverts the most recently applied migrations, newest first
+func Rollback(db *gorm.DB, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("rollback steps must be positive")
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[int]Migration)
+	for _, migration := range migrations {
+		known[migration.Version] = migration
+	}
+
+	versions := make([]int, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	for idx, version := range versions {
+		if idx >= steps {
+			break
+		}
+
+		migration, ok := known[version]
+		if !ok {
+			return fmt.Errorf("migration %d is applied but unknown to this build", version)
+		}
+		if migration.Down == nil {
+			return fmt.Errorf("migration %d (%s) cannot be rolled back", migration.Version, migration.Description)
+		}
+
+		log.Printf("Rolling back migration %d: %s", migration.Version, migration.Description)
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to roll back migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+	}
+
+	return nil
+}
+
+// GetMigrationStatus returns every known migration along with whether it has been applied
+func GetMigrationStatus(db *gorm.DB) ([]MigrationState, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var states []MigrationState
+	for _, migration := range sortedMigrations() {
+		state := MigrationState{
+			Version:     migration.Version,
+			Description: migration.Description,
+		}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			state.Applied = true
+			state.AppliedAt = &appliedAt
+		}
+		states = append(states, state)
+	}
+
+	return states, nil
+}
+
+// appliedMigrations loads the applied migration records keyed by version
+func appliedMigrations(db *gorm.DB) (map[int]SchemaMigration, error) {
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	var records []SchemaMigration
+	if err := db.Order("version ASC").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch applied migrations: %w", err)
+	}
+
+	applied := make(map[int]SchemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	return applied, nil
+}
+
+// sortedMigrations returns the migrations ordered by version
+func sortedMigrations() []Migration {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a].Version < sorted[b].Version
+	})
+	return sorted
+}
+
+// dropColumns drops the named columns from the snapshot's table.
+// SQLite drops a column by rebuilding the table, which loses its indexes, so every index
+// that didn't cover a dropped column is recreated afterwards.
+func dropColumns(tx *gorm.DB, snapshot interface{}, columns ...string) error {
+	migrator := tx.Migrator()
+	indexes, err := migrator.GetIndexes(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to read indexes: %w", err)
+	}
+
+	dropped := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		if err := migrator.DropColumn(snapshot, column); err != nil {
+			return fmt.Errorf("failed to drop column %s: %w", column, err)
+		}
+		dropped[column] = true
+	}
+
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(snapshot); err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if primary, _ := index.PrimaryKey(); primary || migrator.HasIndex(snapshot, index.Name()) {
+			continue
+		}
+
+		var indexColumns []interface{}
+		covered := false
+		for _, column := range index.Columns() {
+			covered = covered || dropped[column]
+			indexColumns = append(indexColumns, clause.Column{Name: column})
+		}
+		if covered {
+			continue
+		}
+
+		sql := "CREATE INDEX ? ON ??"
+		if unique, _ := index.Unique(); unique {
+			sql = "CREATE UNIQUE INDEX ? ON ??"
+		}
+		if err := tx.Exec(sql, clause.Column{Name: index.Name()}, clause.Table{Name: stmt.Table}, indexColumns).Error; err != nil {
+			return fmt.Errorf("failed to restore index %s: %w", index.Name(), err)
+		}
+	}
+
+	return nil
+}
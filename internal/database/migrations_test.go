@@ -0,0 +1,214 @@
+package database
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// liveModels lists every model the application reads and writes
+var liveModels = []interface{}{
+	&User{}, &GuildMember{}, &FocusPeriod{}, &Task{}, &TaskStep{},
+	&PublicResource{}, &PrivateResource{}, &PrivateResourceRole{},
+	&GuildConfig{}, &SprintPoints{}, &Standup{}, &Blocker{}, &StandupThread{}, &UserStreak{},
+	&Win{}, &BuddyRequest{}, &BuddyPair{},
+	&Challenge{}, &ChallengeParticipant{}, &ChallengeProgress{}, &ChallengeValidation{},
+	&MRREntry{}, &MRRSettings{}, &ProjectMapping{}, &ProjectChannel{},
+	&ScheduledJob{}, &NotificationSettings{}, &CohortSprint{}, &PointEstimate{},
+	&FlaggedCompletion{}, &PointAppeal{}, &PointsTransaction{}, &Season{}, &SeasonStanding{},
+	&ReminderDelivery{},
+}
+
+// describeSchema summarises a table's columns and indexes so two databases can be compared
+func describeSchema(t *testing.T, db *gorm.DB, model interface{}) []string {
+	t.Helper()
+
+	db = db.Session(&gorm.Session{Logger: logger.Discard})
+	columns, err := db.Migrator().ColumnTypes(model)
+	if err != nil {
+		t.Fatalf("Failed to read columns for %T: %v", model, err)
+	}
+	var described []string
+	for _, column := range columns {
+		nullable, _ := column.Nullable()
+		value, _ := column.DefaultValue()
+		described = append(described, "column "+column.Name()+" "+strings.ToLower(column.DatabaseTypeName())+
+			" null="+map[bool]string{true: "yes", false: "no"}[nullable]+" default="+value)
+	}
+
+	indexes, err := db.Migrator().GetIndexes(model)
+	if err != nil {
+		t.Fatalf("Failed to read indexes for %T: %v", model, err)
+	}
+	for _, index := range indexes {
+		unique, _ := index.Unique()
+		described = append(described, "index "+index.Name()+" ("+strings.Join(index.Columns(), ",")+")"+
+			map[bool]string{true: " unique", false: ""}[unique])
+	}
+
+	sort.Strings(described)
+	return described
+}
+
+func TestMigrateAppliesAllMigrations(t *testing.T) {
+	db := openTestDB(t)
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	// Running again must be a no-op
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Failed to re-run migrations: %v", err)
+	}
+
+	states, err := GetMigrationStatus(db)
+	if err != nil {
+		t.Fatalf("Failed to get migration status: %v", err)
+	}
+
+	if len(states) != len(migrations) {
+		t.Fatalf("Expected %d migrations, got %d", len(migrations), len(states))
+	}
+
+	for _, state := range states {
+		if !state.Applied {
+			t.Errorf("Expected migration %d to be applied", state.Version)
+		}
+	}
+
+	if !db.Migrator().HasTable(&GuildMember{}) {
+		t.Error("Expected guild_members table to exist")
+	}
+}
+
+func TestRollbackRevertsLatestMigration(t *testing.T) {
+	db := openTestDB(t)
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	latest := sortedMigrations()[len(migrations)-1]
+	if err := Rollback(db, 1); err != nil {
+		t.Fatalf("Failed to roll back: %v", err)
+	}
+
+	states, err := GetMigrationStatus(db)
+	if err != nil {
+		t.Fatalf("Failed to get migration status: %v", err)
+	}
+
+	for _, state := range states {
+		if state.Version == latest.Version && state.Applied {
+			t.Errorf("Expected migration %d to be rolled back", state.Version)
+		}
+		if state.Version != latest.Version && !state.Applied {
+			t.Errorf("Expected migration %d to remain applied", state.Version)
+		}
+	}
+
+	// Re-applying after a rollback brings the schema back
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Failed to re-apply migrations: %v", err)
+	}
+}
+
+func TestRollbackRejectsInvalidSteps(t *testing.T) {
+	db := openTestDB(t)
+
+	if err := Rollback(db, 0); err == nil {
+		t.Error("Expected error for zero rollback steps")
+	}
+}
+
+func TestMigrationsMatchModels(t *testing.T) {
+	migrated := openTestDB(t)
+	if err := Migrate(migrated); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	expected := openTestDB(t)
+	if err := expected.AutoMigrate(liveModels...); err != nil {
+		t.Fatalf("Failed to auto-migrate models: %v", err)
+	}
+	// The unique role index is only declared by its migration
+	if err := sortedMigrations()[1].Up(expected); err != nil {
+		t.Fatalf("Failed to add the unique role index: %v", err)
+	}
+
+	for _, model := range liveModels {
+		want := describeSchema(t, expected, model)
+		got := describeSchema(t, migrated, model)
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("Migrated schema for %T differs from the model\ngot:\n%s\nwant:\n%s",
+				model, strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+	}
+}
+
+func TestRollbackEverythingAndReapply(t *testing.T) {
+	db := openTestDB(t)
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	// Back to the per-guild user columns that predate memberships
+	if err := Rollback(db, len(migrations)-2); err != nil {
+		t.Fatalf("Failed to roll back to version 2: %v", err)
+	}
+	if !db.Migrator().HasColumn("users", "guild_id") || db.Migrator().HasTable("guild_members") {
+		t.Error("Expected users.guild_id back and guild_members gone at version 2")
+	}
+	// Dropping columns rebuilds SQLite tables, which must keep their other indexes
+	if !db.Migrator().HasIndex("users", "idx_users_discord_id") || !db.Migrator().HasIndex("guild_configs", "idx_guild_configs_guild_id") {
+		t.Error("Expected indexes to survive the dropped columns")
+	}
+
+	if err := Rollback(db, 2); err != nil {
+		t.Fatalf("Failed to roll back everything: %v", err)
+	}
+	for _, model := range liveModels {
+		if db.Migrator().HasTable(model) {
+			t.Errorf("Expected %T's table to be dropped", model)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Failed to re-apply migrations: %v", err)
+	}
+	if db.Migrator().HasColumn("users", "guild_id") {
+		t.Error("Expected users.guild_id to be moved into memberships again")
+	}
+}
+
+func TestMigrateUpgradesBaselineDatabase(t *testing.T) {
+	db := openTestDB(t)
+
+	// A database created before versioned migrations, with points kept on users
+	if err := sortedMigrations()[0].Up(db); err != nil {
+		t.Fatalf("Failed to create baseline schema: %v", err)
+	}
+	legacy := v1User{DiscordID: "user-1", GuildID: "guild-1", Username: "alice", TotalPoints: 42}
+	if err := db.Create(&legacy).Error; err != nil {
+		t.Fatalf("Failed to create legacy user: %v", err)
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Failed to migrate baseline database: %v", err)
+	}
+
+	store := NewStore(db)
+	member, err := store.GetGuildMember(legacy.ID, "guild-1")
+	if err != nil || member == nil || member.TotalPoints != 42 || member.Username != "alice" {
+		t.Fatalf("Expected the legacy points moved into a membership, got %+v (%v)", member, err)
+	}
+	history, err := store.GetPointsHistory(legacy.ID, "guild-1", 10)
+	if err != nil || len(history) != 1 || history[0].Amount != 42 {
+		t.Errorf("Expected the ledger opened with the legacy points, got %+v (%v)", history, err)
+	}
+}
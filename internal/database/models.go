@@ -1,21 +1,34 @@
 package database
 
 import (
+	"math"
 	"time"
 
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
 	"gorm.io/gorm"
 )
 
-// User represents a Discord user in the system
+// User represents a Discord user in the system (global across guilds)
 type User struct {
 	gorm.Model
-	DiscordID   string `gorm:"uniqueIndex;not null"` // Discord user ID
-	GuildID     string `gorm:"index;not null"`       // Discord guild/server ID
-	Username    string // Cached username for display
-	TotalPoints int    `gorm:"default:0"` // Lifetime points earned
+	DiscordID string `gorm:"uniqueIndex;not null"` // Discord user ID
+	Username  string // Cached username for display
+	Timezone  string // IANA timezone name, empty to use the guild default
 }
 
-// FocusPeriod represents a 2-week goal period (like a sprint)
+// GuildMember represents a user's membership in a specific guild.
+// Points and display names are tracked per guild so communities don't bleed into each other.
+type GuildMember struct {
+	gorm.Model
+	UserID      uint   `gorm:"uniqueIndex:idx_guild_member;not null"`
+	User        User   `gorm:"foreignKey:UserID"`
+	GuildID     string `gorm:"uniqueIndex:idx_guild_member;not null"` // Discord guild/server ID
+	Username    string // Cached username for display in this guild
+	TotalPoints int    `gorm:"default:0"` // Lifetime points earned in this guild
+}
+
+// FocusPeriod represents a goal period (like a sprint). Its length comes from
+// the guild's settings when it starts and is fixed by StartDate and EndDate.
 type FocusPeriod struct {
 	gorm.Model
 	UserID            uint      `gorm:"index;not null"`
@@ -25,6 +38,7 @@ type FocusPeriod struct {
 	EndDate           time.Time `gorm:"not null"`
 	Tasks             []Task    `gorm:"foreignKey:FocusPeriodID"`
 	LeaderboardPosted bool      `gorm:"default:false"` // Tracks if leaderboard was posted for this period
+	SprintID          *uint     `gorm:"index"`         // Cohort sprint this period belongs to, nil for solo periods
 }
 
 // Task represents a goal/task within a focus period
@@ -36,8 +50,26 @@ type Task struct {
 	Description   string
 	Completed     bool
 	CompletedAt   *time.Time
-	Position      int // Order within the focus period (1, 2, 3, etc.)
-	Points        int `gorm:"default:0"` // Points earned when task is completed
+	Position      int        // Order within the focus period (1, 2, 3, etc.)
+	Points        int        `gorm:"default:0"`          // Points earned when task is completed
+	CarriedFromID *uint      `gorm:"index"`              // Unfinished task from an earlier period this one continues
+	StepPoints    int        `gorm:"not null;default:0"` // Share of Points already credited for checked steps
+	DueDate       *time.Time // Start of the day the task is due in the owner's timezone
+	Steps         []TaskStep `gorm:"foreignKey:TaskID"`
+	// Rationale explains the estimated points
+	Rationale estimator.Rationale `gorm:"embedded;embeddedPrefix:rationale_"`
+	// WithheldPoints is the part of Points not credited on completion, held for review or over the period cap
+	WithheldPoints int `gorm:"not null;default:0"`
+}
+
+// TaskStep is one checklist item breaking a task into smaller steps
+type TaskStep struct {
+	gorm.Model
+	TaskID      uint   `gorm:"index;not null"`
+	Title       string `gorm:"not null"`
+	Position    int    // Order within the task (1, 2, 3, etc.)
+	Completed   bool
+	CompletedAt *time.Time
 }
 
 // TaskStatus represents the status of a task
@@ -119,6 +151,32 @@ type GuildConfig struct {
 	LeaderboardChannel string // Channel ID for automated leaderboard posts
 	WinsChannel        string // Channel ID for win celebrations
 	MRRChannel         string // Channel ID for MRR milestone announcements
+	Timezone           string // Default IANA timezone for members, empty for server local time
+
+	// Reminder settings
+	ReminderChannel           string // Channel ID for reminders, empty to use DISCORD_REMINDER_CHANNEL_ID
+	ReminderHour              int    `gorm:"not null;default:9"` // Local hour reminders are delivered from
+	FocusRemindersEnabled     bool   `gorm:"not null;default:true"`
+	StandupRemindersEnabled   bool   `gorm:"not null;default:true"`
+	ChallengeRemindersEnabled bool   `gorm:"not null;default:true"`
+	MRRRemindersEnabled       bool   `gorm:"not null;default:true"`
+
+	// Standup settings
+	StandupThreadChannel string // Channel ID a standup thread is opened in each day, empty for none
+	StandupRestDays      string // Comma-separated weekdays that don't count against streaks, e.g. "sat,sun"
+	HelpChannel          string // Channel ID new blockers are posted to so others can help, empty for none
+	StandupDigestChannel string // Channel ID the weekly standup digest is posted to, empty for the reminder channel
+
+	// Focus Period settings
+	FocusPeriodDays     int    `gorm:"not null;default:14"` // Length of new Focus Periods in days
+	FocusReminderPoints string // Comma-separated percentages through a period to remind at, empty for the defaults
+	CohortSprints       bool   `gorm:"not null;default:false"` // New Focus Periods join the guild's current cohort sprint
+	StepPointsEnabled   bool   `gorm:"not null;default:false"` // Checking off steps earns a share of the goal's points
+
+	// Guardrails against farming points
+	MinCompleteMinutes int  `gorm:"not null;default:0"`    // Completions sooner than this after adding a goal are held for review, 0 for no minimum
+	PeriodPointCap     int  `gorm:"not null;default:0"`    // Most completion points a member can earn in one Focus Period, 0 for no cap
+	DuplicateGoalCheck bool `gorm:"not null;default:true"` // Completions of goals matching one already completed are held for review
 }
 
 // SprintPoints tracks points earned in a specific focus period
@@ -144,6 +202,38 @@ type Standup struct {
 	Accomplished string    `gorm:"type:text"`
 	WorkingOn    string    `gorm:"type:text;not null"`
 	Blockers     string    `gorm:"type:text"`
+	Blocker      *Blocker  `gorm:"foreignKey:StandupID"` // Set when the blockers were tracked as a Blocker
+}
+
+// Blocker statuses
+const (
+	BlockerOpen     = "open"
+	BlockerResolved = "resolved"
+)
+
+// Blocker is something a member reported in a standup as blocking them, tracked until it's resolved
+type Blocker struct {
+	gorm.Model
+	UserID      uint   `gorm:"index;not null"`
+	User        User   `gorm:"foreignKey:UserID"`
+	GuildID     string `gorm:"index;not null"`
+	StandupID   *uint  `gorm:"index"`
+	Description string `gorm:"type:text;not null"`
+	Status      string `gorm:"not null;default:open"`
+	ChannelID   string // Help channel the blocker was posted to, empty if it wasn't
+	MessageID   string // Discord message ID of the help channel post
+	HelperID    *uint  // Member credited with helping resolve it
+	Helper      *User  `gorm:"foreignKey:HelperID"`
+	ResolvedAt  *time.Time
+}
+
+// StandupThread is a guild's daily standup thread; replies in it are posted as standups
+type StandupThread struct {
+	gorm.Model
+	GuildID   string `gorm:"index;not null"`
+	ChannelID string `gorm:"not null"`             // Channel the thread was opened in
+	ThreadID  string `gorm:"uniqueIndex;not null"` // Discord thread (channel) ID
+	Day       string `gorm:"not null"`             // Guild-local date the thread is for, e.g. "2026-03-15"
 }
 
 // UserStreak tracks daily standup streaks for a user
@@ -156,6 +246,12 @@ type UserStreak struct {
 	LongestStreak   int        `gorm:"default:0"`
 	LastStandupDate *time.Time
 	TotalStandups   int `gorm:"default:0"`
+	FreezeTokens    int `gorm:"not null;default:0"` // Freezes held; each covers one missed day
+	FreezesUsed     int `gorm:"not null;default:0"`
+
+	// Set by CreateStandup, not stored
+	FrozenDays   int  `gorm:"-"` // Missed days the standup covered with freezes
+	EarnedFreeze bool `gorm:"-"` // Whether the standup earned a freeze
 }
 
 // Win represents a user-shared win/celebration
@@ -334,15 +430,165 @@ type ProjectChannel struct {
 	Type       string // "text", "voice", or "thread"
 }
 
-// FocusPeriodDuration is the length of a focus period
-const FocusPeriodDuration = 14 * 24 * time.Hour // 2 weeks
+// ScheduledJob records the state of a recurring scheduler job.
+// LastPeriod is claimed before a run so each period is delivered at most once.
+type ScheduledJob struct {
+	Name          string `gorm:"primaryKey"`
+	Description   string
+	Schedule      string // Human readable, e.g. "daily at 09:00"
+	LastPeriod    string `gorm:"not null;default:''"`
+	LastStartedAt *time.Time
+	LastRunAt     *time.Time // Last successful completion
+	LastError     string
+	UpdatedAt     time.Time
+}
+
+// NotificationSettings holds how a user wants reminders delivered in a guild.
+// An empty delivery falls back to that reminder type's default.
+type NotificationSettings struct {
+	gorm.Model
+	UserID            uint   `gorm:"uniqueIndex:idx_notification_settings;not null"`
+	GuildID           string `gorm:"uniqueIndex:idx_notification_settings;not null"`
+	FocusDelivery     string // "channel", "dm" or "off"
+	StandupDelivery   string
+	ChallengeDelivery string
+	MRRDelivery       string
+	QuietHoursStart   *int // Local hour quiet hours begin, nil for none
+	QuietHoursEnd     *int // Local hour quiet hours end (exclusive)
+}
+
+// CohortSprint is a guild-wide sprint that members' Focus Periods join in cohort mode
+type CohortSprint struct {
+	gorm.Model
+	GuildID     string    `gorm:"index;not null"`
+	Name        string    `gorm:"not null"`
+	StartDate   time.Time `gorm:"not null;index"`
+	EndDate     time.Time `gorm:"not null;index"`
+	RecapPosted bool      `gorm:"not null;default:false"` // Tracks if the end-of-sprint leaderboard and recap were posted
+}
+
+// PointEstimate caches the points estimated for a goal, keyed by its normalized text
+type PointEstimate struct {
+	gorm.Model
+	CacheKey  string              `gorm:"uniqueIndex;not null"`
+	Points    int                 `gorm:"not null"`
+	Rationale estimator.Rationale `gorm:"embedded;embeddedPrefix:rationale_"`
+}
+
+// Flagged completion review statuses
+const (
+	FlagPending  = "pending"
+	FlagApproved = "approved"
+	FlagRejected = "rejected"
+)
+
+// FlaggedCompletion is a goal completion whose points a guardrail held for an admin to review
+type FlaggedCompletion struct {
+	gorm.Model
+	TaskID     uint   `gorm:"index;not null"`
+	Task       Task   `gorm:"foreignKey:TaskID"`
+	UserID     uint   `gorm:"index;not null"`
+	User       User   `gorm:"foreignKey:UserID"`
+	GuildID    string `gorm:"index;not null"`
+	Reasons    string `gorm:"not null"` // Why the completion looks suspicious, one per line
+	HeldPoints int    `gorm:"not null"`
+	Status     string `gorm:"not null;default:pending"`
+	ReviewerID *uint
+	ReviewedAt *time.Time
+}
+
+// Point appeal statuses
+const (
+	AppealPending  = "pending"
+	AppealApproved = "approved"
+	AppealRejected = "rejected"
+)
+
+// PointAppeal is a member's request to re-score one of their goals, decided by a buddy or an admin
+type PointAppeal struct {
+	gorm.Model
+	TaskID          uint   `gorm:"index;not null"`
+	Task            Task   `gorm:"foreignKey:TaskID"`
+	UserID          uint   `gorm:"index;not null"` // Member who appealed
+	User            User   `gorm:"foreignKey:UserID"`
+	GuildID         string `gorm:"index;not null"`
+	Reason          string `gorm:"not null"`
+	PreviousPoints  int    `gorm:"not null"`
+	RequestedPoints int    `gorm:"not null"`
+	Status          string `gorm:"not null;default:pending"`
+	ReviewerID      *uint
+	ReviewedAt      *time.Time
+}
+
+// Points transaction sources
+const (
+	PointsSourceOpeningBalance = "opening_balance" // Points held before the ledger was introduced
+	PointsSourceFocusPeriod    = "focus_period"
+	PointsSourceTaskCompleted  = "task_completed"
+	PointsSourceTaskSteps      = "task_steps"
+	PointsSourceTaskRevoked    = "task_revoked"
+	PointsSourceAppeal         = "appeal"
+	PointsSourceReview         = "completion_review"
+	PointsSourceStandup        = "standup"
+	PointsSourceWin            = "win"
+	PointsSourceChallenge      = "challenge"
+	PointsSourceAdjustment     = "adjustment" // Granted or revoked by an admin; SourceID is the admin's user ID
+	PointsSourceSeasonReset    = "season_reset"
+	PointsSourceStreakFreeze   = "streak_freeze"
+	PointsSourceBlockerHelp    = "blocker_help" // Helped resolve another member's blocker
+)
+
+// PointsTransaction is one change to a member's points in a guild.
+// A member's TotalPoints is the sum of their transactions.
+type PointsTransaction struct {
+	gorm.Model
+	UserID     uint   `gorm:"index:idx_points_member;not null"`
+	User       User   `gorm:"foreignKey:UserID"`
+	GuildID    string `gorm:"index:idx_points_member;not null"`
+	Amount     int    `gorm:"not null"` // Negative when points are taken back
+	SourceType string `gorm:"index:idx_points_source;not null"`
+	SourceID   uint   `gorm:"index:idx_points_source"` // Record named by SourceType, e.g. the task or standup
+	Note       string // Short description shown in the history, e.g. the goal's title
+}
+
+// Season is an archived run of a guild's leaderboard. Archiving a season
+// records the standings and resets everyone's points for the next one.
+type Season struct {
+	gorm.Model
+	GuildID      string           `gorm:"uniqueIndex:idx_season_name;not null"`
+	Name         string           `gorm:"uniqueIndex:idx_season_name;not null"`
+	StartedAt    *time.Time       // End of the previous season, nil for the guild's first
+	EndedAt      time.Time        `gorm:"not null"`
+	ArchivedByID uint             `gorm:"not null"`
+	Standings    []SeasonStanding `gorm:"foreignKey:SeasonID"`
+}
+
+// SeasonStanding is one member's final place in a season
+type SeasonStanding struct {
+	ID         uint   `gorm:"primaryKey"`
+	SeasonID   uint   `gorm:"index;not null"`
+	DiscordID  string `gorm:"not null"`
+	Username   string
+	Rank       int `gorm:"not null"`
+	Points     int `gorm:"not null"`
+	TasksCount int `gorm:"not null"`
+}
+
+// ReminderDelivery records a reminder delivered to one recipient for one local period.
+// Reminders follow each recipient's timezone, so they are claimed per recipient
+// rather than per job. UserID is 0 for guild-wide posts.
+type ReminderDelivery struct {
+	ID        uint   `gorm:"primaryKey"`
+	Kind      string `gorm:"uniqueIndex:idx_reminder_delivery;not null"`
+	UserID    uint   `gorm:"uniqueIndex:idx_reminder_delivery;not null"`
+	GuildID   string `gorm:"uniqueIndex:idx_reminder_delivery;not null"`
+	Period    string `gorm:"uniqueIndex:idx_reminder_delivery;not null"` // Local date or month, e.g. "2026-03-15"
+	CreatedAt time.Time
+}
 
 // MinimumTasksRequired is the minimum number of tasks a user should set
 const MinimumTasksRequired = 3
 
-// ReminderDays are the days on which to send reminders (1-indexed from period start)
-var ReminderDays = []int{3, 7, 10, 12, 13}
-
 // IsActive returns true if the focus period is currently active
 func (fp *FocusPeriod) IsActive() bool {
 	now := time.Now()
@@ -358,15 +604,32 @@ func (fp *FocusPeriod) DaysRemaining() int {
 	return int(remaining.Hours() / 24)
 }
 
-// DayNumber returns the current day number within the focus period (1-14)
+// LengthDays returns how many days the focus period runs.
+// Rounding absorbs the hour gained or lost when the period spans a DST change.
+func (fp *FocusPeriod) LengthDays() int {
+	return int(math.Round(fp.EndDate.Sub(fp.StartDate).Hours() / 24))
+}
+
+// DayNumber returns the current day number within the focus period in server local time
 func (fp *FocusPeriod) DayNumber() int {
-	elapsed := time.Since(fp.StartDate)
-	if elapsed < 0 {
+	return fp.DayNumberAt(time.Now())
+}
+
+// DayNumberIn returns the current day number within the focus period in the given timezone
+func (fp *FocusPeriod) DayNumberIn(loc *time.Location) int {
+	return fp.DayNumberAt(time.Now().In(loc))
+}
+
+// DayNumberAt returns the day number within the focus period, from 1 to its length, at the given time.
+// Days are counted as calendar days in now's location, so day 2 starts at the
+// member's midnight rather than 24 hours after they ran /focus start.
+func (fp *FocusPeriod) DayNumberAt(now time.Time) int {
+	if now.Before(fp.StartDate) {
 		return 0
 	}
-	day := int(elapsed.Hours()/24) + 1
-	if day > 14 {
-		return 14
+	day := calendarDaysBetween(fp.StartDate.In(now.Location()), now) + 1
+	if length := fp.LengthDays(); day > length {
+		return length
 	}
 	return day
 }
@@ -382,6 +645,51 @@ func (fp *FocusPeriod) CompletedTaskCount() int {
 	return count
 }
 
+// CompletedStepCount returns the number of checked steps
+func (t *Task) CompletedStepCount() int {
+	count := 0
+	for _, step := range t.Steps {
+		if step.Completed {
+			count++
+		}
+	}
+	return count
+}
+
+// IsOverdueAt reports whether a pending task's due day has passed at the given time.
+// Days are calendar days in now's location.
+func (t *Task) IsOverdueAt(now time.Time) bool {
+	if t.Completed || t.DueDate == nil {
+		return false
+	}
+	return StartOfDay(now).After(t.DueDate.In(now.Location()))
+}
+
+// Progress returns how much of a task is done, from 0 to 1.
+// Completed tasks count fully; pending tasks with steps count the share of steps checked.
+func (t *Task) Progress() float64 {
+	if t.Completed {
+		return 1
+	}
+	if len(t.Steps) == 0 {
+		return 0
+	}
+	return float64(t.CompletedStepCount()) / float64(len(t.Steps))
+}
+
+// ProgressPercent returns the focus period's progress as a whole percentage,
+// counting partly finished tasks by their checked steps
+func (fp *FocusPeriod) ProgressPercent() int {
+	if len(fp.Tasks) == 0 {
+		return 0
+	}
+	var total float64
+	for idx := range fp.Tasks {
+		total += fp.Tasks[idx].Progress()
+	}
+	return int(total * 100 / float64(len(fp.Tasks)))
+}
+
 // PendingTaskCount returns the number of pending tasks
 func (fp *FocusPeriod) PendingTaskCount() int {
 	count := 0
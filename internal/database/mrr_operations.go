@@ -8,7 +8,7 @@ import (
 )
 
 // CreateMRREntry creates a new MRR entry
-func CreateMRREntry(userID uint, guildID string, amount float64, currency, note string) (*MRREntry, int, error) {
+func (s *Store) CreateMRREntry(userID uint, guildID string, amount float64, currency, note string) (*MRREntry, int, error) {
 	if currency == "" {
 		currency = "USD"
 	}
@@ -22,19 +22,19 @@ func CreateMRREntry(userID uint, guildID string, amount float64, currency, note
 		Note:     note,
 	}
 
-	if err := DB.Create(entry).Error; err != nil {
+	if err := s.db.Create(entry).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to create MRR entry: %w", err)
 	}
 
 	// Check for new milestone
-	milestone := checkMRRMilestone(userID, guildID, amount)
+	milestone := s.checkMRRMilestone(userID, guildID, amount)
 
 	return entry, milestone, nil
 }
 
 // checkMRRMilestone checks if user has reached a new milestone
-func checkMRRMilestone(userID uint, guildID string, amount float64) int {
-	settings, err := GetMRRSettings(userID, guildID)
+func (s *Store) checkMRRMilestone(userID uint, guildID string, amount float64) int {
+	settings, err := s.GetMRRSettings(userID, guildID)
 	if err != nil {
 		return 0
 	}
@@ -53,7 +53,7 @@ func checkMRRMilestone(userID uint, guildID string, amount float64) int {
 	if highestReached > 0 {
 		// Update last milestone reached
 		settings.LastMilestoneReached = highestReached
-		DB.Save(settings)
+		s.db.Save(settings)
 		return highestReached
 	}
 
@@ -61,9 +61,9 @@ func checkMRRMilestone(userID uint, guildID string, amount float64) int {
 }
 
 // GetMRRSettings gets or creates MRR settings for a user
-func GetMRRSettings(userID uint, guildID string) (*MRRSettings, error) {
+func (s *Store) GetMRRSettings(userID uint, guildID string) (*MRRSettings, error) {
 	var settings MRRSettings
-	result := DB.Where("user_id = ? AND guild_id = ?", userID, guildID).First(&settings)
+	result := s.db.Where("user_id = ? AND guild_id = ?", userID, guildID).First(&settings)
 
 	if result.Error == gorm.ErrRecordNotFound {
 		settings = MRRSettings{
@@ -72,7 +72,7 @@ func GetMRRSettings(userID uint, guildID string) (*MRRSettings, error) {
 			IsPublic:             false,
 			LastMilestoneReached: 0,
 		}
-		if err := DB.Create(&settings).Error; err != nil {
+		if err := s.db.Create(&settings).Error; err != nil {
 			return nil, fmt.Errorf("failed to create MRR settings: %w", err)
 		}
 		return &settings, nil
@@ -86,14 +86,14 @@ func GetMRRSettings(userID uint, guildID string) (*MRRSettings, error) {
 }
 
 // UpdateMRRVisibility updates the public visibility of a user's MRR
-func UpdateMRRVisibility(userID uint, guildID string, isPublic bool) error {
-	settings, err := GetMRRSettings(userID, guildID)
+func (s *Store) UpdateMRRVisibility(userID uint, guildID string, isPublic bool) error {
+	settings, err := s.GetMRRSettings(userID, guildID)
 	if err != nil {
 		return err
 	}
 
 	settings.IsPublic = isPublic
-	if err := DB.Save(settings).Error; err != nil {
+	if err := s.db.Save(settings).Error; err != nil {
 		return fmt.Errorf("failed to update MRR visibility: %w", err)
 	}
 
@@ -101,9 +101,9 @@ func UpdateMRRVisibility(userID uint, guildID string, isPublic bool) error {
 }
 
 // GetLatestMRR gets the most recent MRR entry for a user
-func GetLatestMRR(userID uint, guildID string) (*MRREntry, error) {
+func (s *Store) GetLatestMRR(userID uint, guildID string) (*MRREntry, error) {
 	var entry MRREntry
-	result := DB.Where("user_id = ? AND guild_id = ?", userID, guildID).
+	result := s.db.Where("user_id = ? AND guild_id = ?", userID, guildID).
 		Order("date DESC").
 		First(&entry)
 
@@ -117,12 +117,12 @@ func GetLatestMRR(userID uint, guildID string) (*MRREntry, error) {
 	return &entry, nil
 }
 
-// GetMRRHistory gets MRR history for a user
-func GetMRRHistory(userID uint, guildID string, months int) ([]MRREntry, error) {
+// GetMRRHistory gets MRR history for a user, counting months in the user's timezone
+func (s *Store) GetMRRHistory(userID uint, guildID string, months int) ([]MRREntry, error) {
 	var entries []MRREntry
-	since := time.Now().AddDate(0, -months, 0)
+	since := time.Now().In(s.UserLocation(userID, guildID)).AddDate(0, -months, 0).Local()
 
-	result := DB.Where("user_id = ? AND guild_id = ? AND date >= ?", userID, guildID, since).
+	result := s.db.Where("user_id = ? AND guild_id = ? AND date >= ?", userID, guildID, since).
 		Order("date DESC").
 		Find(&entries)
 
@@ -144,18 +144,19 @@ type MRRLeaderboardEntry struct {
 }
 
 // GetMRRLeaderboard gets the public MRR leaderboard
-func GetMRRLeaderboard(guildID string, limit int) ([]MRRLeaderboardEntry, error) {
+func (s *Store) GetMRRLeaderboard(guildID string, limit int) ([]MRRLeaderboardEntry, error) {
 	var entries []MRRLeaderboardEntry
 
 	// Get latest MRR for each user who has public MRR
-	rows, err := DB.Raw(`
+	rows, err := s.db.Raw(`
 		SELECT
 			u.discord_id,
-			u.username,
+			gm.username,
 			mrr.amount,
 			mrr.currency
 		FROM mrr_entries mrr
 		JOIN users u ON u.id = mrr.user_id
+		JOIN guild_members gm ON gm.user_id = mrr.user_id AND gm.guild_id = mrr.guild_id
 		JOIN mrr_settings ms ON ms.user_id = mrr.user_id AND ms.guild_id = mrr.guild_id
 		WHERE mrr.guild_id = ?
 		  AND ms.is_public = true
@@ -187,8 +188,8 @@ func GetMRRLeaderboard(guildID string, limit int) ([]MRRLeaderboardEntry, error)
 }
 
 // GetMRRChannel gets the MRR milestone channel for a guild
-func GetMRRChannel(guildID string) (string, error) {
-	config, err := GetOrCreateGuildConfig(guildID)
+func (s *Store) GetMRRChannel(guildID string) (string, error) {
+	config, err := s.GetOrCreateGuildConfig(guildID)
 	if err != nil {
 		return "", err
 	}
@@ -196,14 +197,14 @@ func GetMRRChannel(guildID string) (string, error) {
 }
 
 // UpdateMRRChannel updates the MRR channel for a guild
-func UpdateMRRChannel(guildID, channelID string) error {
-	config, err := GetOrCreateGuildConfig(guildID)
+func (s *Store) UpdateMRRChannel(guildID, channelID string) error {
+	config, err := s.GetOrCreateGuildConfig(guildID)
 	if err != nil {
 		return err
 	}
 
 	config.MRRChannel = channelID
-	if err := DB.Save(config).Error; err != nil {
+	if err := s.db.Save(config).Error; err != nil {
 		return fmt.Errorf("failed to update MRR channel: %w", err)
 	}
 
@@ -241,14 +242,14 @@ type MRRStats struct {
 }
 
 // UpdateMRRProjectChannel updates the project channel for a user's MRR reminders
-func UpdateMRRProjectChannel(userID uint, guildID, channelID string) error {
-	settings, err := GetMRRSettings(userID, guildID)
+func (s *Store) UpdateMRRProjectChannel(userID uint, guildID, channelID string) error {
+	settings, err := s.GetMRRSettings(userID, guildID)
 	if err != nil {
 		return err
 	}
 
 	settings.ProjectChannelID = channelID
-	if err := DB.Save(settings).Error; err != nil {
+	if err := s.db.Save(settings).Error; err != nil {
 		return fmt.Errorf("failed to update MRR project channel: %w", err)
 	}
 
@@ -256,9 +257,9 @@ func UpdateMRRProjectChannel(userID uint, guildID, channelID string) error {
 }
 
 // GetUsersWithProjectChannels gets all users with configured project channels in a guild
-func GetUsersWithProjectChannels(guildID string) ([]MRRSettings, error) {
+func (s *Store) GetUsersWithProjectChannels(guildID string) ([]MRRSettings, error) {
 	var settings []MRRSettings
-	result := DB.Preload("User").Where("guild_id = ? AND project_channel_id != ''", guildID).Find(&settings)
+	result := s.db.Preload("User").Where("guild_id = ? AND project_channel_id != ''", guildID).Find(&settings)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to fetch users with project channels: %w", result.Error)
 	}
@@ -277,38 +278,40 @@ type MRRShowcaseEntry struct {
 }
 
 // GetPublicMRRWithGrowth gets public MRR entries with month-over-month growth data
-func GetPublicMRRWithGrowth(guildID string) ([]MRRShowcaseEntry, error) {
+func (s *Store) GetPublicMRRWithGrowth(guildID string) ([]MRRShowcaseEntry, error) {
 	var entries []MRRShowcaseEntry
 
-	// Get latest MRR for each user who has public MRR, along with previous month's data
-	now := time.Now()
+	// Get latest MRR for each user who has public MRR, along with previous month's data.
+	// Month boundaries follow the guild's timezone.
+	now := time.Now().In(s.GuildLocation(guildID))
 	// Start of current month
-	currentMonthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	currentMonthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Local()
 	// Start of previous month
-	previousMonthStart := currentMonthStart.AddDate(0, -1, 0)
+	previousMonthStart := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, now.Location()).Local()
 
-	rows, err := DB.Raw(`
+	rows, err := s.db.Raw(`
 		SELECT
 			u.discord_id,
-			u.username,
+			gm.username,
 			current_mrr.amount AS current_amount,
 			COALESCE(prev_mrr.amount, 0) AS previous_amount,
 			current_mrr.currency
 		FROM mrr_entries current_mrr
 		JOIN users u ON u.id = current_mrr.user_id
+		JOIN guild_members gm ON gm.user_id = current_mrr.user_id AND gm.guild_id = current_mrr.guild_id
 		JOIN mrr_settings ms ON ms.user_id = current_mrr.user_id AND ms.guild_id = current_mrr.guild_id
 		LEFT JOIN (
-			SELECT user_id, guild_id, amount
-			FROM mrr_entries
-			WHERE guild_id = ?
-			  AND date >= ? AND date < ?
-			  AND date = (
+			SELECT m1.user_id, m1.guild_id, m1.amount
+			FROM mrr_entries m1
+			WHERE m1.guild_id = ?
+			  AND m1.date >= ? AND m1.date < ?
+			  AND m1.date = (
 				SELECT MAX(m2.date) FROM mrr_entries m2
-				WHERE m2.user_id = mrr_entries.user_id
-				  AND m2.guild_id = mrr_entries.guild_id
+				WHERE m2.user_id = m1.user_id
+				  AND m2.guild_id = m1.guild_id
 				  AND m2.date >= ? AND m2.date < ?
 			  )
-		) prev_mrr ON prev_mrr.user_id = current_mrr.user_id AND prev_mrr.guild_id = current_mrr.guild_id
+		) AS prev_mrr ON prev_mrr.user_id = current_mrr.user_id AND prev_mrr.guild_id = current_mrr.guild_id
 		WHERE current_mrr.guild_id = ?
 		  AND ms.is_public = true
 		  AND current_mrr.date = (
@@ -339,9 +342,9 @@ func GetPublicMRRWithGrowth(guildID string) ([]MRRShowcaseEntry, error) {
 }
 
 // GetAllGuildsWithMRRChannel gets all guild IDs that have an MRR channel configured
-func GetAllGuildsWithMRRChannel() ([]string, error) {
+func (s *Store) GetAllGuildsWithMRRChannel() ([]string, error) {
 	var guildIDs []string
-	result := DB.Model(&GuildConfig{}).Where("mrr_channel != ''").Pluck("guild_id", &guildIDs)
+	result := s.db.Model(&GuildConfig{}).Where("mrr_channel != ''").Pluck("guild_id", &guildIDs)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to fetch guilds with MRR channel: %w", result.Error)
 	}
@@ -349,9 +352,9 @@ func GetAllGuildsWithMRRChannel() ([]string, error) {
 }
 
 // GetTotalCommunityMRR calculates the total public MRR for a guild
-func GetTotalCommunityMRR(guildID string) (float64, error) {
+func (s *Store) GetTotalCommunityMRR(guildID string) (float64, error) {
 	var total float64
-	result := DB.Raw(`
+	result := s.db.Raw(`
 		SELECT COALESCE(SUM(mrr.amount), 0)
 		FROM mrr_entries mrr
 		JOIN mrr_settings ms ON ms.user_id = mrr.user_id AND ms.guild_id = mrr.guild_id
@@ -369,11 +372,11 @@ func GetTotalCommunityMRR(guildID string) (float64, error) {
 	return total, nil
 }
 
-func GetMRRStats(userID uint, guildID string) (*MRRStats, error) {
+func (s *Store) GetMRRStats(userID uint, guildID string) (*MRRStats, error) {
 	stats := &MRRStats{}
 
 	// Get latest entry
-	latest, err := GetLatestMRR(userID, guildID)
+	latest, err := s.GetLatestMRR(userID, guildID)
 	if err != nil {
 		return nil, err
 	}
@@ -384,14 +387,14 @@ func GetMRRStats(userID uint, guildID string) (*MRRStats, error) {
 
 	// Get all-time high
 	var maxEntry MRREntry
-	DB.Where("user_id = ? AND guild_id = ?", userID, guildID).
+	s.db.Where("user_id = ? AND guild_id = ?", userID, guildID).
 		Order("amount DESC").
 		First(&maxEntry)
 	stats.AllTimeHigh = maxEntry.Amount
 
 	// Get first entry
 	var firstEntry MRREntry
-	result := DB.Where("user_id = ? AND guild_id = ?", userID, guildID).
+	result := s.db.Where("user_id = ? AND guild_id = ?", userID, guildID).
 		Order("date ASC").
 		First(&firstEntry)
 	if result.Error == nil {
@@ -400,13 +403,13 @@ func GetMRRStats(userID uint, guildID string) (*MRRStats, error) {
 
 	// Count entries
 	var count int64
-	DB.Model(&MRREntry{}).Where("user_id = ? AND guild_id = ?", userID, guildID).Count(&count)
+	s.db.Model(&MRREntry{}).Where("user_id = ? AND guild_id = ?", userID, guildID).Count(&count)
 	stats.TotalEntries = int(count)
 
 	// Get monthly growth
-	oneMonthAgo := time.Now().AddDate(0, -1, 0)
+	oneMonthAgo := time.Now().In(s.UserLocation(userID, guildID)).AddDate(0, -1, 0).Local()
 	var previousEntry MRREntry
-	result = DB.Where("user_id = ? AND guild_id = ? AND date <= ?", userID, guildID, oneMonthAgo).
+	result = s.db.Where("user_id = ? AND guild_id = ? AND date <= ?", userID, guildID, oneMonthAgo).
 		Order("date DESC").
 		First(&previousEntry)
 	if result.Error == nil && latest != nil {
@@ -414,7 +417,7 @@ func GetMRRStats(userID uint, guildID string) (*MRRStats, error) {
 	}
 
 	// Get settings
-	settings, _ := GetMRRSettings(userID, guildID)
+	settings, _ := s.GetMRRSettings(userID, guildID)
 	if settings != nil {
 		stats.IsPublic = settings.IsPublic
 
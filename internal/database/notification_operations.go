@@ -0,0 +1,159 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Delivery is how a user receives one type of reminder
+type Delivery string
+
+// Reminder delivery options
+const (
+	DeliveryChannel Delivery = "channel"
+	DeliveryDM      Delivery = "dm"
+	DeliveryOff     Delivery = "off"
+)
+
+// DefaultDelivery is how a reminder type is delivered until the user chooses.
+// Challenge reminders have always been DMs; everything else is posted in a channel.
+func DefaultDelivery(feature ReminderFeature) Delivery {
+	if feature == ReminderFeatureChallenge {
+		return DeliveryDM
+	}
+	return DeliveryChannel
+}
+
+// DeliveryFor returns how the user wants reminders of a type delivered
+func (n *NotificationSettings) DeliveryFor(feature ReminderFeature) Delivery {
+	var value string
+	switch feature {
+	case ReminderFeatureFocus:
+		value = n.FocusDelivery
+	case ReminderFeatureStandup:
+		value = n.StandupDelivery
+	case ReminderFeatureChallenge:
+		value = n.ChallengeDelivery
+	case ReminderFeatureMRR:
+		value = n.MRRDelivery
+	}
+
+	if value == "" {
+		return DefaultDelivery(feature)
+	}
+	return Delivery(value)
+}
+
+// InQuietHours reports whether a local hour falls in the user's quiet hours.
+// Quiet hours may wrap past midnight, e.g. 22 to 7.
+func (n *NotificationSettings) InQuietHours(hour int) bool {
+	if n.QuietHoursStart == nil || n.QuietHoursEnd == nil {
+		return false
+	}
+
+	start, end := *n.QuietHoursStart, *n.QuietHoursEnd
+	if start <= end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
+
+// GetNotificationSettings gets a user's notification settings in a guild.
+// Users who never changed them get the defaults.
+func (s *Store) GetNotificationSettings(userID uint, guildID string) (*NotificationSettings, error) {
+	var settings NotificationSettings
+	result := s.db.Where("user_id = ? AND guild_id = ?", userID, guildID).First(&settings)
+
+	if result.Error == gorm.ErrRecordNotFound {
+		return &NotificationSettings{UserID: userID, GuildID: guildID}, nil
+	}
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch notification settings: %w", result.Error)
+	}
+
+	return &settings, nil
+}
+
+// getOrCreateNotificationSettings loads a user's notification settings, creating the row if needed
+func (s *Store) getOrCreateNotificationSettings(userID uint, guildID string) (*NotificationSettings, error) {
+	settings := NotificationSettings{UserID: userID, GuildID: guildID}
+	if err := s.db.Where(NotificationSettings{UserID: userID, GuildID: guildID}).FirstOrCreate(&settings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get notification settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// SetNotificationDelivery sets how a user receives one type of reminder
+func (s *Store) SetNotificationDelivery(userID uint, guildID string, feature ReminderFeature, delivery Delivery) error {
+	switch delivery {
+	case DeliveryChannel, DeliveryDM, DeliveryOff:
+	default:
+		return fmt.Errorf("unknown delivery %q", delivery)
+	}
+
+	settings, err := s.getOrCreateNotificationSettings(userID, guildID)
+	if err != nil {
+		return err
+	}
+
+	switch feature {
+	case ReminderFeatureFocus:
+		settings.FocusDelivery = string(delivery)
+	case ReminderFeatureStandup:
+		settings.StandupDelivery = string(delivery)
+	case ReminderFeatureChallenge:
+		settings.ChallengeDelivery = string(delivery)
+	case ReminderFeatureMRR:
+		settings.MRRDelivery = string(delivery)
+	default:
+		return fmt.Errorf("unknown reminder feature %q", feature)
+	}
+
+	if err := s.db.Save(settings).Error; err != nil {
+		return fmt.Errorf("failed to update notification settings: %w", err)
+	}
+
+	return nil
+}
+
+// SetQuietHours sets the local hours during which a user gets no reminders.
+// Reminders due during quiet hours are sent once they end.
+func (s *Store) SetQuietHours(userID uint, guildID string, start, end int) error {
+	if start < 0 || start > 23 || end < 0 || end > 23 {
+		return fmt.Errorf("quiet hours must be between 0 and 23")
+	}
+	if start == end {
+		return fmt.Errorf("quiet hours must start and end at different hours")
+	}
+
+	settings, err := s.getOrCreateNotificationSettings(userID, guildID)
+	if err != nil {
+		return err
+	}
+
+	settings.QuietHoursStart = &start
+	settings.QuietHoursEnd = &end
+	if err := s.db.Save(settings).Error; err != nil {
+		return fmt.Errorf("failed to update quiet hours: %w", err)
+	}
+
+	return nil
+}
+
+// ClearQuietHours removes a user's quiet hours
+func (s *Store) ClearQuietHours(userID uint, guildID string) error {
+	result := s.db.Model(&NotificationSettings{}).
+		Where("user_id = ? AND guild_id = ?", userID, guildID).
+		Updates(map[string]interface{}{
+			"quiet_hours_start": nil,
+			"quiet_hours_end":   nil,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to clear quiet hours: %w", result.Error)
+	}
+
+	return nil
+}
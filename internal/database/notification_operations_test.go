@@ -0,0 +1,50 @@
+package database
+
+import "testing"
+
+func TestNotificationSettingsDefaultsAndUpdates(t *testing.T) {
+	store := setupTestDB(t)
+	if err := store.db.AutoMigrate(&NotificationSettings{}); err != nil {
+		t.Fatalf("Failed to migrate notification settings: %v", err)
+	}
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+
+	settings, err := store.GetNotificationSettings(user.ID, guildID)
+	if err != nil {
+		t.Fatalf("Failed to get settings: %v", err)
+	}
+	if settings.DeliveryFor(ReminderFeatureFocus) != DeliveryChannel || settings.DeliveryFor(ReminderFeatureChallenge) != DeliveryDM {
+		t.Errorf("Expected channel focus and DM challenge defaults, got %+v", settings)
+	}
+
+	if err := store.SetNotificationDelivery(user.ID, guildID, ReminderFeatureStandup, DeliveryOff); err != nil {
+		t.Fatalf("Failed to set delivery: %v", err)
+	}
+	if err := store.SetNotificationDelivery(user.ID, guildID, ReminderFeatureStandup, "carrier-pigeon"); err == nil {
+		t.Error("Expected an unknown delivery to be rejected")
+	}
+	if err := store.SetQuietHours(user.ID, guildID, 22, 7); err != nil {
+		t.Fatalf("Failed to set quiet hours: %v", err)
+	}
+
+	settings, _ = store.GetNotificationSettings(user.ID, guildID)
+	if settings.DeliveryFor(ReminderFeatureStandup) != DeliveryOff {
+		t.Errorf("Expected standup reminders off, got %s", settings.DeliveryFor(ReminderFeatureStandup))
+	}
+
+	// Quiet hours wrap past midnight
+	for hour, quiet := range map[int]bool{21: false, 22: true, 2: true, 7: false, 12: false} {
+		if settings.InQuietHours(hour) != quiet {
+			t.Errorf("InQuietHours(%d) = %v, want %v", hour, !quiet, quiet)
+		}
+	}
+
+	if err := store.ClearQuietHours(user.ID, guildID); err != nil {
+		t.Fatalf("Failed to clear quiet hours: %v", err)
+	}
+	settings, _ = store.GetNotificationSettings(user.ID, guildID)
+	if settings.InQuietHours(23) {
+		t.Error("Expected no quiet hours after clearing")
+	}
+}
@@ -1,7 +1,6 @@
 package database
 
 import (
-	"database/sql"
 	"fmt"
 	"time"
 
@@ -18,16 +17,61 @@ type LeaderboardEntry struct {
 	CompletedAt time.Time
 }
 
+// timestampFormats are the layouts SQLite may use for timestamps returned from aggregates
+var timestampFormats = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// nullTime scans a nullable timestamp. Aggregates like MAX(completed_at) lose their
+// column type in SQLite and come back as text, so strings are parsed as well.
+type nullTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+// Scan implements sql.Scanner
+func (nt *nullTime) Scan(value interface{}) error {
+	nt.Time, nt.Valid = time.Time{}, false
+
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		nt.Time, nt.Valid = v, true
+		return nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return fmt.Errorf("unsupported timestamp type %T", value)
+	}
+
+	for _, layout := range timestampFormats {
+		if t, err := time.Parse(layout, text); err == nil {
+			nt.Time, nt.Valid = t, true
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported timestamp format %q", text)
+}
+
 // GetOrCreateGuildConfig gets or creates guild configuration
-func GetOrCreateGuildConfig(guildID string) (*GuildConfig, error) {
+func (s *Store) GetOrCreateGuildConfig(guildID string) (*GuildConfig, error) {
 	var config GuildConfig
-	result := DB.Where("guild_id = ?", guildID).First(&config)
+	result := s.db.Where("guild_id = ?", guildID).First(&config)
 
 	if result.Error == gorm.ErrRecordNotFound {
 		config = GuildConfig{
 			GuildID: guildID,
 		}
-		if err := DB.Create(&config).Error; err != nil {
+		if err := s.db.Create(&config).Error; err != nil {
 			return nil, fmt.Errorf("failed to create guild config: %w", err)
 		}
 		return &config, nil
@@ -41,14 +85,14 @@ func GetOrCreateGuildConfig(guildID string) (*GuildConfig, error) {
 }
 
 // UpdateLeaderboardChannel updates the leaderboard channel for a guild
-func UpdateLeaderboardChannel(guildID, channelID string) error {
-	config, err := GetOrCreateGuildConfig(guildID)
+func (s *Store) UpdateLeaderboardChannel(guildID, channelID string) error {
+	config, err := s.GetOrCreateGuildConfig(guildID)
 	if err != nil {
 		return err
 	}
 
 	config.LeaderboardChannel = channelID
-	if err := DB.Save(config).Error; err != nil {
+	if err := s.db.Save(config).Error; err != nil {
 		return fmt.Errorf("failed to update leaderboard channel: %w", err)
 	}
 
@@ -56,8 +100,8 @@ func UpdateLeaderboardChannel(guildID, channelID string) error {
 }
 
 // GetLeaderboardChannel gets the leaderboard channel for a guild
-func GetLeaderboardChannel(guildID string) (string, error) {
-	config, err := GetOrCreateGuildConfig(guildID)
+func (s *Store) GetLeaderboardChannel(guildID string) (string, error) {
+	config, err := s.GetOrCreateGuildConfig(guildID)
 	if err != nil {
 		return "", err
 	}
@@ -65,9 +109,9 @@ func GetLeaderboardChannel(guildID string) (string, error) {
 }
 
 // GetOrCreateSprintPoints gets or creates sprint points for a focus period
-func GetOrCreateSprintPoints(focusPeriodID, userID uint, guildID string, startDate, endDate time.Time) (*SprintPoints, error) {
+func (s *Store) GetOrCreateSprintPoints(focusPeriodID, userID uint, guildID string, startDate, endDate time.Time) (*SprintPoints, error) {
 	var sp SprintPoints
-	result := DB.Where("focus_period_id = ? AND user_id = ?", focusPeriodID, userID).First(&sp)
+	result := s.db.Where("focus_period_id = ? AND user_id = ?", focusPeriodID, userID).First(&sp)
 
 	if result.Error == gorm.ErrRecordNotFound {
 		sp = SprintPoints{
@@ -78,7 +122,7 @@ func GetOrCreateSprintPoints(focusPeriodID, userID uint, guildID string, startDa
 			StartDate:     startDate,
 			EndDate:       endDate,
 		}
-		if err := DB.Create(&sp).Error; err != nil {
+		if err := s.db.Create(&sp).Error; err != nil {
 			return nil, fmt.Errorf("failed to create sprint points: %w", err)
 		}
 		return &sp, nil
@@ -92,67 +136,79 @@ func GetOrCreateSprintPoints(focusPeriodID, userID uint, guildID string, startDa
 }
 
 // AddPointsToUser adds points to a user's total and sprint total
-func AddPointsToUser(userID, focusPeriodID uint, points int, guildID string, startDate, endDate time.Time) error {
-	return DB.Transaction(func(tx *gorm.DB) error {
-		// Update user's total points
-		var user User
-		if err := tx.Where("id = ?", userID).First(&user).Error; err != nil {
-			return fmt.Errorf("failed to fetch user: %w", err)
-		}
-		user.TotalPoints += points
-		if err := tx.Save(&user).Error; err != nil {
-			return fmt.Errorf("failed to update user points: %w", err)
+func (s *Store) AddPointsToUser(userID, focusPeriodID uint, points int, guildID string, startDate, endDate time.Time) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// Update user's total points in this guild
+		if err := addMemberPoints(tx, userID, guildID, points, PointsSource{Type: PointsSourceFocusPeriod, ID: focusPeriodID}); err != nil {
+			return err
 		}
 
-		// Update sprint points
-		var sp SprintPoints
-		result := tx.Where("focus_period_id = ? AND user_id = ?", focusPeriodID, userID).First(&sp)
-
-		if result.Error == gorm.ErrRecordNotFound {
-			sp = SprintPoints{
-				FocusPeriodID: focusPeriodID,
-				UserID:        userID,
-				GuildID:       guildID,
-				Points:        points,
-				StartDate:     startDate,
-				EndDate:       endDate,
-			}
-			if err := tx.Create(&sp).Error; err != nil {
-				return fmt.Errorf("failed to create sprint points: %w", err)
-			}
-		} else if result.Error != nil {
-			return fmt.Errorf("failed to fetch sprint points: %w", result.Error)
-		} else {
-			sp.Points += points
-			if err := tx.Save(&sp).Error; err != nil {
-				return fmt.Errorf("failed to update sprint points: %w", err)
-			}
+		return addSprintPoints(tx, userID, focusPeriodID, points, guildID, startDate, endDate)
+	})
+}
+
+// addSprintPoints adds points, which may be negative, to a user's total for a focus period
+func addSprintPoints(tx *gorm.DB, userID, focusPeriodID uint, points int, guildID string, startDate, endDate time.Time) error {
+	var sp SprintPoints
+	result := tx.Where("focus_period_id = ? AND user_id = ?", focusPeriodID, userID).First(&sp)
+
+	if result.Error == gorm.ErrRecordNotFound {
+		sp = SprintPoints{
+			FocusPeriodID: focusPeriodID,
+			UserID:        userID,
+			GuildID:       guildID,
+			Points:        points,
+			StartDate:     startDate,
+			EndDate:       endDate,
+		}
+		if err := tx.Create(&sp).Error; err != nil {
+			return fmt.Errorf("failed to create sprint points: %w", err)
+		}
+	} else if result.Error != nil {
+		return fmt.Errorf("failed to fetch sprint points: %w", result.Error)
+	} else {
+		sp.Points += points
+		if err := tx.Save(&sp).Error; err != nil {
+			return fmt.Errorf("failed to update sprint points: %w", err)
 		}
+	}
 
-		return nil
-	})
+	return nil
+}
+
+// GetAllTimeLeaderboard gets the all-time leaderboard for a guild.
+// Once a season has been archived it ranks points and tasks since then.
+func (s *Store) GetAllTimeLeaderboard(guildID string, limit int) ([]LeaderboardEntry, error) {
+	return allTimeLeaderboard(s.db, guildID, time.Now(), limit)
 }
 
-// GetAllTimeLeaderboard gets the all-time leaderboard for a guild
-func GetAllTimeLeaderboard(guildID string, limit int) ([]LeaderboardEntry, error) {
+// allTimeLeaderboard ranks members by their total points in a guild,
+// counting the tasks they completed between the start of the current season and until
+func allTimeLeaderboard(tx *gorm.DB, guildID string, until time.Time, limit int) ([]LeaderboardEntry, error) {
 	var entries []LeaderboardEntry
 
-	rows, err := DB.Raw(`
+	since, err := currentSeasonStart(tx, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Raw(`
 		SELECT
 			u.discord_id,
-			u.username,
-			u.total_points as points,
+			gm.username,
+			gm.total_points as points,
 			COUNT(DISTINCT t.id) as tasks_count,
 			MAX(t.completed_at) as completed_at
-		FROM users u
-		LEFT JOIN focus_periods fp ON fp.user_id = u.id
-		LEFT JOIN tasks t ON t.focus_period_id = fp.id AND t.completed = 1
-		WHERE u.guild_id = ?
-		GROUP BY u.id
-		HAVING u.total_points > 0
-		ORDER BY u.total_points DESC, completed_at ASC
+		FROM guild_members gm
+		JOIN users u ON u.id = gm.user_id
+		LEFT JOIN focus_periods fp ON fp.user_id = gm.user_id AND fp.guild_id = gm.guild_id
+		LEFT JOIN tasks t ON t.focus_period_id = fp.id AND t.completed = ? AND t.completed_at >= ? AND t.completed_at <= ?
+		WHERE gm.guild_id = ?
+		GROUP BY gm.id, u.discord_id, gm.username, gm.total_points
+		HAVING gm.total_points > 0
+		ORDER BY gm.total_points DESC, completed_at ASC
 		LIMIT ?
-	`, guildID, limit).Rows()
+	`, true, since.Local(), until.Local(), guildID, limit).Rows()
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch all-time leaderboard: %w", err)
@@ -162,7 +218,7 @@ func GetAllTimeLeaderboard(guildID string, limit int) ([]LeaderboardEntry, error
 	rank := 1
 	for rows.Next() {
 		var entry LeaderboardEntry
-		var completedAt sql.NullTime
+		var completedAt nullTime
 		if err := rows.Scan(&entry.DiscordID, &entry.Username, &entry.Points, &entry.TasksCount, &completedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
 		}
@@ -177,29 +233,61 @@ func GetAllTimeLeaderboard(guildID string, limit int) ([]LeaderboardEntry, error
 	return entries, nil
 }
 
-// GetSprintLeaderboard gets the current sprint leaderboard for a guild
-func GetSprintLeaderboard(guildID string, limit int) ([]LeaderboardEntry, error) {
+// GetSprintLeaderboard gets the current sprint leaderboard for a guild.
+// In cohort mode it ranks the running cohort sprint rather than every overlapping period.
+func (s *Store) GetSprintLeaderboard(guildID string, limit int) ([]LeaderboardEntry, error) {
 	now := time.Now()
+
+	config, err := s.GetGuildConfig(guildID)
+	if err != nil {
+		return nil, err
+	}
+	if config.CohortSprints {
+		sprint, err := s.GetCurrentCohortSprint(guildID, now)
+		if err != nil {
+			return nil, err
+		}
+		if sprint != nil {
+			return s.GetCohortSprintLeaderboard(guildID, sprint.ID, limit)
+		}
+	}
+
+	return s.sprintLeaderboard("sp.start_date <= ? AND sp.end_date >= ?", []interface{}{now, now}, guildID, limit)
+}
+
+// GetSprintLeaderboardForPeriods ranks specific focus periods in a guild.
+// Periods are matched by ID, so it works for ended periods of any length.
+func (s *Store) GetSprintLeaderboardForPeriods(guildID string, periodIDs []uint, limit int) ([]LeaderboardEntry, error) {
+	if len(periodIDs) == 0 {
+		return nil, nil
+	}
+	return s.sprintLeaderboard("sp.focus_period_id IN ?", []interface{}{periodIDs}, guildID, limit)
+}
+
+// sprintLeaderboard ranks sprint points in a guild matching an extra condition
+func (s *Store) sprintLeaderboard(condition string, args []interface{}, guildID string, limit int) ([]LeaderboardEntry, error) {
 	var entries []LeaderboardEntry
 
-	rows, err := DB.Raw(`
+	vars := append([]interface{}{true, guildID}, args...)
+	vars = append(vars, limit)
+	rows, err := s.db.Raw(`
 		SELECT
 			u.discord_id,
-			u.username,
+			gm.username,
 			sp.points,
 			COUNT(DISTINCT t.id) as tasks_count,
 			MAX(t.completed_at) as completed_at
 		FROM sprint_points sp
 		JOIN users u ON u.id = sp.user_id
-		LEFT JOIN tasks t ON t.focus_period_id = sp.focus_period_id AND t.completed = 1
+		JOIN guild_members gm ON gm.user_id = sp.user_id AND gm.guild_id = sp.guild_id
+		LEFT JOIN tasks t ON t.focus_period_id = sp.focus_period_id AND t.completed = ?
 		WHERE sp.guild_id = ?
-		  AND sp.start_date <= ?
-		  AND sp.end_date >= ?
-		GROUP BY sp.id
+		  AND `+condition+`
+		GROUP BY sp.id, u.discord_id, gm.username, sp.points
 		HAVING sp.points > 0
 		ORDER BY sp.points DESC, completed_at ASC
 		LIMIT ?
-	`, guildID, now, now, limit).Rows()
+	`, vars...).Rows()
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch sprint leaderboard: %w", err)
@@ -209,7 +297,7 @@ func GetSprintLeaderboard(guildID string, limit int) ([]LeaderboardEntry, error)
 	rank := 1
 	for rows.Next() {
 		var entry LeaderboardEntry
-		var completedAt sql.NullTime
+		var completedAt nullTime
 		if err := rows.Scan(&entry.DiscordID, &entry.Username, &entry.Points, &entry.TasksCount, &completedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
 		}
@@ -225,14 +313,14 @@ func GetSprintLeaderboard(guildID string, limit int) ([]LeaderboardEntry, error)
 }
 
 // GetCompletedFocusPeriodsByDate gets focus periods that ended on a specific date
-func GetCompletedFocusPeriodsByDate(guildID string, endDate time.Time) ([]FocusPeriod, error) {
+func (s *Store) GetCompletedFocusPeriodsByDate(guildID string, endDate time.Time) ([]FocusPeriod, error) {
 	var periods []FocusPeriod
 
 	// Find periods that ended on the given date (within the same day)
 	startOfDay := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, endDate.Location())
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
-	result := DB.Preload("User").
+	result := s.db.Preload("User").
 		Where("guild_id = ? AND end_date >= ? AND end_date < ?", guildID, startOfDay, endOfDay).
 		Find(&periods)
 
@@ -244,21 +332,22 @@ func GetCompletedFocusPeriodsByDate(guildID string, endDate time.Time) ([]FocusP
 }
 
 // MarkLeaderboardPosted marks that the leaderboard has been posted for a focus period
-func MarkLeaderboardPosted(focusPeriodID uint) error {
-	result := DB.Model(&FocusPeriod{}).Where("id = ?", focusPeriodID).Update("leaderboard_posted", true)
+func (s *Store) MarkLeaderboardPosted(focusPeriodID uint) error {
+	result := s.db.Model(&FocusPeriod{}).Where("id = ?", focusPeriodID).Update("leaderboard_posted", true)
 	if result.Error != nil {
 		return fmt.Errorf("failed to mark leaderboard as posted: %w", result.Error)
 	}
 	return nil
 }
 
-// GetEndedPeriodsNeedingLeaderboard gets focus periods that ended but haven't had leaderboard posted
-func GetEndedPeriodsNeedingLeaderboard(guildID string) ([]FocusPeriod, error) {
+// GetEndedPeriodsNeedingLeaderboard gets solo focus periods that ended but haven't had leaderboard posted.
+// Periods in a cohort sprint are covered by the sprint's recap instead.
+func (s *Store) GetEndedPeriodsNeedingLeaderboard(guildID string) ([]FocusPeriod, error) {
 	var periods []FocusPeriod
 	now := time.Now()
 
-	result := DB.Preload("User").
-		Where("guild_id = ? AND end_date < ? AND leaderboard_posted = ?", guildID, now, false).
+	result := s.db.Preload("User").
+		Where("guild_id = ? AND end_date < ? AND leaderboard_posted = ? AND sprint_id IS NULL", guildID, now, false).
 		Find(&periods)
 
 	if result.Error != nil {
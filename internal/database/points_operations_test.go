@@ -4,40 +4,43 @@ import (
 	"testing"
 	"time"
 
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
-func setupTestDB(t *testing.T) *gorm.DB {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	if err != nil {
-		t.Fatalf("Failed to open test database: %v", err)
-	}
+func setupTestDB(t *testing.T) *Store {
+	db := openTestDB(t)
 
 	// Run migrations
-	err = db.AutoMigrate(
+	err := db.AutoMigrate(
 		&User{},
+		&GuildMember{},
 		&FocusPeriod{},
 		&Task{},
+		&TaskStep{},
 		&GuildConfig{},
 		&SprintPoints{},
+		&FlaggedCompletion{},
+		&PointsTransaction{},
+		&Season{},
+		&SeasonStanding{},
+		&Standup{},
+		&UserStreak{},
+		&Blocker{},
 	)
 	if err != nil {
 		t.Fatalf("Failed to run migrations: %v", err)
 	}
 
-	// Set global DB for tests
-	DB = db
-	return db
+	return NewStore(db)
 }
 
 func TestGetOrCreateGuildConfig(t *testing.T) {
-	setupTestDB(t)
+	store := setupTestDB(t)
 
 	guildID := "test-guild-123"
 
 	// Create config
-	config1, err := GetOrCreateGuildConfig(guildID)
+	config1, err := store.GetOrCreateGuildConfig(guildID)
 	if err != nil {
 		t.Fatalf("Failed to create guild config: %v", err)
 	}
@@ -47,7 +50,7 @@ func TestGetOrCreateGuildConfig(t *testing.T) {
 	}
 
 	// Get existing config
-	config2, err := GetOrCreateGuildConfig(guildID)
+	config2, err := store.GetOrCreateGuildConfig(guildID)
 	if err != nil {
 		t.Fatalf("Failed to get guild config: %v", err)
 	}
@@ -58,18 +61,18 @@ func TestGetOrCreateGuildConfig(t *testing.T) {
 }
 
 func TestUpdateLeaderboardChannel(t *testing.T) {
-	setupTestDB(t)
+	store := setupTestDB(t)
 
 	guildID := "test-guild-123"
 	channelID := "channel-456"
 
-	err := UpdateLeaderboardChannel(guildID, channelID)
+	err := store.UpdateLeaderboardChannel(guildID, channelID)
 	if err != nil {
 		t.Fatalf("Failed to update leaderboard channel: %v", err)
 	}
 
 	// Verify update
-	config, err := GetOrCreateGuildConfig(guildID)
+	config, err := store.GetOrCreateGuildConfig(guildID)
 	if err != nil {
 		t.Fatalf("Failed to get guild config: %v", err)
 	}
@@ -80,19 +83,16 @@ func TestUpdateLeaderboardChannel(t *testing.T) {
 }
 
 func TestAddPointsToUser(t *testing.T) {
-	setupTestDB(t)
+	store := setupTestDB(t)
 
 	guildID := "test-guild-123"
 	userDiscordID := "user-789"
 
 	// Create user
-	user := User{
-		DiscordID:   userDiscordID,
-		GuildID:     guildID,
-		Username:    "TestUser",
-		TotalPoints: 0,
+	user, err := store.GetOrCreateUser(userDiscordID, guildID, "TestUser")
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
 	}
-	DB.Create(&user)
 
 	// Create focus period
 	startDate := time.Now()
@@ -103,25 +103,27 @@ func TestAddPointsToUser(t *testing.T) {
 		StartDate: startDate,
 		EndDate:   endDate,
 	}
-	DB.Create(&period)
+	store.db.Create(&period)
 
 	// Add points
 	points := 7
-	err := AddPointsToUser(user.ID, period.ID, points, guildID, startDate, endDate)
+	err = store.AddPointsToUser(user.ID, period.ID, points, guildID, startDate, endDate)
 	if err != nil {
 		t.Fatalf("Failed to add points: %v", err)
 	}
 
 	// Verify user points
-	var updatedUser User
-	DB.First(&updatedUser, user.ID)
-	if updatedUser.TotalPoints != points {
-		t.Errorf("Expected TotalPoints %d, got %d", points, updatedUser.TotalPoints)
+	member, err := store.GetGuildMember(user.ID, guildID)
+	if err != nil || member == nil {
+		t.Fatalf("Failed to get guild member: %v", err)
+	}
+	if member.TotalPoints != points {
+		t.Errorf("Expected TotalPoints %d, got %d", points, member.TotalPoints)
 	}
 
 	// Verify sprint points
 	var sp SprintPoints
-	err = DB.Where("focus_period_id = ? AND user_id = ?", period.ID, user.ID).First(&sp).Error
+	err = store.db.Where("focus_period_id = ? AND user_id = ?", period.ID, user.ID).First(&sp).Error
 	if err != nil {
 		t.Fatalf("Failed to get sprint points: %v", err)
 	}
@@ -132,46 +134,140 @@ func TestAddPointsToUser(t *testing.T) {
 
 	// Add more points
 	additionalPoints := 3
-	err = AddPointsToUser(user.ID, period.ID, additionalPoints, guildID, startDate, endDate)
+	err = store.AddPointsToUser(user.ID, period.ID, additionalPoints, guildID, startDate, endDate)
 	if err != nil {
 		t.Fatalf("Failed to add additional points: %v", err)
 	}
 
 	// Verify cumulative points
-	DB.First(&updatedUser, user.ID)
+	member, _ = store.GetGuildMember(user.ID, guildID)
 	expectedTotal := points + additionalPoints
-	if updatedUser.TotalPoints != expectedTotal {
-		t.Errorf("Expected TotalPoints %d, got %d", expectedTotal, updatedUser.TotalPoints)
+	if member.TotalPoints != expectedTotal {
+		t.Errorf("Expected TotalPoints %d, got %d", expectedTotal, member.TotalPoints)
+	}
+}
+
+func TestGetOrCreateUserAcrossGuilds(t *testing.T) {
+	store := setupTestDB(t)
+
+	// Same Discord user in two guilds
+	userA, err := store.GetOrCreateUser("user-789", "guild-a", "TestUser")
+	if err != nil {
+		t.Fatalf("Failed to create user in guild A: %v", err)
+	}
+	userB, err := store.GetOrCreateUser("user-789", "guild-b", "TestUser")
+	if err != nil {
+		t.Fatalf("Failed to create user in guild B: %v", err)
+	}
+
+	if userA.ID != userB.ID {
+		t.Errorf("Expected same global user, got %d and %d", userA.ID, userB.ID)
+	}
+
+	// Points in guild A must not show up in guild B
+	now := time.Now()
+	period, err := store.CreateFocusPeriod(userA.ID, "guild-a")
+	if err != nil {
+		t.Fatalf("Failed to create focus period: %v", err)
+	}
+	if err := store.AddPointsToUser(userA.ID, period.ID, 5, "guild-a", now, now.Add(time.Hour)); err != nil {
+		t.Fatalf("Failed to add points: %v", err)
+	}
+
+	memberA, _ := store.GetGuildMember(userA.ID, "guild-a")
+	memberB, _ := store.GetGuildMember(userA.ID, "guild-b")
+	if memberA.TotalPoints != 5 {
+		t.Errorf("Expected 5 points in guild A, got %d", memberA.TotalPoints)
+	}
+	if memberB.TotalPoints != 0 {
+		t.Errorf("Expected 0 points in guild B, got %d", memberB.TotalPoints)
+	}
+
+	// Focus periods are scoped per guild too
+	current, err := store.GetCurrentFocusPeriod(userA.ID, "guild-b")
+	if err != nil {
+		t.Fatalf("Failed to get focus period: %v", err)
+	}
+	if current != nil {
+		t.Error("Expected no focus period in guild B")
+	}
+}
+
+// legacyUser mirrors the users table before guild memberships were introduced
+type legacyUser struct {
+	gorm.Model
+	DiscordID   string `gorm:"uniqueIndex;not null"`
+	GuildID     string `gorm:"index;not null"`
+	Username    string
+	TotalPoints int `gorm:"default:0"`
+}
+
+func (legacyUser) TableName() string { return "users" }
+
+func TestMigrateLegacyUsers(t *testing.T) {
+	db := openTestDB(t)
+
+	// Legacy schema with guild_id and total_points on users
+	err := db.AutoMigrate(&legacyUser{})
+	if err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+	db.Create(&legacyUser{DiscordID: "user1", GuildID: "guild-a", Username: "User1", TotalPoints: 42})
+
+	if err := db.AutoMigrate(&User{}, &GuildMember{}); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+	if err := migrateLegacyUsers(db); err != nil {
+		t.Fatalf("Failed to migrate legacy users: %v", err)
+	}
+
+	if db.Migrator().HasColumn(&User{}, "guild_id") {
+		t.Error("Expected guild_id column to be dropped")
+	}
+
+	var member GuildMember
+	if err := db.Where("guild_id = ?", "guild-a").First(&member).Error; err != nil {
+		t.Fatalf("Expected guild member to be created: %v", err)
+	}
+	if member.TotalPoints != 42 || member.Username != "User1" {
+		t.Errorf("Expected migrated member with 42 points, got %d (%s)", member.TotalPoints, member.Username)
+	}
+
+	// Running again is a no-op
+	if err := migrateLegacyUsers(db); err != nil {
+		t.Fatalf("Expected second migration to succeed: %v", err)
 	}
 }
 
 func TestGetAllTimeLeaderboard(t *testing.T) {
-	db := setupTestDB(t)
+	store := setupTestDB(t)
 
 	guildID := "test-guild-123"
 
 	// Create test users with points
 	users := []User{
-		{DiscordID: "user1", GuildID: guildID, Username: "User1", TotalPoints: 50},
-		{DiscordID: "user2", GuildID: guildID, Username: "User2", TotalPoints: 30},
-		{DiscordID: "user3", GuildID: guildID, Username: "User3", TotalPoints: 0},
-		{DiscordID: "user4", GuildID: guildID, Username: "User4", TotalPoints: 40},
+		{DiscordID: "user1", Username: "User1"},
+		{DiscordID: "user2", Username: "User2"},
+		{DiscordID: "user3", Username: "User3"},
+		{DiscordID: "user4", Username: "User4"},
 	}
+	points := []int{50, 30, 0, 40}
 
-	for _, u := range users {
-		db.Create(&u)
+	for i := range users {
+		store.db.Create(&users[i])
+		store.db.Create(&GuildMember{UserID: users[i].ID, GuildID: guildID, Username: users[i].Username, TotalPoints: points[i]})
 	}
 
 	// Create focus periods and tasks for users with points
 	for i, u := range users {
-		if u.TotalPoints > 0 {
+		if points[i] > 0 {
 			period := FocusPeriod{
 				UserID:    u.ID,
 				GuildID:   guildID,
 				StartDate: time.Now().Add(-14 * 24 * time.Hour),
 				EndDate:   time.Now(),
 			}
-			db.Create(&period)
+			store.db.Create(&period)
 
 			// Create completed task
 			now := time.Now()
@@ -182,12 +278,12 @@ func TestGetAllTimeLeaderboard(t *testing.T) {
 				Completed:     true,
 				CompletedAt:   &now,
 			}
-			db.Create(&task)
+			store.db.Create(&task)
 		}
 	}
 
 	// Get leaderboard
-	entries, err := GetAllTimeLeaderboard(guildID, 10)
+	entries, err := store.GetAllTimeLeaderboard(guildID, 10)
 	if err != nil {
 		t.Fatalf("Failed to get leaderboard: %v", err)
 	}
@@ -216,17 +312,16 @@ func TestGetAllTimeLeaderboard(t *testing.T) {
 }
 
 func TestMarkLeaderboardPosted(t *testing.T) {
-	setupTestDB(t)
+	store := setupTestDB(t)
 
 	guildID := "test-guild-123"
 
 	// Create user and focus period
 	user := User{
 		DiscordID: "user1",
-		GuildID:   guildID,
 		Username:  "User1",
 	}
-	DB.Create(&user)
+	store.db.Create(&user)
 
 	startDate := time.Now().Add(-15 * 24 * time.Hour)
 	endDate := startDate.Add(14 * 24 * time.Hour)
@@ -237,34 +332,33 @@ func TestMarkLeaderboardPosted(t *testing.T) {
 		EndDate:           endDate,
 		LeaderboardPosted: false,
 	}
-	DB.Create(&period)
+	store.db.Create(&period)
 
 	// Mark as posted
-	err := MarkLeaderboardPosted(period.ID)
+	err := store.MarkLeaderboardPosted(period.ID)
 	if err != nil {
 		t.Fatalf("Failed to mark leaderboard posted: %v", err)
 	}
 
 	// Verify
 	var updatedPeriod FocusPeriod
-	DB.First(&updatedPeriod, period.ID)
+	store.db.First(&updatedPeriod, period.ID)
 	if !updatedPeriod.LeaderboardPosted {
 		t.Error("Expected LeaderboardPosted to be true")
 	}
 }
 
 func TestGetEndedPeriodsNeedingLeaderboard(t *testing.T) {
-	setupTestDB(t)
+	store := setupTestDB(t)
 
 	guildID := "test-guild-123"
 
 	// Create user
 	user := User{
 		DiscordID: "user1",
-		GuildID:   guildID,
 		Username:  "User1",
 	}
-	DB.Create(&user)
+	store.db.Create(&user)
 
 	now := time.Now()
 
@@ -276,7 +370,7 @@ func TestGetEndedPeriodsNeedingLeaderboard(t *testing.T) {
 		EndDate:           now.Add(-6 * 24 * time.Hour),
 		LeaderboardPosted: false,
 	}
-	DB.Create(&period1)
+	store.db.Create(&period1)
 
 	// Create ended period already posted
 	period2 := FocusPeriod{
@@ -286,7 +380,7 @@ func TestGetEndedPeriodsNeedingLeaderboard(t *testing.T) {
 		EndDate:           now.Add(-26 * 24 * time.Hour),
 		LeaderboardPosted: true,
 	}
-	DB.Create(&period2)
+	store.db.Create(&period2)
 
 	// Create active period
 	period3 := FocusPeriod{
@@ -296,10 +390,10 @@ func TestGetEndedPeriodsNeedingLeaderboard(t *testing.T) {
 		EndDate:           now.Add(9 * 24 * time.Hour),
 		LeaderboardPosted: false,
 	}
-	DB.Create(&period3)
+	store.db.Create(&period3)
 
 	// Get periods needing leaderboard
-	periods, err := GetEndedPeriodsNeedingLeaderboard(guildID)
+	periods, err := store.GetEndedPeriodsNeedingLeaderboard(guildID)
 	if err != nil {
 		t.Fatalf("Failed to get ended periods: %v", err)
 	}
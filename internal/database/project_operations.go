@@ -8,7 +8,7 @@ import (
 )
 
 // CreateProjectMapping creates or updates a role-to-category mapping
-func CreateProjectMapping(guildID, roleID, roleName, categoryID, categoryName string, maxChannels int) (*ProjectMapping, error) {
+func (s *Store) CreateProjectMapping(guildID, roleID, roleName, categoryID, categoryName string, maxChannels int) (*ProjectMapping, error) {
 	mapping := ProjectMapping{
 		GuildID:      guildID,
 		RoleID:       roleID,
@@ -18,7 +18,7 @@ func CreateProjectMapping(guildID, roleID, roleName, categoryID, categoryName st
 		MaxChannels:  maxChannels,
 	}
 
-	result := DB.Clauses(clause.OnConflict{
+	result := s.db.Clauses(clause.OnConflict{
 		Columns: []clause.Column{{Name: "guild_id"}, {Name: "role_id"}},
 		DoUpdates: clause.AssignmentColumns([]string{
 			"role_name", "category_id", "category_name", "max_channels",
@@ -33,8 +33,8 @@ func CreateProjectMapping(guildID, roleID, roleName, categoryID, categoryName st
 }
 
 // RemoveProjectMapping deletes a role-to-category mapping
-func RemoveProjectMapping(guildID, roleID string) error {
-	result := DB.Where("guild_id = ? AND role_id = ?", guildID, roleID).Delete(&ProjectMapping{})
+func (s *Store) RemoveProjectMapping(guildID, roleID string) error {
+	result := s.db.Where("guild_id = ? AND role_id = ?", guildID, roleID).Delete(&ProjectMapping{})
 	if result.Error != nil {
 		return fmt.Errorf("failed to remove project mapping: %w", result.Error)
 	}
@@ -45,9 +45,9 @@ func RemoveProjectMapping(guildID, roleID string) error {
 }
 
 // GetProjectMappings returns all mappings for a guild
-func GetProjectMappings(guildID string) ([]ProjectMapping, error) {
+func (s *Store) GetProjectMappings(guildID string) ([]ProjectMapping, error) {
 	var mappings []ProjectMapping
-	result := DB.Where("guild_id = ?", guildID).Find(&mappings)
+	result := s.db.Where("guild_id = ?", guildID).Find(&mappings)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to fetch project mappings: %w", result.Error)
 	}
@@ -55,9 +55,9 @@ func GetProjectMappings(guildID string) ([]ProjectMapping, error) {
 }
 
 // GetUserMappings finds mappings that match any of the user's role IDs
-func GetUserMappings(guildID string, roleIDs []string) ([]ProjectMapping, error) {
+func (s *Store) GetUserMappings(guildID string, roleIDs []string) ([]ProjectMapping, error) {
 	var mappings []ProjectMapping
-	result := DB.Where("guild_id = ? AND role_id IN ?", guildID, roleIDs).Find(&mappings)
+	result := s.db.Where("guild_id = ? AND role_id IN ?", guildID, roleIDs).Find(&mappings)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to fetch user mappings: %w", result.Error)
 	}
@@ -65,7 +65,7 @@ func GetUserMappings(guildID string, roleIDs []string) ([]ProjectMapping, error)
 }
 
 // CreateProjectChannel records a channel created via /project
-func CreateProjectChannel(guildID, userID, channelID, categoryID, roleID, name, channelType string) (*ProjectChannel, error) {
+func (s *Store) CreateProjectChannel(guildID, userID, channelID, categoryID, roleID, name, channelType string) (*ProjectChannel, error) {
 	channel := ProjectChannel{
 		GuildID:    guildID,
 		UserID:     userID,
@@ -76,7 +76,7 @@ func CreateProjectChannel(guildID, userID, channelID, categoryID, roleID, name,
 		Type:       channelType,
 	}
 
-	if err := DB.Create(&channel).Error; err != nil {
+	if err := s.db.Create(&channel).Error; err != nil {
 		return nil, fmt.Errorf("failed to record project channel: %w", err)
 	}
 
@@ -84,9 +84,9 @@ func CreateProjectChannel(guildID, userID, channelID, categoryID, roleID, name,
 }
 
 // CountUserChannelsInCategory returns how many channels a user has created in a category
-func CountUserChannelsInCategory(guildID, userID, categoryID string) (int64, error) {
+func (s *Store) CountUserChannelsInCategory(guildID, userID, categoryID string) (int64, error) {
 	var count int64
-	result := DB.Model(&ProjectChannel{}).Where(
+	result := s.db.Model(&ProjectChannel{}).Where(
 		"guild_id = ? AND user_id = ? AND category_id = ?",
 		guildID, userID, categoryID,
 	).Count(&count)
@@ -99,9 +99,9 @@ func CountUserChannelsInCategory(guildID, userID, categoryID string) (int64, err
 }
 
 // GetUserChannelsInCategory returns all channels a user has created in a category
-func GetUserChannelsInCategory(guildID, userID, categoryID string) ([]ProjectChannel, error) {
+func (s *Store) GetUserChannelsInCategory(guildID, userID, categoryID string) ([]ProjectChannel, error) {
 	var channels []ProjectChannel
-	result := DB.Where(
+	result := s.db.Where(
 		"guild_id = ? AND user_id = ? AND category_id = ?",
 		guildID, userID, categoryID,
 	).Find(&channels)
@@ -0,0 +1,255 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// DefaultReminderHour is the local hour reminders are delivered from unless a guild changes it
+const DefaultReminderHour = 9
+
+// DefaultFocusPeriodDays is the length of a Focus Period unless a guild changes it
+const DefaultFocusPeriodDays = 14
+
+// Bounds for a guild's Focus Period length
+const (
+	MinFocusPeriodDays = 3
+	MaxFocusPeriodDays = 60
+)
+
+// DefaultFocusReminderPoints are how far through a Focus Period reminders are sent, as percentages.
+// On a 14-day period they fall on days 3, 7, 10, 12 and 13.
+var DefaultFocusReminderPoints = []int{20, 50, 70, 85, 90}
+
+// ReminderFeature identifies a group of reminders that a guild can switch off
+type ReminderFeature string
+
+// Reminder features
+const (
+	ReminderFeatureFocus     ReminderFeature = "focus"
+	ReminderFeatureStandup   ReminderFeature = "standup"
+	ReminderFeatureChallenge ReminderFeature = "challenge"
+	ReminderFeatureMRR       ReminderFeature = "mrr"
+)
+
+// ReminderFeatures lists every feature in display order
+var ReminderFeatures = []ReminderFeature{
+	ReminderFeatureFocus,
+	ReminderFeatureStandup,
+	ReminderFeatureChallenge,
+	ReminderFeatureMRR,
+}
+
+// ReminderEnabled reports whether reminders for a feature are switched on
+func (c *GuildConfig) ReminderEnabled(feature ReminderFeature) bool {
+	switch feature {
+	case ReminderFeatureFocus:
+		return c.FocusRemindersEnabled
+	case ReminderFeatureStandup:
+		return c.StandupRemindersEnabled
+	case ReminderFeatureChallenge:
+		return c.ChallengeRemindersEnabled
+	case ReminderFeatureMRR:
+		return c.MRRRemindersEnabled
+	default:
+		return false
+	}
+}
+
+// FocusLength returns the length in days of new Focus Periods in the guild
+func (c *GuildConfig) FocusLength() int {
+	if c.FocusPeriodDays <= 0 {
+		return DefaultFocusPeriodDays
+	}
+	return c.FocusPeriodDays
+}
+
+// ReminderPoints returns the guild's Focus Period reminder points as percentages
+func (c *GuildConfig) ReminderPoints() []int {
+	points, err := ParseReminderPoints(c.FocusReminderPoints)
+	if err != nil || len(points) == 0 {
+		return DefaultFocusReminderPoints
+	}
+	return points
+}
+
+// ParseReminderPoints parses comma-separated percentages such as "25, 50, 90".
+// The result is sorted with duplicates removed; an empty string parses to nil.
+func ParseReminderPoints(value string) ([]int, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	seen := make(map[int]bool)
+	var points []int
+	for _, part := range strings.Split(value, ",") {
+		text := strings.TrimSuffix(strings.TrimSpace(part), "%")
+		point, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a whole number", text)
+		}
+		if point < 1 || point > 100 {
+			return nil, fmt.Errorf("reminder points must be between 1 and 100, got %d", point)
+		}
+		if !seen[point] {
+			seen[point] = true
+			points = append(points, point)
+		}
+	}
+
+	sort.Ints(points)
+	return points, nil
+}
+
+// ReminderDaysFor converts reminder points into day numbers for a period of the given length.
+// Each point rounds up to the day it falls in, and points sharing a day send one reminder.
+func ReminderDaysFor(length int, points []int) []int {
+	seen := make(map[int]bool)
+	var days []int
+	for _, point := range points {
+		day := (point*length + 99) / 100
+		if day < 1 || day > length || seen[day] {
+			continue
+		}
+		seen[day] = true
+		days = append(days, day)
+	}
+
+	sort.Ints(days)
+	return days
+}
+
+// GetGuildConfig gets a guild's configuration without creating it.
+// Guilds that never ran /config get the defaults.
+func (s *Store) GetGuildConfig(guildID string) (*GuildConfig, error) {
+	var config GuildConfig
+	result := s.db.Where("guild_id = ?", guildID).First(&config)
+
+	if result.Error == gorm.ErrRecordNotFound {
+		return &GuildConfig{
+			GuildID:                   guildID,
+			ReminderHour:              DefaultReminderHour,
+			FocusRemindersEnabled:     true,
+			StandupRemindersEnabled:   true,
+			ChallengeRemindersEnabled: true,
+			MRRRemindersEnabled:       true,
+			FocusPeriodDays:           DefaultFocusPeriodDays,
+			DuplicateGoalCheck:        true,
+		}, nil
+	}
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch guild config: %w", result.Error)
+	}
+
+	return &config, nil
+}
+
+// UpdateReminderChannel updates the reminder channel for a guild
+func (s *Store) UpdateReminderChannel(guildID, channelID string) error {
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	config.ReminderChannel = channelID
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update reminder channel: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateReminderHour sets the local hour a guild's reminders are delivered from
+func (s *Store) UpdateReminderHour(guildID string, hour int) error {
+	if hour < 0 || hour > 23 {
+		return fmt.Errorf("reminder hour must be between 0 and 23, got %d", hour)
+	}
+
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	config.ReminderHour = hour
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update reminder time: %w", err)
+	}
+
+	return nil
+}
+
+// SetReminderEnabled switches reminders for a feature on or off in a guild
+func (s *Store) SetReminderEnabled(guildID string, feature ReminderFeature, enabled bool) error {
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	switch feature {
+	case ReminderFeatureFocus:
+		config.FocusRemindersEnabled = enabled
+	case ReminderFeatureStandup:
+		config.StandupRemindersEnabled = enabled
+	case ReminderFeatureChallenge:
+		config.ChallengeRemindersEnabled = enabled
+	case ReminderFeatureMRR:
+		config.MRRRemindersEnabled = enabled
+	default:
+		return fmt.Errorf("unknown reminder feature %q", feature)
+	}
+
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update %s reminders: %w", feature, err)
+	}
+
+	return nil
+}
+
+// UpdateFocusPeriodLength sets how many days new Focus Periods in a guild run.
+// Periods that already started keep their length.
+func (s *Store) UpdateFocusPeriodLength(guildID string, days int) error {
+	if days < MinFocusPeriodDays || days > MaxFocusPeriodDays {
+		return fmt.Errorf("focus period length must be between %d and %d days, got %d", MinFocusPeriodDays, MaxFocusPeriodDays, days)
+	}
+
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	config.FocusPeriodDays = days
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update focus period length: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateFocusReminderPoints sets how far through a Focus Period reminders are sent.
+// Nil points restore the defaults.
+func (s *Store) UpdateFocusReminderPoints(guildID string, points []int) error {
+	values := make([]string, 0, len(points))
+	for _, point := range points {
+		if point < 1 || point > 100 {
+			return fmt.Errorf("reminder points must be between 1 and 100, got %d", point)
+		}
+		values = append(values, strconv.Itoa(point))
+	}
+
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	config.FocusReminderPoints = strings.Join(values, ",")
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update focus reminder points: %w", err)
+	}
+
+	return nil
+}
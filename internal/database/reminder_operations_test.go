@@ -0,0 +1,65 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReminderDaysFor(t *testing.T) {
+	tests := []struct {
+		length int
+		points []int
+		want   []int
+	}{
+		{14, DefaultFocusReminderPoints, []int{3, 7, 10, 12, 13}},
+		{7, DefaultFocusReminderPoints, []int{2, 4, 5, 6, 7}},
+		{28, DefaultFocusReminderPoints, []int{6, 14, 20, 24, 26}},
+		{3, []int{10, 20, 30}, []int{1}},
+	}
+
+	for _, tt := range tests {
+		if got := ReminderDaysFor(tt.length, tt.points); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ReminderDaysFor(%d, %v) = %v, want %v", tt.length, tt.points, got, tt.want)
+		}
+	}
+}
+
+func TestParseReminderPoints(t *testing.T) {
+	points, err := ParseReminderPoints(" 90, 25%,50,25 ")
+	if err != nil {
+		t.Fatalf("Failed to parse points: %v", err)
+	}
+	if !reflect.DeepEqual(points, []int{25, 50, 90}) {
+		t.Errorf("Expected sorted, de-duplicated points, got %v", points)
+	}
+
+	for _, value := range []string{"half", "0", "120", "50,,90"} {
+		if _, err := ParseReminderPoints(value); err == nil {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestCreateFocusPeriodUsesGuildLength(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+
+	if err := store.UpdateFocusPeriodLength(guildID, 2); err == nil {
+		t.Error("Expected a length below the minimum to be rejected")
+	}
+	if err := store.UpdateFocusPeriodLength(guildID, 7); err != nil {
+		t.Fatalf("Failed to set length: %v", err)
+	}
+
+	period, err := store.CreateFocusPeriod(user.ID, guildID)
+	if err != nil {
+		t.Fatalf("Failed to create focus period: %v", err)
+	}
+	if period.LengthDays() != 7 {
+		t.Errorf("Expected a 7-day period, got %d days", period.LengthDays())
+	}
+	if day := period.DayNumberAt(period.EndDate.AddDate(0, 0, 3)); day != 7 {
+		t.Errorf("Expected the day number to stop at 7, got %d", day)
+	}
+}
@@ -11,7 +11,7 @@ import (
 // ==================== Public Resource Operations ====================
 
 // CreatePublicResource creates a new public resource pending approval
-func CreatePublicResource(guildID, submitterID, submitterUsername, url, title, description, category, tags string) (*PublicResource, error) {
+func (s *Store) CreatePublicResource(guildID, submitterID, submitterUsername, url, title, description, category, tags string) (*PublicResource, error) {
 	resource := PublicResource{
 		GuildID:           guildID,
 		SubmitterID:       submitterID,
@@ -26,7 +26,7 @@ func CreatePublicResource(guildID, submitterID, submitterUsername, url, title, d
 		NotUsefulVotes:    0,
 	}
 
-	if err := DB.Create(&resource).Error; err != nil {
+	if err := s.db.Create(&resource).Error; err != nil {
 		return nil, fmt.Errorf("failed to create public resource: %w", err)
 	}
 
@@ -34,8 +34,8 @@ func CreatePublicResource(guildID, submitterID, submitterUsername, url, title, d
 }
 
 // UpdateResourceVoteMessage updates the vote message details for a resource
-func UpdateResourceVoteMessage(resourceID uint, messageID, channelID string, expiresAt time.Time) error {
-	result := DB.Model(&PublicResource{}).
+func (s *Store) UpdateResourceVoteMessage(resourceID uint, messageID, channelID string, expiresAt time.Time) error {
+	result := s.db.Model(&PublicResource{}).
 		Where("id = ?", resourceID).
 		Updates(map[string]interface{}{
 			"vote_message_id": messageID,
@@ -51,9 +51,9 @@ func UpdateResourceVoteMessage(resourceID uint, messageID, channelID string, exp
 }
 
 // GetPublicResourceByVoteMessageID retrieves a resource by its vote message ID
-func GetPublicResourceByVoteMessageID(messageID string) (*PublicResource, error) {
+func (s *Store) GetPublicResourceByVoteMessageID(messageID string) (*PublicResource, error) {
 	var resource PublicResource
-	result := DB.Where("vote_message_id = ?", messageID).First(&resource)
+	result := s.db.Where("vote_message_id = ?", messageID).First(&resource)
 
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, nil
@@ -67,11 +67,11 @@ func GetPublicResourceByVoteMessageID(messageID string) (*PublicResource, error)
 }
 
 // GetPendingResourcesWithExpiredVotes returns pending resources with expired voting periods
-func GetPendingResourcesWithExpiredVotes() ([]PublicResource, error) {
+func (s *Store) GetPendingResourcesWithExpiredVotes() ([]PublicResource, error) {
 	var resources []PublicResource
 	now := time.Now()
 
-	result := DB.Where("status = ? AND vote_expires_at <= ? AND vote_expires_at IS NOT NULL", ResourceStatusPending, now).Find(&resources)
+	result := s.db.Where("status = ? AND vote_expires_at <= ? AND vote_expires_at IS NOT NULL", ResourceStatusPending, now).Find(&resources)
 
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to fetch expired resources: %w", result.Error)
@@ -81,8 +81,8 @@ func GetPendingResourcesWithExpiredVotes() ([]PublicResource, error) {
 }
 
 // UpdateResourceStatus updates the status of a resource
-func UpdateResourceStatus(resourceID uint, status ResourceStatus, processedAt time.Time) error {
-	result := DB.Model(&PublicResource{}).
+func (s *Store) UpdateResourceStatus(resourceID uint, status ResourceStatus, processedAt time.Time) error {
+	result := s.db.Model(&PublicResource{}).
 		Where("id = ?", resourceID).
 		Updates(map[string]interface{}{
 			"status":       status,
@@ -97,8 +97,8 @@ func UpdateResourceStatus(resourceID uint, status ResourceStatus, processedAt ti
 }
 
 // IncrementUsefulVotes increments the useful vote count for a resource
-func IncrementUsefulVotes(resourceID uint) error {
-	result := DB.Model(&PublicResource{}).
+func (s *Store) IncrementUsefulVotes(resourceID uint) error {
+	result := s.db.Model(&PublicResource{}).
 		Where("id = ?", resourceID).
 		UpdateColumn("useful_votes", gorm.Expr("useful_votes + ?", 1))
 
@@ -110,8 +110,8 @@ func IncrementUsefulVotes(resourceID uint) error {
 }
 
 // DecrementUsefulVotes decrements the useful vote count for a resource
-func DecrementUsefulVotes(resourceID uint) error {
-	result := DB.Model(&PublicResource{}).
+func (s *Store) DecrementUsefulVotes(resourceID uint) error {
+	result := s.db.Model(&PublicResource{}).
 		Where("id = ? AND useful_votes > 0", resourceID).
 		UpdateColumn("useful_votes", gorm.Expr("useful_votes - ?", 1))
 
@@ -123,8 +123,8 @@ func DecrementUsefulVotes(resourceID uint) error {
 }
 
 // IncrementNotUsefulVotes increments the not useful vote count for a resource
-func IncrementNotUsefulVotes(resourceID uint) error {
-	result := DB.Model(&PublicResource{}).
+func (s *Store) IncrementNotUsefulVotes(resourceID uint) error {
+	result := s.db.Model(&PublicResource{}).
 		Where("id = ?", resourceID).
 		UpdateColumn("not_useful_votes", gorm.Expr("not_useful_votes + ?", 1))
 
@@ -136,8 +136,8 @@ func IncrementNotUsefulVotes(resourceID uint) error {
 }
 
 // DecrementNotUsefulVotes decrements the not useful vote count for a resource
-func DecrementNotUsefulVotes(resourceID uint) error {
-	result := DB.Model(&PublicResource{}).
+func (s *Store) DecrementNotUsefulVotes(resourceID uint) error {
+	result := s.db.Model(&PublicResource{}).
 		Where("id = ? AND not_useful_votes > 0", resourceID).
 		UpdateColumn("not_useful_votes", gorm.Expr("not_useful_votes - ?", 1))
 
@@ -149,9 +149,9 @@ func DecrementNotUsefulVotes(resourceID uint) error {
 }
 
 // GetApprovedPublicResources retrieves approved public resources with optional filters
-func GetApprovedPublicResources(guildID string, category string, search string) ([]PublicResource, error) {
+func (s *Store) GetApprovedPublicResources(guildID string, category string, search string) ([]PublicResource, error) {
 	var resources []PublicResource
-	query := DB.Where("guild_id = ? AND status = ?", guildID, ResourceStatusApproved)
+	query := s.db.Where("guild_id = ? AND status = ?", guildID, ResourceStatusApproved)
 
 	if category != "" {
 		categoryPattern := "%" + strings.ToLower(category) + "%"
@@ -174,9 +174,9 @@ func GetApprovedPublicResources(guildID string, category string, search string)
 }
 
 // CheckDuplicateURL checks if a URL already exists as an approved resource in the guild
-func CheckDuplicateURL(guildID, url string) (*PublicResource, error) {
+func (s *Store) CheckDuplicateURL(guildID, url string) (*PublicResource, error) {
 	var resource PublicResource
-	result := DB.Where("guild_id = ? AND url = ? AND status = ?", guildID, url, ResourceStatusApproved).First(&resource)
+	result := s.db.Where("guild_id = ? AND url = ? AND status = ?", guildID, url, ResourceStatusApproved).First(&resource)
 
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, nil
@@ -192,7 +192,7 @@ func CheckDuplicateURL(guildID, url string) (*PublicResource, error) {
 // ==================== Private Resource Operations ====================
 
 // CreatePrivateResourceWithRoles creates a private resource with associated roles
-func CreatePrivateResourceWithRoles(guildID, ownerID, ownerUsername, url, title, description, category, tags string, roles []struct {
+func (s *Store) CreatePrivateResourceWithRoles(guildID, ownerID, ownerUsername, url, title, description, category, tags string, roles []struct {
 	RoleID   string
 	RoleName string
 }) (*PrivateResource, error) {
@@ -208,7 +208,7 @@ func CreatePrivateResourceWithRoles(guildID, ownerID, ownerUsername, url, title,
 	}
 
 	// Create resource and roles in a transaction
-	err := DB.Transaction(func(tx *gorm.DB) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(&resource).Error; err != nil {
 			return fmt.Errorf("failed to create resource: %w", err)
 		}
@@ -233,20 +233,20 @@ func CreatePrivateResourceWithRoles(guildID, ownerID, ownerUsername, url, title,
 	}
 
 	// Reload with associations
-	DB.Preload("AllowedRoles").First(&resource, resource.ID)
+	s.db.Preload("AllowedRoles").First(&resource, resource.ID)
 
 	return &resource, nil
 }
 
 // GetPrivateResourcesForUser retrieves private resources accessible to a user based on their roles
-func GetPrivateResourcesForUser(guildID string, userRoleIDs []string, category string, search string) ([]PrivateResource, error) {
+func (s *Store) GetPrivateResourcesForUser(guildID string, userRoleIDs []string, category string, search string) ([]PrivateResource, error) {
 	var resources []PrivateResource
 
 	if len(userRoleIDs) == 0 {
 		return resources, nil
 	}
 
-	query := DB.Preload("AllowedRoles").
+	query := s.db.Preload("AllowedRoles").
 		Joins("JOIN private_resource_roles ON private_resource_roles.private_resource_id = private_resources.id").
 		Where("private_resources.guild_id = ?", guildID).
 		Where("private_resource_roles.role_id IN ?", userRoleIDs).
@@ -273,8 +273,8 @@ func GetPrivateResourcesForUser(guildID string, userRoleIDs []string, category s
 }
 
 // DeletePrivateResource deletes a private resource (only owner can delete)
-func DeletePrivateResource(resourceID uint, ownerID string) error {
-	result := DB.Where("id = ? AND owner_id = ?", resourceID, ownerID).Delete(&PrivateResource{})
+func (s *Store) DeletePrivateResource(resourceID uint, ownerID string) error {
+	result := s.db.Where("id = ? AND owner_id = ?", resourceID, ownerID).Delete(&PrivateResource{})
 
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete resource: %w", result.Error)
@@ -288,9 +288,9 @@ func DeletePrivateResource(resourceID uint, ownerID string) error {
 }
 
 // GetPrivateResourceByID retrieves a private resource by ID
-func GetPrivateResourceByID(resourceID uint) (*PrivateResource, error) {
+func (s *Store) GetPrivateResourceByID(resourceID uint) (*PrivateResource, error) {
 	var resource PrivateResource
-	result := DB.Preload("AllowedRoles").First(&resource, resourceID)
+	result := s.db.Preload("AllowedRoles").First(&resource, resourceID)
 
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, fmt.Errorf("resource not found")
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaxSeasonNameLength bounds a season's name
+const MaxSeasonNameLength = 50
+
+// ArchiveSeason ends a guild's current season: the all-time standings, with the tasks each member
+// completed during the season, are saved under name and every member's points are reset to zero
+// through the ledger, starting a fresh leaderboard.
+func (s *Store) ArchiveSeason(guildID, name string, archivedByID uint) (*Season, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MaxSeasonNameLength {
+		return nil, fmt.Errorf("season name must be between 1 and %d characters", MaxSeasonNameLength)
+	}
+
+	endedAt := time.Now()
+	var season Season
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&Season{}).Where("guild_id = ? AND LOWER(name) = ?", guildID, strings.ToLower(name)).Count(&existing).Error; err != nil {
+			return fmt.Errorf("failed to check season names: %w", err)
+		}
+		if existing > 0 {
+			return fmt.Errorf("there is already a season named \"%s\"", name)
+		}
+
+		standings, err := allTimeLeaderboard(tx, guildID, endedAt, math.MaxInt32)
+		if err != nil {
+			return err
+		}
+		if len(standings) == 0 {
+			return fmt.Errorf("no one has earned points this season yet")
+		}
+
+		since, err := currentSeasonStart(tx, guildID)
+		if err != nil {
+			return err
+		}
+		season = Season{
+			GuildID:      guildID,
+			Name:         name,
+			EndedAt:      endedAt,
+			ArchivedByID: archivedByID,
+		}
+		if !since.IsZero() {
+			season.StartedAt = &since
+		}
+		for _, entry := range standings {
+			season.Standings = append(season.Standings, SeasonStanding{
+				DiscordID:  entry.DiscordID,
+				Username:   entry.Username,
+				Rank:       entry.Rank,
+				Points:     entry.Points,
+				TasksCount: entry.TasksCount,
+			})
+		}
+		if err := tx.Create(&season).Error; err != nil {
+			return fmt.Errorf("failed to archive season: %w", err)
+		}
+
+		var members []GuildMember
+		if err := tx.Where("guild_id = ? AND total_points <> 0", guildID).Find(&members).Error; err != nil {
+			return fmt.Errorf("failed to fetch guild members: %w", err)
+		}
+		for _, member := range members {
+			source := PointsSource{Type: PointsSourceSeasonReset, ID: season.ID, Note: name}
+			if err := addMemberPoints(tx, member.UserID, guildID, -member.TotalPoints, source); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &season, nil
+}
+
+// currentSeasonStart returns when a guild's current season began: the end of its
+// most recently archived season, or the zero time if none has been archived
+func currentSeasonStart(tx *gorm.DB, guildID string) (time.Time, error) {
+	var season Season
+	result := tx.Where("guild_id = ?", guildID).Order("ended_at DESC").Limit(1).Find(&season)
+	if result.Error != nil {
+		return time.Time{}, fmt.Errorf("failed to fetch current season: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return time.Time{}, nil
+	}
+	return season.EndedAt, nil
+}
+
+// GetSeasons returns a guild's archived seasons with their champions, newest first
+func (s *Store) GetSeasons(guildID string) ([]Season, error) {
+	var seasons []Season
+	result := s.db.Preload("Standings", "rank = ?", 1).
+		Where("guild_id = ?", guildID).
+		Order("ended_at DESC").
+		Find(&seasons)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch seasons: %w", result.Error)
+	}
+
+	return seasons, nil
+}
+
+// GetSeason returns a guild's archived season by name with its standings in rank order, or nil if there is none
+func (s *Store) GetSeason(guildID, name string) (*Season, error) {
+	var season Season
+	result := s.db.Preload("Standings", func(db *gorm.DB) *gorm.DB {
+		return db.Order("rank ASC")
+	}).Where("guild_id = ? AND LOWER(name) = ?", guildID, strings.ToLower(strings.TrimSpace(name))).First(&season)
+
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch season: %w", result.Error)
+	}
+
+	return &season, nil
+}
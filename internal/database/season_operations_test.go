@@ -0,0 +1,129 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+)
+
+func TestArchiveSeason(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	alice, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	bob, _ := store.GetOrCreateUser("user-2", guildID, "bob")
+	admin, _ := store.GetOrCreateUser("user-3", guildID, "admin")
+
+	if _, err := store.ArchiveSeason(guildID, "Spring", admin.ID); err == nil {
+		t.Error("Expected an empty season to be rejected")
+	}
+
+	period, _ := store.CreateFocusPeriod(alice.ID, guildID)
+	store.AddTask(period.ID, "Launch the pricing page", "", estimator.Estimate{Points: 8}, nil)
+	store.AwardTaskCompletion(period, 1)
+	store.AdjustPoints(bob.ID, guildID, 3, admin.ID, "Hosted office hours")
+
+	season, err := store.ArchiveSeason(guildID, "Spring", admin.ID)
+	if err != nil {
+		t.Fatalf("Failed to archive season: %v", err)
+	}
+	if season.StartedAt != nil || len(season.Standings) != 2 {
+		t.Fatalf("Expected the first season with 2 standings, got %+v", season)
+	}
+	if _, err := store.ArchiveSeason(guildID, "spring", admin.ID); err == nil {
+		t.Error("Expected season names to be unique")
+	}
+
+	// Everyone starts the next season from zero, through the ledger
+	leaderboard, _ := store.GetAllTimeLeaderboard(guildID, 10)
+	if len(leaderboard) != 0 {
+		t.Errorf("Expected a fresh leaderboard, got %+v", leaderboard)
+	}
+	if corrected, _ := store.ReconcilePoints(); corrected != 0 {
+		t.Errorf("Expected the reset to match the ledger, got %d corrected", corrected)
+	}
+
+	// Tasks from earlier seasons don't count towards the new one
+	store.AddTask(period.ID, "Interview five customers", "", estimator.Estimate{Points: 6}, nil)
+	store.AwardTaskCompletion(period, 2)
+	leaderboard, _ = store.GetAllTimeLeaderboard(guildID, 10)
+	if len(leaderboard) != 1 || leaderboard[0].Points != 6 || leaderboard[0].TasksCount != 1 {
+		t.Errorf("Expected 6 points from 1 task this season, got %+v", leaderboard)
+	}
+
+	archived, err := store.GetSeason(guildID, " SPRING ")
+	if err != nil || archived == nil {
+		t.Fatalf("Expected to find the season, got %v", err)
+	}
+	first := archived.Standings[0]
+	if first.Username != "alice" || first.Rank != 1 || first.Points != 8 || first.TasksCount != 1 {
+		t.Errorf("Expected alice first with 8 points, got %+v", first)
+	}
+	if missing, _ := store.GetSeason(guildID, "Summer"); missing != nil {
+		t.Errorf("Expected no season named Summer, got %+v", missing)
+	}
+
+	second, err := store.ArchiveSeason(guildID, "Summer", admin.ID)
+	if err != nil {
+		t.Fatalf("Failed to archive second season: %v", err)
+	}
+	if second.StartedAt == nil || !second.StartedAt.Equal(season.EndedAt) {
+		t.Errorf("Expected the second season to start when the first ended, got %v", second.StartedAt)
+	}
+	if second.Standings[0].TasksCount != 1 {
+		t.Errorf("Expected only the task completed during the second season to count, got %d", second.Standings[0].TasksCount)
+	}
+
+	seasons, _ := store.GetSeasons(guildID)
+	if len(seasons) != 2 || seasons[0].Name != "Summer" || len(seasons[1].Standings) != 1 || seasons[1].Standings[0].Username != "alice" {
+		t.Errorf("Expected seasons newest first with their champions, got %+v", seasons)
+	}
+}
+
+func TestRevokeAfterArchive(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	alice, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	admin, _ := store.GetOrCreateUser("user-2", guildID, "admin")
+
+	period, _ := store.CreateFocusPeriod(alice.ID, guildID)
+	store.AddTask(period.ID, "Launch the pricing page", "", estimator.Estimate{Points: 8}, nil)
+	store.AddTask(period.ID, "Write the launch post", "", estimator.Estimate{Points: 4}, nil)
+	store.AwardTaskCompletion(period, 1)
+	store.AwardTaskCompletion(period, 2)
+	if _, err := store.ArchiveSeason(guildID, "Spring", admin.ID); err != nil {
+		t.Fatalf("Failed to archive season: %v", err)
+	}
+	store.AddTask(period.ID, "Interview five customers", "", estimator.Estimate{Points: 6}, nil)
+	store.AwardTaskCompletion(period, 3)
+
+	memberPoints := func() int {
+		t.Helper()
+		member, err := store.GetGuildMember(alice.ID, guildID)
+		if err != nil {
+			t.Fatalf("Failed to get member: %v", err)
+		}
+		return member.TotalPoints
+	}
+
+	// Points from the archived season were already reset, so the new season keeps its own
+	if _, lost, err := store.ReopenTask(period, 1); err != nil || lost != 0 {
+		t.Errorf("Expected reopening an archived completion to take nothing back, got %d (%v)", lost, err)
+	}
+	if _, err := store.DeleteTask(period, 2); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+	if got := memberPoints(); got != 6 {
+		t.Errorf("Expected this season's 6 points to be kept, got %d", got)
+	}
+
+	// Completions from this season are still taken back
+	if _, lost, err := store.ReopenTask(period, 2); err != nil || lost != 6 {
+		t.Errorf("Expected reopening this season's completion to take back 6 points, got %d (%v)", lost, err)
+	}
+	if got := memberPoints(); got != 0 {
+		t.Errorf("Expected no points left, got %d", got)
+	}
+	if corrected, _ := store.ReconcilePoints(); corrected != 0 {
+		t.Errorf("Expected totals to match the ledger, got %d corrected", corrected)
+	}
+}
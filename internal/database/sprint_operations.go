@@ -0,0 +1,230 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNoCohortSprint is returned when a guild in cohort mode has no sprint running
+var ErrNoCohortSprint = errors.New("no cohort sprint is running")
+
+// CohortSprintRecap summarizes how a cohort sprint went
+type CohortSprintRecap struct {
+	Participants   int
+	GoalsSet       int
+	GoalsCompleted int
+	Points         int
+}
+
+// SetCohortSprints turns cohort mode on or off for a guild
+func (s *Store) SetCohortSprints(guildID string, enabled bool) error {
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	config.CohortSprints = enabled
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update cohort mode: %w", err)
+	}
+
+	return nil
+}
+
+// CreateCohortSprint schedules a guild-wide sprint starting at midnight on the start date
+// in the guild's timezone. Sprints in a guild may not overlap.
+func (s *Store) CreateCohortSprint(guildID, name string, startDate time.Time, days int) (*CohortSprint, error) {
+	if days < MinFocusPeriodDays || days > MaxFocusPeriodDays {
+		return nil, fmt.Errorf("sprint length must be between %d and %d days, got %d", MinFocusPeriodDays, MaxFocusPeriodDays, days)
+	}
+
+	start := StartOfDay(startDate.In(s.GuildLocation(guildID)))
+	sprint := CohortSprint{
+		GuildID:   guildID,
+		Name:      name,
+		StartDate: start.Local(),
+		EndDate:   start.AddDate(0, 0, days).Local(),
+	}
+
+	var overlapping int64
+	s.db.Model(&CohortSprint{}).
+		Where("guild_id = ? AND start_date < ? AND end_date > ?", guildID, sprint.EndDate, sprint.StartDate).
+		Count(&overlapping)
+	if overlapping > 0 {
+		return nil, fmt.Errorf("sprint overlaps an existing sprint")
+	}
+
+	if err := s.db.Create(&sprint).Error; err != nil {
+		return nil, fmt.Errorf("failed to create cohort sprint: %w", err)
+	}
+
+	return &sprint, nil
+}
+
+// GetCurrentCohortSprint returns the guild's sprint running at the given time, or nil if none is
+func (s *Store) GetCurrentCohortSprint(guildID string, now time.Time) (*CohortSprint, error) {
+	var sprint CohortSprint
+	now = now.Local()
+	result := s.db.Where("guild_id = ? AND start_date <= ? AND end_date > ?", guildID, now, now).First(&sprint)
+
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch cohort sprint: %w", result.Error)
+	}
+
+	return &sprint, nil
+}
+
+// GetNextCohortSprint returns the guild's next sprint to start after the given time, or nil if none is scheduled
+func (s *Store) GetNextCohortSprint(guildID string, now time.Time) (*CohortSprint, error) {
+	var sprint CohortSprint
+	result := s.db.Where("guild_id = ? AND start_date > ?", guildID, now.Local()).Order("start_date ASC").First(&sprint)
+
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch next cohort sprint: %w", result.Error)
+	}
+
+	return &sprint, nil
+}
+
+// GetUpcomingCohortSprints returns the guild's running and scheduled sprints in start order
+func (s *Store) GetUpcomingCohortSprints(guildID string) ([]CohortSprint, error) {
+	var sprints []CohortSprint
+	result := s.db.Where("guild_id = ? AND end_date > ?", guildID, time.Now()).Order("start_date ASC").Find(&sprints)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch cohort sprints: %w", result.Error)
+	}
+
+	return sprints, nil
+}
+
+// DeleteCohortSprint removes a sprint that has not started yet
+func (s *Store) DeleteCohortSprint(guildID string, sprintID uint) error {
+	result := s.db.Where("id = ? AND guild_id = ? AND start_date > ?", sprintID, guildID, time.Now()).Delete(&CohortSprint{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete cohort sprint: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("no upcoming sprint with ID %d", sprintID)
+	}
+
+	return nil
+}
+
+// GetEndedCohortSprintsNeedingRecap gets a guild's sprints that ended without a recap being posted
+func (s *Store) GetEndedCohortSprintsNeedingRecap(guildID string) ([]CohortSprint, error) {
+	var sprints []CohortSprint
+	result := s.db.Where("guild_id = ? AND end_date <= ? AND recap_posted = ?", guildID, time.Now(), false).
+		Order("end_date ASC").
+		Find(&sprints)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch ended cohort sprints: %w", result.Error)
+	}
+
+	return sprints, nil
+}
+
+// ClaimCohortSprintRecap marks a sprint's recap as posted.
+// It reports false if the recap was already claimed, so each recap is posted at most once.
+func (s *Store) ClaimCohortSprintRecap(sprintID uint) (bool, error) {
+	result := s.db.Model(&CohortSprint{}).
+		Where("id = ? AND recap_posted = ?", sprintID, false).
+		Update("recap_posted", true)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim cohort sprint recap: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	// The cohort recap covers its members' periods, so they get no leaderboard of their own
+	if err := s.db.Model(&FocusPeriod{}).Where("sprint_id = ?", sprintID).Update("leaderboard_posted", true).Error; err != nil {
+		return true, fmt.Errorf("failed to mark cohort periods as posted: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetCohortSprintLeaderboard ranks the members of a cohort sprint
+func (s *Store) GetCohortSprintLeaderboard(guildID string, sprintID uint, limit int) ([]LeaderboardEntry, error) {
+	return s.sprintLeaderboard("sp.focus_period_id IN (SELECT id FROM focus_periods WHERE sprint_id = ?)", []interface{}{sprintID}, guildID, limit)
+}
+
+// GetCohortSprintRecap totals participation, goals and points for a cohort sprint
+func (s *Store) GetCohortSprintRecap(sprintID uint) (*CohortSprintRecap, error) {
+	var recap CohortSprintRecap
+
+	result := s.db.Raw(`
+		SELECT
+			COUNT(DISTINCT fp.user_id) as participants,
+			COUNT(t.id) as goals_set,
+			COALESCE(SUM(CASE WHEN t.completed = ? THEN 1 ELSE 0 END), 0) as goals_completed
+		FROM focus_periods fp
+		LEFT JOIN tasks t ON t.focus_period_id = fp.id AND t.deleted_at IS NULL
+		WHERE fp.sprint_id = ? AND fp.deleted_at IS NULL
+	`, true, sprintID).Scan(&recap)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch cohort sprint recap: %w", result.Error)
+	}
+
+	result = s.db.Raw(`
+		SELECT COALESCE(SUM(sp.points), 0)
+		FROM sprint_points sp
+		JOIN focus_periods fp ON fp.id = sp.focus_period_id
+		WHERE fp.sprint_id = ?
+	`, sprintID).Scan(&recap.Points)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch cohort sprint points: %w", result.Error)
+	}
+
+	return &recap, nil
+}
+
+// GetGuildsWithEndedSprints returns guilds with focus periods or cohort sprints that ended
+// without a leaderboard. Guilds whose members all finished together have no active periods left.
+func (s *Store) GetGuildsWithEndedSprints() ([]string, error) {
+	now := time.Now()
+
+	var periodGuilds []string
+	result := s.db.Model(&FocusPeriod{}).
+		Distinct("guild_id").
+		Where("end_date < ? AND leaderboard_posted = ?", now, false).
+		Pluck("guild_id", &periodGuilds)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch guilds with ended periods: %w", result.Error)
+	}
+
+	var sprintGuilds []string
+	result = s.db.Model(&CohortSprint{}).
+		Distinct("guild_id").
+		Where("end_date <= ? AND recap_posted = ?", now, false).
+		Pluck("guild_id", &sprintGuilds)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch guilds with ended sprints: %w", result.Error)
+	}
+
+	seen := make(map[string]bool)
+	var guildIDs []string
+	for _, guildID := range append(periodGuilds, sprintGuilds...) {
+		if !seen[guildID] {
+			seen[guildID] = true
+			guildIDs = append(guildIDs, guildID)
+		}
+	}
+
+	return guildIDs, nil
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+)
+
+func setupSprintTestDB(t *testing.T) *Store {
+	t.Helper()
+	store := setupTestDB(t)
+	if err := store.db.AutoMigrate(&CohortSprint{}); err != nil {
+		t.Fatalf("Failed to migrate cohort sprints: %v", err)
+	}
+	return store
+}
+
+func TestCohortModeJoinsCurrentSprint(t *testing.T) {
+	store := setupSprintTestDB(t)
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+
+	if err := store.SetCohortSprints(guildID, true); err != nil {
+		t.Fatalf("Failed to enable cohort mode: %v", err)
+	}
+	if _, err := store.CreateFocusPeriod(user.ID, guildID); !errors.Is(err, ErrNoCohortSprint) {
+		t.Fatalf("Expected ErrNoCohortSprint without a running sprint, got %v", err)
+	}
+
+	sprint, err := store.CreateCohortSprint(guildID, "March Sprint", time.Now().AddDate(0, 0, -3), 7)
+	if err != nil {
+		t.Fatalf("Failed to create sprint: %v", err)
+	}
+	if _, err := store.CreateCohortSprint(guildID, "Overlap", time.Now(), 7); err == nil {
+		t.Error("Expected an overlapping sprint to be rejected")
+	}
+
+	period, err := store.CreateFocusPeriod(user.ID, guildID)
+	if err != nil {
+		t.Fatalf("Failed to join sprint: %v", err)
+	}
+	if period.SprintID == nil || *period.SprintID != sprint.ID {
+		t.Fatalf("Expected the period to join sprint %d, got %v", sprint.ID, period.SprintID)
+	}
+	if !period.StartDate.Equal(sprint.StartDate) || !period.EndDate.Equal(sprint.EndDate) {
+		t.Errorf("Expected the period to share the sprint's dates, got %v - %v", period.StartDate, period.EndDate)
+	}
+	if day := period.DayNumber(); day != 4 {
+		t.Errorf("Expected late joiners to be on the sprint's day 4, got %d", day)
+	}
+}
+
+func TestCohortSprintLeaderboardAndRecap(t *testing.T) {
+	store := setupSprintTestDB(t)
+	guildID := "test-guild-123"
+	alice, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	bob, _ := store.GetOrCreateUser("user-2", guildID, "bob")
+
+	store.SetCohortSprints(guildID, true)
+	sprint, _ := store.CreateCohortSprint(guildID, "March Sprint", time.Now(), 14)
+	period, _ := store.CreateFocusPeriod(alice.ID, guildID)
+	store.AddTask(period.ID, "Ship onboarding", "", estimator.Estimate{Points: 10}, nil)
+	store.AddPointsToUser(alice.ID, period.ID, 10, guildID, period.StartDate, period.EndDate)
+
+	// A solo period overlapping the sprint stays off the cohort leaderboard
+	solo := FocusPeriod{UserID: bob.ID, GuildID: guildID, StartDate: time.Now().AddDate(0, 0, -1), EndDate: time.Now().AddDate(0, 0, 13)}
+	store.db.Create(&solo)
+	store.AddPointsToUser(bob.ID, solo.ID, 50, guildID, solo.StartDate, solo.EndDate)
+
+	entries, err := store.GetSprintLeaderboard(guildID, 10)
+	if err != nil {
+		t.Fatalf("Failed to get sprint leaderboard: %v", err)
+	}
+	if len(entries) != 1 || entries[0].DiscordID != "user-1" {
+		t.Errorf("Expected only the cohort member on the leaderboard, got %+v", entries)
+	}
+
+	recap, err := store.GetCohortSprintRecap(sprint.ID)
+	if err != nil {
+		t.Fatalf("Failed to get recap: %v", err)
+	}
+	if recap.Participants != 1 || recap.GoalsSet != 1 || recap.GoalsCompleted != 0 || recap.Points != 10 {
+		t.Errorf("Unexpected recap %+v", recap)
+	}
+
+	claimed, err := store.ClaimCohortSprintRecap(sprint.ID)
+	if err != nil || !claimed {
+		t.Fatalf("Expected the first claim to succeed, got %v, %v", claimed, err)
+	}
+	if claimed, _ := store.ClaimCohortSprintRecap(sprint.ID); claimed {
+		t.Error("Expected the recap to be claimed only once")
+	}
+}
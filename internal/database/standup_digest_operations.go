@@ -0,0 +1,90 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// StandupWeek is a guild's standups over a week, with what's needed to judge participation
+type StandupWeek struct {
+	Standups   []Standup // Ordered by member, then date
+	Members    int       // Members who have ever posted a standup in the guild
+	Posters    int       // Members who posted during the week
+	ActiveDays int       // Days of the week that aren't rest days
+}
+
+// Participation returns the share of expected standups that were posted, from 0 to 1.
+// Every member is expected to post on each active day; standups on rest days count as a bonus.
+func (w *StandupWeek) Participation() float64 {
+	expected := w.Members * w.ActiveDays
+	if expected == 0 {
+		return 0
+	}
+	return min(float64(len(w.Standups))/float64(expected), 1)
+}
+
+// UpdateStandupDigestChannel sets the channel the weekly standup digest is posted to, or "" to use the reminder channel
+func (s *Store) UpdateStandupDigestChannel(guildID, channelID string) error {
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	config.StandupDigestChannel = channelID
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update standup digest channel: %w", err)
+	}
+
+	return nil
+}
+
+// GetGuildsWithStandupsSince returns the IDs of guilds with a standup posted since a time
+func (s *Store) GetGuildsWithStandupsSince(since time.Time) ([]string, error) {
+	var guildIDs []string
+	result := s.db.Model(&Standup{}).Where("date >= ?", since.Local()).Distinct().Pluck("guild_id", &guildIDs)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch guilds with standups: %w", result.Error)
+	}
+
+	return guildIDs, nil
+}
+
+// GetStandupWeek returns a guild's standups posted from start up to end, usually the guild-local
+// midnights a week apart, along with how many members were expected to post on how many days
+func (s *Store) GetStandupWeek(guildID string, start, end time.Time) (*StandupWeek, error) {
+	config, err := s.GetGuildConfig(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	week := &StandupWeek{}
+	restDays := config.RestDays()
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !restDays[day.Weekday()] {
+			week.ActiveDays++
+		}
+	}
+
+	result := s.db.Preload("User").
+		Where("guild_id = ? AND date >= ? AND date < ?", guildID, start.Local(), end.Local()).
+		Order("user_id ASC, date ASC").
+		Find(&week.Standups)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch standups: %w", result.Error)
+	}
+
+	var members int64
+	if err := s.db.Model(&UserStreak{}).Where("guild_id = ? AND total_standups > 0", guildID).Count(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to count standup members: %w", err)
+	}
+	week.Members = int(members)
+
+	posters := make(map[uint]bool)
+	for _, standup := range week.Standups {
+		posters[standup.UserID] = true
+	}
+	week.Posters = len(posters)
+
+	return week, nil
+}
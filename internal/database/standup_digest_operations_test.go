@@ -0,0 +1,50 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetStandupWeek(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	alice, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	bob, _ := store.GetOrCreateUser("user-2", guildID, "bob")
+	if err := store.UpdateStandupRestDays(guildID, []time.Weekday{time.Saturday, time.Sunday}); err != nil {
+		t.Fatalf("Failed to set rest days: %v", err)
+	}
+
+	if _, _, _, err := store.CreateStandup(alice.ID, guildID, "Pricing page", "Shipped billing", ""); err != nil {
+		t.Fatalf("Failed to create standup: %v", err)
+	}
+	if _, _, _, err := store.CreateStandup(bob.ID, guildID, "Landing page", "", ""); err != nil {
+		t.Fatalf("Failed to create standup: %v", err)
+	}
+	// Move bob's standup back to last week
+	lastWeek := time.Now().AddDate(0, 0, -8)
+	store.db.Model(&Standup{}).Where("user_id = ?", bob.ID).Update("date", lastWeek)
+
+	end := StartOfDay(time.Now()).AddDate(0, 0, 1)
+	start := end.AddDate(0, 0, -7)
+	week, err := store.GetStandupWeek(guildID, start, end)
+	if err != nil {
+		t.Fatalf("Failed to fetch standup week: %v", err)
+	}
+	if len(week.Standups) != 1 || week.Standups[0].User.Username != "alice" {
+		t.Fatalf("Expected only alice's standup this week, got %+v", week.Standups)
+	}
+	if week.Members != 2 || week.Posters != 1 || week.ActiveDays != 5 {
+		t.Errorf("Expected 2 members, 1 poster and 5 active days, got %+v", week)
+	}
+	if participation := week.Participation(); participation != 0.1 {
+		t.Errorf("Expected 10%% participation, got %v", participation)
+	}
+
+	guilds, err := store.GetGuildsWithStandupsSince(start)
+	if err != nil || len(guilds) != 1 || guilds[0] != guildID {
+		t.Errorf("Expected the guild to have standups this week, got %v (%v)", guilds, err)
+	}
+	if guilds, _ := store.GetGuildsWithStandupsSince(end); len(guilds) != 0 {
+		t.Errorf("Expected no standups after the week, got %v", guilds)
+	}
+}
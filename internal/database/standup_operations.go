@@ -2,28 +2,185 @@ package database
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
-// CreateStandup creates a new standup entry and updates streak
-func CreateStandup(userID uint, guildID, workingOn, accomplished, blockers string) (*Standup, *UserStreak, int, error) {
+// Streak freeze rules
+const (
+	MaxFreezeTokens    = 3  // Most freezes a member can hold at once
+	FreezeEarnInterval = 7  // A freeze is earned every this many days of streak
+	FreezeTokenCost    = 25 // Points a freeze costs to buy
+)
+
+// weekdayNames maps the names and abbreviations accepted for rest days to weekdays
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseRestDays parses comma-separated weekdays such as "sat, sun".
+// The result is sorted from Sunday with duplicates removed; "" and "none" parse to nil.
+func ParseRestDays(value string) ([]time.Weekday, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.EqualFold(value, "none") {
+		return nil, nil
+	}
+
+	seen := make(map[time.Weekday]bool)
+	var days []time.Weekday
+	for _, part := range strings.Split(value, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		day, ok := weekdayNames[name]
+		if !ok {
+			return nil, fmt.Errorf("%q is not a day of the week", strings.TrimSpace(part))
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	if len(days) == 7 {
+		return nil, fmt.Errorf("at least one day a week must count toward streaks")
+	}
+
+	sort.Slice(days, func(a, b int) bool { return days[a] < days[b] })
+	return days, nil
+}
+
+// RestDays returns the weekdays that don't count against standup streaks in the guild
+func (c *GuildConfig) RestDays() map[time.Weekday]bool {
+	days, _ := ParseRestDays(c.StandupRestDays)
+	rest := make(map[time.Weekday]bool, len(days))
+	for _, day := range days {
+		rest[day] = true
+	}
+	return rest
+}
+
+// UpdateStandupRestDays sets the weekdays that don't count against standup streaks. Nil days clears them.
+func (s *Store) UpdateStandupRestDays(guildID string, days []time.Weekday) error {
+	if len(days) >= 7 {
+		return fmt.Errorf("at least one day a week must count toward streaks")
+	}
+	names := make([]string, 0, len(days))
+	for _, day := range days {
+		names = append(names, strings.ToLower(day.String()[:3]))
+	}
+
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	config.StandupRestDays = strings.Join(names, ",")
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update rest days: %w", err)
+	}
+
+	return nil
+}
+
+// missedStreakDays counts the days after last and before today that aren't rest days.
+// Both are the starts of days in the same location.
+func missedStreakDays(last, today time.Time, restDays map[time.Weekday]bool) int {
+	missed := 0
+	for day := last.AddDate(0, 0, 1); day.Before(today); day = day.AddDate(0, 0, 1) {
+		if !restDays[day.Weekday()] {
+			missed++
+		}
+	}
+	return missed
+}
+
+// MissedDays returns how many days the streak has missed since its last standup, not counting today or rest days.
+// The streak survives a standup today if freezes cover them all.
+func (streak *UserStreak) MissedDays(local time.Time, restDays map[time.Weekday]bool) int {
+	if streak.LastStandupDate == nil {
+		return 0
+	}
+	today := StartOfDay(local)
+	last := StartOfDay(streak.LastStandupDate.In(local.Location()))
+	if !last.Before(today) {
+		return 0
+	}
+	return missedStreakDays(last, today, restDays)
+}
+
+// recordStandup advances the streak for a standup posted at a time, counting days in loc
+func (streak *UserStreak) recordStandup(at time.Time, loc *time.Location, restDays map[time.Weekday]bool) {
+	streak.FrozenDays = 0
+	streak.EarnedFreeze = false
+
+	if streak.LastStandupDate != nil {
+		missed := streak.MissedDays(at.In(loc), restDays)
+		switch {
+		case missed == 0:
+			// Consecutive day, or only rest days since - increment streak
+			streak.CurrentStreak++
+		case missed <= streak.FreezeTokens:
+			// Freezes cover the missed days - keep the streak going
+			streak.FreezeTokens -= missed
+			streak.FreezesUsed += missed
+			streak.FrozenDays = missed
+			streak.CurrentStreak++
+		default:
+			// Streak broken - reset to 1
+			streak.CurrentStreak = 1
+		}
+	} else {
+		// First standup ever
+		streak.CurrentStreak = 1
+	}
+
+	// Every few days of streak earns a freeze
+	if streak.CurrentStreak%FreezeEarnInterval == 0 && streak.FreezeTokens < MaxFreezeTokens {
+		streak.FreezeTokens++
+		streak.EarnedFreeze = true
+	}
+
+	// Update longest streak if needed
+	if streak.CurrentStreak > streak.LongestStreak {
+		streak.LongestStreak = streak.CurrentStreak
+	}
+
+	streak.TotalStandups++
+	streak.LastStandupDate = &at
+}
+
+// CreateStandup creates a new standup entry and updates streak.
+// Days are counted in the user's timezone. The guild's rest days don't break a streak,
+// and other missed days are covered by the member's streak freezes when they hold enough.
+func (s *Store) CreateStandup(userID uint, guildID, workingOn, accomplished, blockers string) (*Standup, *UserStreak, int, error) {
 	today := time.Now()
-	todayStart := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+	local := today.In(s.UserLocation(userID, guildID))
 
-	// Check if user already posted today
-	var existingStandup Standup
-	result := DB.Where("user_id = ? AND guild_id = ? AND date >= ?", userID, guildID, todayStart).First(&existingStandup)
-	if result.Error == nil {
+	posted, err := s.HasPostedStandupToday(userID, guildID)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if posted {
 		return nil, nil, 0, fmt.Errorf("you've already posted a standup today")
 	}
 
+	config, err := s.GetGuildConfig(guildID)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
 	var standup *Standup
 	var streak *UserStreak
 	var bonusPoints int
 
-	err := DB.Transaction(func(tx *gorm.DB) error {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
 		// Create standup
 		standup = &Standup{
 			UserID:       userID,
@@ -36,6 +193,9 @@ func CreateStandup(userID uint, guildID, workingOn, accomplished, blockers strin
 		if err := tx.Create(standup).Error; err != nil {
 			return fmt.Errorf("failed to create standup: %w", err)
 		}
+		if err := trackBlocker(tx, standup); err != nil {
+			return err
+		}
 
 		// Get or create streak
 		streak = &UserStreak{}
@@ -56,30 +216,7 @@ func CreateStandup(userID uint, guildID, workingOn, accomplished, blockers strin
 			return fmt.Errorf("failed to fetch streak: %w", result.Error)
 		}
 
-		// Update streak
-		yesterday := todayStart.Add(-24 * time.Hour)
-		if streak.LastStandupDate != nil {
-			lastDate := time.Date(streak.LastStandupDate.Year(), streak.LastStandupDate.Month(), streak.LastStandupDate.Day(), 0, 0, 0, 0, streak.LastStandupDate.Location())
-			if lastDate.Equal(yesterday) {
-				// Consecutive day - increment streak
-				streak.CurrentStreak++
-			} else if lastDate.Before(yesterday) {
-				// Streak broken - reset to 1
-				streak.CurrentStreak = 1
-			}
-			// Same day means no change (handled by the "already posted" check above)
-		} else {
-			// First standup ever
-			streak.CurrentStreak = 1
-		}
-
-		// Update longest streak if needed
-		if streak.CurrentStreak > streak.LongestStreak {
-			streak.LongestStreak = streak.CurrentStreak
-		}
-
-		streak.TotalStandups++
-		streak.LastStandupDate = &today
+		streak.recordStandup(today, local.Location(), config.RestDays())
 
 		// Check for streak milestone bonus
 		if bonus, exists := StreakMilestones[streak.CurrentStreak]; exists {
@@ -92,13 +229,12 @@ func CreateStandup(userID uint, guildID, workingOn, accomplished, blockers strin
 
 		// Award base points (1 point per standup) + bonus
 		totalPoints := 1 + bonusPoints
-		var user User
-		if err := tx.Where("id = ?", userID).First(&user).Error; err != nil {
-			return fmt.Errorf("failed to fetch user: %w", err)
+		source := PointsSource{Type: PointsSourceStandup, ID: standup.ID}
+		if bonusPoints > 0 {
+			source.Note = fmt.Sprintf("%d-day streak bonus", streak.CurrentStreak)
 		}
-		user.TotalPoints += totalPoints
-		if err := tx.Save(&user).Error; err != nil {
-			return fmt.Errorf("failed to update user points: %w", err)
+		if err := addMemberPoints(tx, userID, guildID, totalPoints, source); err != nil {
+			return err
 		}
 
 		return nil
@@ -112,9 +248,9 @@ func CreateStandup(userID uint, guildID, workingOn, accomplished, blockers strin
 }
 
 // GetUserStreak gets the streak info for a user
-func GetUserStreak(userID uint, guildID string) (*UserStreak, error) {
+func (s *Store) GetUserStreak(userID uint, guildID string) (*UserStreak, error) {
 	var streak UserStreak
-	result := DB.Where("user_id = ? AND guild_id = ?", userID, guildID).First(&streak)
+	result := s.db.Where("user_id = ? AND guild_id = ?", userID, guildID).First(&streak)
 
 	if result.Error == gorm.ErrRecordNotFound {
 		// Return a default streak (user hasn't posted any standups yet)
@@ -145,18 +281,19 @@ type StreakLeaderboardEntry struct {
 }
 
 // GetStreakLeaderboard gets the streak leaderboard for a guild
-func GetStreakLeaderboard(guildID string, limit int) ([]StreakLeaderboardEntry, error) {
+func (s *Store) GetStreakLeaderboard(guildID string, limit int) ([]StreakLeaderboardEntry, error) {
 	var entries []StreakLeaderboardEntry
 
-	rows, err := DB.Raw(`
+	rows, err := s.db.Raw(`
 		SELECT
 			u.discord_id,
-			u.username,
+			gm.username,
 			us.current_streak,
 			us.longest_streak,
 			us.total_standups
 		FROM user_streaks us
 		JOIN users u ON u.id = us.user_id
+		JOIN guild_members gm ON gm.user_id = us.user_id AND gm.guild_id = us.guild_id
 		WHERE us.guild_id = ? AND us.total_standups > 0
 		ORDER BY us.current_streak DESC, us.total_standups DESC
 		LIMIT ?
@@ -182,11 +319,11 @@ func GetStreakLeaderboard(guildID string, limit int) ([]StreakLeaderboardEntry,
 }
 
 // GetUserStandups gets recent standups for a user
-func GetUserStandups(userID uint, guildID string, days int) ([]Standup, error) {
+func (s *Store) GetUserStandups(userID uint, guildID string, days int) ([]Standup, error) {
 	var standups []Standup
 	since := time.Now().AddDate(0, 0, -days)
 
-	result := DB.Where("user_id = ? AND guild_id = ? AND date >= ?", userID, guildID, since).
+	result := s.db.Where("user_id = ? AND guild_id = ? AND date >= ?", userID, guildID, since).
 		Order("date DESC").
 		Find(&standups)
 
@@ -198,12 +335,11 @@ func GetUserStandups(userID uint, guildID string, days int) ([]Standup, error) {
 }
 
 // HasPostedStandupToday checks if a user has posted a standup today
-func HasPostedStandupToday(userID uint, guildID string) (bool, error) {
-	today := time.Now()
-	todayStart := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+func (s *Store) HasPostedStandupToday(userID uint, guildID string) (bool, error) {
+	todayStart := StartOfDay(time.Now().In(s.UserLocation(userID, guildID)))
 
 	var count int64
-	result := DB.Model(&Standup{}).Where("user_id = ? AND guild_id = ? AND date >= ?", userID, guildID, todayStart).Count(&count)
+	result := s.db.Model(&Standup{}).Where("user_id = ? AND guild_id = ? AND date >= ?", userID, guildID, todayStart.Local()).Count(&count)
 	if result.Error != nil {
 		return false, fmt.Errorf("failed to check standup: %w", result.Error)
 	}
@@ -211,27 +347,135 @@ func HasPostedStandupToday(userID uint, guildID string) (bool, error) {
 	return count > 0, nil
 }
 
-// GetUsersWithoutStandupToday gets users who have active focus periods but haven't posted today
-func GetUsersWithoutStandupToday(guildID string) ([]User, error) {
-	today := time.Now()
-	todayStart := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+// GetUsersWithoutStandupToday gets users who have active focus periods but haven't posted today.
+// "Today" is each user's own calendar day.
+func (s *Store) GetUsersWithoutStandupToday(guildID string) ([]User, error) {
+	now := time.Now()
 
-	var users []User
-	result := DB.Raw(`
+	var candidates []User
+	result := s.db.Raw(`
 		SELECT DISTINCT u.*
 		FROM users u
 		JOIN focus_periods fp ON fp.user_id = u.id
-		WHERE u.guild_id = ?
+		WHERE fp.guild_id = ?
 		  AND fp.start_date <= ?
 		  AND fp.end_date >= ?
-		  AND u.id NOT IN (
-			SELECT user_id FROM standups WHERE guild_id = ? AND date >= ?
-		  )
-	`, guildID, today, today, guildID, todayStart).Scan(&users)
+	`, guildID, now, now).Scan(&candidates)
 
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to fetch users without standup: %w", result.Error)
 	}
 
+	guildLocation := s.GuildLocation(guildID)
+
+	var users []User
+	for _, user := range candidates {
+		todayStart := StartOfDay(now.In(resolveLocation(user.Timezone, guildLocation))).Local()
+
+		var count int64
+		if err := s.db.Model(&Standup{}).Where("user_id = ? AND guild_id = ? AND date >= ?", user.ID, guildID, todayStart).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to check standup: %w", err)
+		}
+		if count == 0 {
+			users = append(users, user)
+		}
+	}
+
 	return users, nil
 }
+
+// UpdateStandupThreadChannel sets the channel a guild's daily standup thread is opened in, or "" to stop opening them
+func (s *Store) UpdateStandupThreadChannel(guildID, channelID string) error {
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	config.StandupThreadChannel = channelID
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update standup thread channel: %w", err)
+	}
+
+	return nil
+}
+
+// GetGuildsWithStandupThreads returns the settings of every guild that opens a daily standup thread
+func (s *Store) GetGuildsWithStandupThreads() ([]GuildConfig, error) {
+	var configs []GuildConfig
+	result := s.db.Where("standup_thread_channel <> ''").Find(&configs)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch guilds with standup threads: %w", result.Error)
+	}
+
+	return configs, nil
+}
+
+// SaveStandupThread records a guild's standup thread for a day so replies in it can be posted as standups
+func (s *Store) SaveStandupThread(guildID, channelID, threadID, day string) (*StandupThread, error) {
+	thread := StandupThread{
+		GuildID:   guildID,
+		ChannelID: channelID,
+		ThreadID:  threadID,
+		Day:       day,
+	}
+	if err := s.db.Create(&thread).Error; err != nil {
+		return nil, fmt.Errorf("failed to save standup thread: %w", err)
+	}
+	return &thread, nil
+}
+
+// GetStandupThread returns the standup thread with a Discord thread ID, or nil if it isn't one
+func (s *Store) GetStandupThread(threadID string) (*StandupThread, error) {
+	var thread StandupThread
+	result := s.db.Where("thread_id = ?", threadID).Limit(1).Find(&thread)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch standup thread: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &thread, nil
+}
+
+// BuyStreakFreeze spends a member's points on a streak freeze
+func (s *Store) BuyStreakFreeze(userID uint, guildID string) (*UserStreak, error) {
+	var streak UserStreak
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND guild_id = ?", userID, guildID).First(&streak)
+		if result.Error == gorm.ErrRecordNotFound {
+			streak = UserStreak{UserID: userID, GuildID: guildID}
+			if err := tx.Create(&streak).Error; err != nil {
+				return fmt.Errorf("failed to create streak: %w", err)
+			}
+		} else if result.Error != nil {
+			return fmt.Errorf("failed to fetch streak: %w", result.Error)
+		}
+		if streak.FreezeTokens >= MaxFreezeTokens {
+			return fmt.Errorf("you already hold the most freezes (%d)", MaxFreezeTokens)
+		}
+
+		var member GuildMember
+		result = tx.Where("user_id = ? AND guild_id = ?", userID, guildID).First(&member)
+		if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
+			return fmt.Errorf("failed to fetch guild member: %w", result.Error)
+		}
+		if member.TotalPoints < FreezeTokenCost {
+			return fmt.Errorf("a freeze costs %d points and you have %d", FreezeTokenCost, member.TotalPoints)
+		}
+
+		streak.FreezeTokens++
+		if err := tx.Save(&streak).Error; err != nil {
+			return fmt.Errorf("failed to update streak: %w", err)
+		}
+		source := PointsSource{Type: PointsSourceStreakFreeze, ID: streak.ID}
+		return addMemberPoints(tx, userID, guildID, -FreezeTokenCost, source)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &streak, nil
+}
@@ -0,0 +1,122 @@
+package database
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRestDays(t *testing.T) {
+	days, err := ParseRestDays(" Sun, saturday,SAT ")
+	if err != nil || len(days) != 2 || days[0] != time.Sunday || days[1] != time.Saturday {
+		t.Errorf("Expected Sunday and Saturday, got %v (%v)", days, err)
+	}
+	if days, err := ParseRestDays("none"); err != nil || days != nil {
+		t.Errorf("Expected no rest days, got %v (%v)", days, err)
+	}
+	if _, err := ParseRestDays("sat,someday"); err == nil {
+		t.Error("Expected an unknown weekday to be rejected")
+	}
+	if _, err := ParseRestDays("mon,tue,wed,thu,fri,sat,sun"); err == nil {
+		t.Error("Expected a week of rest days to be rejected")
+	}
+}
+
+func TestMissedDays(t *testing.T) {
+	thursday := time.Date(2026, 3, 12, 18, 0, 0, 0, time.UTC)
+	streak := &UserStreak{LastStandupDate: &thursday}
+	monday := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+
+	if missed := streak.MissedDays(monday, nil); missed != 3 {
+		t.Errorf("Expected Friday to Sunday missed, got %d", missed)
+	}
+	weekends := map[time.Weekday]bool{time.Saturday: true, time.Sunday: true}
+	if missed := streak.MissedDays(monday, weekends); missed != 1 {
+		t.Errorf("Expected only Friday missed with weekends off, got %d", missed)
+	}
+	if missed := streak.MissedDays(thursday.Add(time.Hour), nil); missed != 0 {
+		t.Errorf("Expected nothing missed on the same day, got %d", missed)
+	}
+}
+
+func TestStreakRestDaysAndFreezes(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	now := time.Now()
+
+	// seed gives a member a streak last extended daysAgo days ago
+	seed := func(discordID string, current, freezes, daysAgo int) *User {
+		user, _ := store.GetOrCreateUser(discordID, guildID, discordID)
+		last := now.AddDate(0, 0, -daysAgo)
+		store.db.Create(&UserStreak{UserID: user.ID, GuildID: guildID, CurrentStreak: current, LongestStreak: current, FreezeTokens: freezes, LastStandupDate: &last})
+		return user
+	}
+
+	// Missing two days with two freezes keeps the streak and uses them up
+	frozen := seed("user-frozen", 10, 2, 3)
+	_, streak, _, err := store.CreateStandup(frozen.ID, guildID, "Pricing page", "", "")
+	if err != nil {
+		t.Fatalf("Failed to create standup: %v", err)
+	}
+	if streak.CurrentStreak != 11 || streak.FreezeTokens != 0 || streak.FrozenDays != 2 || streak.FreezesUsed != 2 {
+		t.Errorf("Expected freezes to carry the streak to 11, got %+v", streak)
+	}
+
+	// Too few freezes and the streak resets, keeping the freeze
+	broken := seed("user-broken", 10, 1, 3)
+	_, streak, _, _ = store.CreateStandup(broken.ID, guildID, "Pricing page", "", "")
+	if streak.CurrentStreak != 1 || streak.FreezeTokens != 1 || streak.FrozenDays != 0 {
+		t.Errorf("Expected the streak to reset without using the freeze, got %+v", streak)
+	}
+
+	// Rest days don't count as missed
+	rested := seed("user-rested", 10, 0, 3)
+	restDays := []time.Weekday{now.AddDate(0, 0, -1).Weekday(), now.AddDate(0, 0, -2).Weekday()}
+	if err := store.UpdateStandupRestDays(guildID, restDays); err != nil {
+		t.Fatalf("Failed to set rest days: %v", err)
+	}
+	_, streak, _, _ = store.CreateStandup(rested.ID, guildID, "Pricing page", "", "")
+	if streak.CurrentStreak != 11 || streak.FrozenDays != 0 {
+		t.Errorf("Expected rest days to keep the streak, got %+v", streak)
+	}
+
+	// Every week of streak earns a freeze
+	earner := seed("user-earner", 6, 0, 1)
+	_, streak, bonus, _ := store.CreateStandup(earner.ID, guildID, "Pricing page", "", "")
+	if streak.CurrentStreak != 7 || streak.FreezeTokens != 1 || !streak.EarnedFreeze || bonus != StreakMilestones[7] {
+		t.Errorf("Expected a freeze and bonus at 7 days, got %+v (bonus %d)", streak, bonus)
+	}
+	if saved, _ := store.GetUserStreak(earner.ID, guildID); saved.FreezeTokens != 1 {
+		t.Errorf("Expected the earned freeze to be saved, got %d", saved.FreezeTokens)
+	}
+}
+
+func TestBuyStreakFreeze(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+
+	if _, err := store.BuyStreakFreeze(user.ID, guildID); err == nil || !strings.Contains(err.Error(), "costs") {
+		t.Fatalf("Expected a freeze to need points, got %v", err)
+	}
+
+	store.AdjustPoints(user.ID, guildID, 100, user.ID, "Seed points")
+	for range MaxFreezeTokens {
+		if _, err := store.BuyStreakFreeze(user.ID, guildID); err != nil {
+			t.Fatalf("Failed to buy freeze: %v", err)
+		}
+	}
+	if _, err := store.BuyStreakFreeze(user.ID, guildID); err == nil {
+		t.Error("Expected buying past the most freezes to fail")
+	}
+
+	streak, _ := store.GetUserStreak(user.ID, guildID)
+	member, _ := store.GetGuildMember(user.ID, guildID)
+	if streak.FreezeTokens != MaxFreezeTokens || member.TotalPoints != 100-MaxFreezeTokens*FreezeTokenCost {
+		t.Errorf("Expected %d freezes bought with points, got %d freezes and %d points", MaxFreezeTokens, streak.FreezeTokens, member.TotalPoints)
+	}
+	history, _ := store.GetPointsHistory(user.ID, guildID, 1)
+	if len(history) != 1 || history[0].SourceType != PointsSourceStreakFreeze || history[0].Amount != -FreezeTokenCost {
+		t.Errorf("Expected the purchase in the ledger, got %+v", history)
+	}
+}
@@ -0,0 +1,203 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaxStepsPerTask caps how many steps a single task can be broken into
+const MaxStepsPerTask = 20
+
+// SetStepPointsEnabled turns partial points for checked steps on or off for a guild.
+// Points already credited for steps are kept.
+func (s *Store) SetStepPointsEnabled(guildID string, enabled bool) error {
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	config.StepPointsEnabled = enabled
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update step points: %w", err)
+	}
+
+	return nil
+}
+
+// CreditedPoints returns the points a task has earned so far: all of them once completed
+// apart from any withheld by guardrails, otherwise the share credited for checked steps
+func (t *Task) CreditedPoints() int {
+	if t.Completed {
+		return t.Points - t.WithheldPoints
+	}
+	return t.StepPoints
+}
+
+// stepShare returns the share of a task's points its checked steps are worth
+func stepShare(task *Task) int {
+	if len(task.Steps) == 0 {
+		return 0
+	}
+	return task.Points * task.CompletedStepCount() / len(task.Steps)
+}
+
+// orderedSteps loads a task's steps in position order
+func orderedSteps(tx *gorm.DB, taskID uint) ([]TaskStep, error) {
+	var steps []TaskStep
+	if err := tx.Where("task_id = ?", taskID).Order("position ASC").Find(&steps).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch steps: %w", err)
+	}
+	return steps, nil
+}
+
+// syncStepPoints credits or takes back points so a pending task's StepPoints matches its checked steps.
+// Nothing is credited for steps unless the guild has step points switched on.
+// It returns the change in the member's points.
+func syncStepPoints(tx *gorm.DB, config *GuildConfig, period *FocusPeriod, task *Task) (int, error) {
+	if task.Completed {
+		return 0, nil
+	}
+
+	target := 0
+	if config.StepPointsEnabled {
+		target = stepShare(task)
+	}
+	return setStepPoints(tx, config, period, task, target)
+}
+
+// setStepPoints credits or takes back points so a task's StepPoints becomes target, in the given period.
+// Steps earn points under the same period cap as completions, so an increase may be credited only in part.
+// It returns the change in the member's points.
+func setStepPoints(tx *gorm.DB, config *GuildConfig, period *FocusPeriod, task *Task, target int) (int, error) {
+	delta := target - task.StepPoints
+	if delta > 0 {
+		var err error
+		if delta, err = capPeriodPoints(tx, config, period, delta); err != nil {
+			return 0, err
+		}
+		target = task.StepPoints + delta
+	}
+	if delta == 0 {
+		return 0, nil
+	}
+
+	source := PointsSource{Type: PointsSourceTaskSteps, ID: task.ID, Note: task.Title}
+	if err := addMemberPoints(tx, period.UserID, period.GuildID, delta, source); err != nil {
+		return 0, err
+	}
+	if err := addSprintPoints(tx, period.UserID, period.ID, delta, period.GuildID, period.StartDate, period.EndDate); err != nil {
+		return 0, err
+	}
+	if err := tx.Model(task).Update("step_points", target).Error; err != nil {
+		return 0, fmt.Errorf("failed to update step points: %w", err)
+	}
+	task.StepPoints = target
+
+	return delta, nil
+}
+
+// changeTaskSteps runs a change to a task's steps, then reloads them and resyncs the task's step points.
+// It returns the task with its steps and the change in the member's points.
+func (s *Store) changeTaskSteps(period *FocusPeriod, position int, change func(tx *gorm.DB, task *Task) error) (*Task, int, error) {
+	config, err := s.GetGuildConfig(period.GuildID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var task *Task
+	var pointsChange int
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		task, err = findTask(tx, period.ID, position)
+		if err != nil {
+			return err
+		}
+		if task.Steps, err = orderedSteps(tx, task.ID); err != nil {
+			return err
+		}
+
+		if err := change(tx, task); err != nil {
+			return err
+		}
+
+		if task.Steps, err = orderedSteps(tx, task.ID); err != nil {
+			return err
+		}
+		pointsChange, err = syncStepPoints(tx, config, period, task)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return task, pointsChange, nil
+}
+
+// AddTaskStep adds a step to the end of a task's checklist
+func (s *Store) AddTaskStep(period *FocusPeriod, position int, title string) (*Task, int, error) {
+	return s.changeTaskSteps(period, position, func(tx *gorm.DB, task *Task) error {
+		if len(task.Steps) >= MaxStepsPerTask {
+			return fmt.Errorf("task #%d already has %d steps", position, MaxStepsPerTask)
+		}
+
+		step := TaskStep{
+			TaskID:   task.ID,
+			Title:    title,
+			Position: len(task.Steps) + 1,
+		}
+		if err := tx.Create(&step).Error; err != nil {
+			return fmt.Errorf("failed to create step: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetTaskStepCompleted checks or unchecks one of a task's steps
+func (s *Store) SetTaskStepCompleted(period *FocusPeriod, position, stepNumber int, completed bool) (*Task, int, error) {
+	return s.changeTaskSteps(period, position, func(tx *gorm.DB, task *Task) error {
+		if stepNumber < 1 || stepNumber > len(task.Steps) {
+			return fmt.Errorf("step %d not found on task #%d", stepNumber, position)
+		}
+
+		step := &task.Steps[stepNumber-1]
+		if step.Completed == completed {
+			if completed {
+				return fmt.Errorf("step %d is already checked", stepNumber)
+			}
+			return fmt.Errorf("step %d is not checked", stepNumber)
+		}
+
+		step.Completed = completed
+		step.CompletedAt = nil
+		if completed {
+			now := time.Now()
+			step.CompletedAt = &now
+		}
+		if err := tx.Save(step).Error; err != nil {
+			return fmt.Errorf("failed to update step: %w", err)
+		}
+		return nil
+	})
+}
+
+// RemoveTaskStep deletes one of a task's steps and renumbers the rest
+func (s *Store) RemoveTaskStep(period *FocusPeriod, position, stepNumber int) (*Task, int, error) {
+	return s.changeTaskSteps(period, position, func(tx *gorm.DB, task *Task) error {
+		if stepNumber < 1 || stepNumber > len(task.Steps) {
+			return fmt.Errorf("step %d not found on task #%d", stepNumber, position)
+		}
+
+		if err := tx.Unscoped().Delete(&task.Steps[stepNumber-1]).Error; err != nil {
+			return fmt.Errorf("failed to delete step: %w", err)
+		}
+
+		for idx := stepNumber; idx < len(task.Steps); idx++ {
+			if err := tx.Model(&task.Steps[idx]).Update("position", idx).Error; err != nil {
+				return fmt.Errorf("failed to renumber steps: %w", err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+)
+
+func TestTaskStepsAwardPartialPoints(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	period, _ := store.CreateFocusPeriod(user.ID, guildID)
+	store.AddTask(period.ID, "Launch", "", estimator.Estimate{Points: 10}, nil)
+
+	memberPoints := func() int {
+		t.Helper()
+		member, _ := store.GetGuildMember(user.ID, guildID)
+		sprint, _ := store.GetOrCreateSprintPoints(period.ID, user.ID, guildID, period.StartDate, period.EndDate)
+		if member.TotalPoints != sprint.Points {
+			t.Errorf("Expected total and sprint points to agree, got %d and %d", member.TotalPoints, sprint.Points)
+		}
+		return member.TotalPoints
+	}
+
+	for _, step := range []string{"Write copy", "Design hero", "Deploy"} {
+		if _, _, err := store.AddTaskStep(period, 1, step); err != nil {
+			t.Fatalf("Failed to add step: %v", err)
+		}
+	}
+
+	// Steps show progress but earn nothing until the guild opts in
+	task, change, err := store.SetTaskStepCompleted(period, 1, 1, true)
+	if err != nil {
+		t.Fatalf("Failed to check step: %v", err)
+	}
+	if change != 0 || task.Progress() <= 0.3 || task.Progress() >= 0.4 {
+		t.Errorf("Expected a third done and no points, got %.2f and %+d", task.Progress(), change)
+	}
+	if _, _, err := store.SetTaskStepCompleted(period, 1, 1, true); err == nil {
+		t.Error("Expected checking a checked step to be rejected")
+	}
+
+	store.SetStepPointsEnabled(guildID, true)
+	if _, change, _ = store.SetTaskStepCompleted(period, 1, 2, true); change != 6 {
+		t.Errorf("Expected two of three steps to credit 6 points, got %+d", change)
+	}
+	if _, change, _ = store.SetTaskStepCompleted(period, 1, 2, false); change != -3 {
+		t.Errorf("Expected unchecking to take back 3 points, got %+d", change)
+	}
+	if got := memberPoints(); got != 3 {
+		t.Errorf("Expected 3 points from steps, got %d", got)
+	}
+
+	// Completing earns only the rest of the goal's points
+	task, _ = store.CompleteTask(period.ID, 1)
+	store.AddPointsToUser(user.ID, period.ID, task.Points-task.StepPoints, guildID, period.StartDate, period.EndDate)
+	if got := memberPoints(); got != 10 {
+		t.Errorf("Expected the goal's full 10 points, got %d", got)
+	}
+
+	// Reopening keeps the share for the step still checked
+	if _, _, err := store.ReopenTask(period, 1); err != nil {
+		t.Fatalf("Failed to reopen task: %v", err)
+	}
+	if got := memberPoints(); got != 3 {
+		t.Errorf("Expected 3 points after reopening, got %d", got)
+	}
+
+	task, change, _ = store.RemoveTaskStep(period, 1, 1)
+	if change != -3 || len(task.Steps) != 2 || task.Steps[1].Position != 2 || task.Steps[1].Title != "Deploy" {
+		t.Errorf("Expected the checked step gone and the rest renumbered, got %+d and %+v", change, task.Steps)
+	}
+
+	store.SetTaskStepCompleted(period, 1, 1, true)
+	if _, err := store.DeleteTask(period, 1); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+	if got := memberPoints(); got != 0 {
+		t.Errorf("Expected deleting the goal to take back its step points, got %d", got)
+	}
+	var remaining int64
+	store.DB().Model(&TaskStep{}).Count(&remaining)
+	if remaining != 0 {
+		t.Errorf("Expected the goal's steps to be deleted, got %d", remaining)
+	}
+}
+
+func TestCarryOverKeepsSteps(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	store.SetStepPointsEnabled(guildID, true)
+
+	previous, _ := store.CreateFocusPeriod(user.ID, guildID)
+	store.AddTask(previous.ID, "Launch", "", estimator.Estimate{Points: 10}, nil)
+	store.AddTaskStep(previous, 1, "Write copy")
+	store.AddTaskStep(previous, 1, "Deploy")
+	store.SetTaskStepCompleted(previous, 1, 1, true)
+
+	next := FocusPeriod{UserID: user.ID, GuildID: guildID, StartDate: previous.EndDate, EndDate: previous.EndDate.AddDate(0, 0, 14)}
+	store.DB().Create(&next)
+
+	if _, err := store.CarryOverTasks(previous.ID, next.ID); err != nil {
+		t.Fatalf("Failed to carry over tasks: %v", err)
+	}
+	tasks, _ := store.GetTasksByFocusPeriod(next.ID)
+	if len(tasks) != 1 || len(tasks[0].Steps) != 2 || !tasks[0].Steps[0].Completed || tasks[0].StepPoints != 5 {
+		t.Fatalf("Expected the goal carried over with its checked step, got %+v", tasks)
+	}
+
+	// The step's points move to the new period instead of counting in both
+	member, _ := store.GetGuildMember(user.ID, guildID)
+	before, _ := store.GetOrCreateSprintPoints(previous.ID, user.ID, guildID, previous.StartDate, previous.EndDate)
+	after, _ := store.GetOrCreateSprintPoints(next.ID, user.ID, guildID, next.StartDate, next.EndDate)
+	if member.TotalPoints != 5 || before.Points != 0 || after.Points != 5 {
+		t.Errorf("Expected 5 total points all in the new period, got %d total, %d before and %d after",
+			member.TotalPoints, before.Points, after.Points)
+	}
+
+	// The carried step's points were already earned, so checking the other step only adds its share
+	_, change, _ := store.SetTaskStepCompleted(&next, 1, 2, true)
+	if change != 5 {
+		t.Errorf("Expected 5 more points for the second step, got %+d", change)
+	}
+}
@@ -0,0 +1,215 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaxBackfillDays is how far back an admin can backfill a missed standup
+const MaxBackfillDays = 30
+
+// backfilledWorkingOn stands in for what a member was working on in a backfilled standup
+const backfilledWorkingOn = "Backfilled by an admin"
+
+// streakEvent is a standup or freeze purchase replayed when rebuilding a streak
+type streakEvent struct {
+	at      time.Time
+	standup bool // A standup, otherwise a freeze purchase
+}
+
+// replayStreak rebuilds a member's streak from their standups and freeze purchases, in the order they happened.
+// Days are counted in loc and restDays don't count against the streak, the same as when each standup was posted.
+func replayStreak(tx *gorm.DB, userID uint, guildID string, loc *time.Location, restDays map[time.Weekday]bool) (*UserStreak, error) {
+	var standups []Standup
+	if err := tx.Select("date").Where("user_id = ? AND guild_id = ?", userID, guildID).Find(&standups).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch standups: %w", err)
+	}
+	var purchases []PointsTransaction
+	if err := tx.Select("created_at").Where("user_id = ? AND guild_id = ? AND source_type = ?", userID, guildID, PointsSourceStreakFreeze).
+		Find(&purchases).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch freeze purchases: %w", err)
+	}
+
+	events := make([]streakEvent, 0, len(standups)+len(purchases))
+	for _, standup := range standups {
+		events = append(events, streakEvent{at: standup.Date, standup: true})
+	}
+	for _, purchase := range purchases {
+		events = append(events, streakEvent{at: purchase.CreatedAt})
+	}
+	sort.SliceStable(events, func(a, b int) bool {
+		return events[a].at.Before(events[b].at)
+	})
+
+	streak := &UserStreak{UserID: userID, GuildID: guildID}
+	for _, event := range events {
+		if event.standup {
+			streak.recordStandup(event.at, loc, restDays)
+		} else {
+			streak.FreezeTokens = min(streak.FreezeTokens+1, MaxFreezeTokens)
+		}
+	}
+	streak.FrozenDays = 0
+	streak.EarnedFreeze = false
+
+	return streak, nil
+}
+
+// recomputeStreak replaces a member's stored streak with one rebuilt from their standups.
+// It reports whether the stored streak changed.
+func recomputeStreak(tx *gorm.DB, userID uint, guildID string, loc *time.Location, restDays map[time.Weekday]bool) (*UserStreak, bool, error) {
+	rebuilt, err := replayStreak(tx, userID, guildID, loc, restDays)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var stored UserStreak
+	result := tx.Where("user_id = ? AND guild_id = ?", userID, guildID).Limit(1).Find(&stored)
+	if result.Error != nil {
+		return nil, false, fmt.Errorf("failed to fetch streak: %w", result.Error)
+	}
+	if result.RowsAffected > 0 && sameStreak(&stored, rebuilt) {
+		return &stored, false, nil
+	}
+
+	rebuilt.Model = stored.Model
+	if err := tx.Save(rebuilt).Error; err != nil {
+		return nil, false, fmt.Errorf("failed to save streak: %w", err)
+	}
+	return rebuilt, true, nil
+}
+
+// sameStreak reports whether two streaks record the same standup history
+func sameStreak(a, b *UserStreak) bool {
+	if (a.LastStandupDate == nil) != (b.LastStandupDate == nil) {
+		return false
+	}
+	if a.LastStandupDate != nil && !a.LastStandupDate.Equal(*b.LastStandupDate) {
+		return false
+	}
+	return a.CurrentStreak == b.CurrentStreak && a.LongestStreak == b.LongestStreak &&
+		a.TotalStandups == b.TotalStandups && a.FreezeTokens == b.FreezeTokens && a.FreezesUsed == b.FreezesUsed
+}
+
+// RecomputeStreak rebuilds a member's streak from their standups under the guild's current
+// rest days and the member's current timezone. Points already awarded are left alone.
+func (s *Store) RecomputeStreak(userID uint, guildID string) (*UserStreak, error) {
+	config, err := s.GetGuildConfig(guildID)
+	if err != nil {
+		return nil, err
+	}
+	loc := s.UserLocation(userID, guildID)
+
+	var streak *UserStreak
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		streak, _, err = recomputeStreak(tx, userID, guildID, loc, config.RestDays())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return streak, nil
+}
+
+// RecomputeStreaks rebuilds every member's streak from their standups, as RecomputeStreak does.
+// It returns how many streaks were corrected.
+func (s *Store) RecomputeStreaks() (int, error) {
+	type member struct {
+		UserID  uint
+		GuildID string
+	}
+
+	var members []member
+	result := s.db.Raw(`
+		SELECT user_id, guild_id FROM standups WHERE deleted_at IS NULL
+		UNION
+		SELECT user_id, guild_id FROM user_streaks WHERE deleted_at IS NULL
+	`).Scan(&members)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to fetch members with streaks: %w", result.Error)
+	}
+
+	configs := make(map[string]*GuildConfig)
+	corrected := 0
+	for _, m := range members {
+		config, ok := configs[m.GuildID]
+		if !ok {
+			var err error
+			if config, err = s.GetGuildConfig(m.GuildID); err != nil {
+				return corrected, err
+			}
+			configs[m.GuildID] = config
+		}
+
+		_, changed, err := recomputeStreak(s.db, m.UserID, m.GuildID, s.UserLocation(m.UserID, m.GuildID), config.RestDays())
+		if err != nil {
+			return corrected, err
+		}
+		if changed {
+			corrected++
+		}
+	}
+
+	return corrected, nil
+}
+
+// BackfillStandup records a standup for a day a member missed, such as during a bot outage,
+// and rebuilds their streak. The day is the start of a past day in the member's timezone,
+// at most MaxBackfillDays ago. The standup earns its base point but no streak bonus.
+func (s *Store) BackfillStandup(userID uint, guildID string, day time.Time) (*Standup, *UserStreak, error) {
+	config, err := s.GetGuildConfig(guildID)
+	if err != nil {
+		return nil, nil, err
+	}
+	loc := s.UserLocation(userID, guildID)
+
+	day = StartOfDay(day.In(loc))
+	today := StartOfDay(time.Now().In(loc))
+	if !day.Before(today) {
+		return nil, nil, fmt.Errorf("only days before today can be backfilled")
+	}
+	if day.Before(today.AddDate(0, 0, -MaxBackfillDays)) {
+		return nil, nil, fmt.Errorf("only the last %d days can be backfilled", MaxBackfillDays)
+	}
+
+	var standup *Standup
+	var streak *UserStreak
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&Standup{}).Where("user_id = ? AND guild_id = ? AND date >= ? AND date < ?",
+			userID, guildID, day.Local(), day.AddDate(0, 0, 1).Local()).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check standup: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("there is already a standup on %s", day.Format("Jan 2"))
+		}
+
+		// Midday keeps the standup on the right day whatever the server's timezone
+		standup = &Standup{
+			UserID:    userID,
+			GuildID:   guildID,
+			Date:      day.Add(12 * time.Hour),
+			WorkingOn: backfilledWorkingOn,
+		}
+		if err := tx.Create(standup).Error; err != nil {
+			return fmt.Errorf("failed to create standup: %w", err)
+		}
+
+		source := PointsSource{Type: PointsSourceStandup, ID: standup.ID, Note: "Backfilled for " + day.Format("Jan 2")}
+		if err := addMemberPoints(tx, userID, guildID, 1, source); err != nil {
+			return err
+		}
+
+		streak, _, err = recomputeStreak(tx, userID, guildID, loc, config.RestDays())
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return standup, streak, nil
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecomputeStreakAndBackfill(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	alice, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+
+	// Alice posted four and three days ago and yesterday, but missed two days ago
+	today := StartOfDay(time.Now())
+	for _, daysAgo := range []int{4, 3, 1} {
+		standup := Standup{UserID: alice.ID, GuildID: guildID, Date: today.AddDate(0, 0, -daysAgo).Add(10 * time.Hour), WorkingOn: "Pricing page"}
+		if err := store.db.Create(&standup).Error; err != nil {
+			t.Fatalf("Failed to create standup: %v", err)
+		}
+	}
+	store.db.Create(&UserStreak{UserID: alice.ID, GuildID: guildID, CurrentStreak: 9, LongestStreak: 9, TotalStandups: 9})
+
+	corrected, err := store.RecomputeStreaks()
+	if err != nil || corrected != 1 {
+		t.Fatalf("Expected one streak corrected, got %d (%v)", corrected, err)
+	}
+	streak, _ := store.GetUserStreak(alice.ID, guildID)
+	if streak.CurrentStreak != 1 || streak.LongestStreak != 2 || streak.TotalStandups != 3 {
+		t.Errorf("Expected the missed day to break the streak, got %+v", streak)
+	}
+	if corrected, _ := store.RecomputeStreaks(); corrected != 0 {
+		t.Errorf("Expected nothing left to correct, got %d", corrected)
+	}
+
+	// Backfilling the missed day joins the streak back up
+	_, streak, err = store.BackfillStandup(alice.ID, guildID, today.AddDate(0, 0, -2))
+	if err != nil {
+		t.Fatalf("Failed to backfill standup: %v", err)
+	}
+	if streak.CurrentStreak != 4 || streak.LongestStreak != 4 || streak.TotalStandups != 4 {
+		t.Errorf("Expected a 4-day streak after backfilling, got %+v", streak)
+	}
+	history, _ := store.GetPointsHistory(alice.ID, guildID, 1)
+	if len(history) != 1 || history[0].SourceType != PointsSourceStandup || history[0].Amount != 1 {
+		t.Errorf("Expected the backfilled standup's point in the ledger, got %+v", history)
+	}
+
+	if _, _, err := store.BackfillStandup(alice.ID, guildID, today.AddDate(0, 0, -2)); err == nil {
+		t.Error("Expected backfilling a day with a standup to fail")
+	}
+	if _, _, err := store.BackfillStandup(alice.ID, guildID, today); err == nil {
+		t.Error("Expected backfilling today to fail")
+	}
+	if _, _, err := store.BackfillStandup(alice.ID, guildID, today.AddDate(0, 0, -MaxBackfillDays-1)); err == nil {
+		t.Error("Expected backfilling too far back to fail")
+	}
+}
+
+func TestRecomputeStreakReplaysFreezes(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	alice, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	if _, err := store.AdjustPoints(alice.ID, guildID, FreezeTokenCost, alice.ID, "Seed"); err != nil {
+		t.Fatalf("Failed to grant points: %v", err)
+	}
+	if _, err := store.BuyStreakFreeze(alice.ID, guildID); err != nil {
+		t.Fatalf("Failed to buy freeze: %v", err)
+	}
+	// The freeze was bought before any of the standups
+	store.db.Model(&PointsTransaction{}).Where("source_type = ?", PointsSourceStreakFreeze).Update("created_at", time.Now().AddDate(0, 0, -10))
+
+	today := StartOfDay(time.Now())
+	for _, daysAgo := range []int{3, 1} {
+		store.db.Create(&Standup{UserID: alice.ID, GuildID: guildID, Date: today.AddDate(0, 0, -daysAgo).Add(10 * time.Hour), WorkingOn: "Pricing page"})
+	}
+
+	streak, err := store.RecomputeStreak(alice.ID, guildID)
+	if err != nil {
+		t.Fatalf("Failed to recompute streak: %v", err)
+	}
+	if streak.CurrentStreak != 2 || streak.FreezeTokens != 0 || streak.FreezesUsed != 1 {
+		t.Errorf("Expected the bought freeze to cover the missed day, got %+v", streak)
+	}
+}
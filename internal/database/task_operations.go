@@ -0,0 +1,368 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+	"gorm.io/gorm"
+)
+
+// findTask loads the task at a position in a focus period
+func findTask(tx *gorm.DB, focusPeriodID uint, position int) (*Task, error) {
+	var task Task
+	result := tx.Where("focus_period_id = ? AND position = ?", focusPeriodID, position).First(&task)
+
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("task #%d not found", position)
+	}
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch task: %w", result.Error)
+	}
+
+	return &task, nil
+}
+
+// renumberTasks writes positions 1..n for a focus period's tasks in the given order
+func renumberTasks(tx *gorm.DB, tasks []Task) error {
+	for idx := range tasks {
+		position := idx + 1
+		if tasks[idx].Position == position {
+			continue
+		}
+		if err := tx.Model(&tasks[idx]).Update("position", position).Error; err != nil {
+			return fmt.Errorf("failed to renumber tasks: %w", err)
+		}
+		tasks[idx].Position = position
+	}
+	return nil
+}
+
+// orderedTasks loads a focus period's tasks in position order
+func orderedTasks(tx *gorm.DB, focusPeriodID uint) ([]Task, error) {
+	var tasks []Task
+	if err := tx.Where("focus_period_id = ?", focusPeriodID).Order("position ASC").Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// UpdateTask changes the title and estimated points of a pending task.
+// Points credited for its checked steps are rescaled to the new points.
+func (s *Store) UpdateTask(period *FocusPeriod, position int, title string, estimate estimator.Estimate) (*Task, error) {
+	config, err := s.GetGuildConfig(period.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var task *Task
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		task, err = findTask(tx, period.ID, position)
+		if err != nil {
+			return err
+		}
+
+		if task.Completed {
+			return fmt.Errorf("task #%d is completed; reopen it before editing", position)
+		}
+
+		task.Title = title
+		task.Points = estimate.Points
+		task.Rationale = estimate.Rationale
+		if err := tx.Save(task).Error; err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+
+		if task.Steps, err = orderedSteps(tx, task.ID); err != nil {
+			return err
+		}
+		_, err = syncStepPoints(tx, config, period, task)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// ReopenTask marks a completed task as pending again and takes back the points it earned.
+// If the guild awards step points, the share for its checked steps is credited again.
+// It returns the task and the points the member lost overall.
+func (s *Store) ReopenTask(period *FocusPeriod, position int) (*Task, int, error) {
+	config, err := s.GetGuildConfig(period.GuildID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var task *Task
+	var lost int
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		task, err = findTask(tx, period.ID, position)
+		if err != nil {
+			return err
+		}
+
+		if !task.Completed {
+			return fmt.Errorf("task #%d is not completed", position)
+		}
+
+		revoked, err := revokeTaskPoints(tx, period, task)
+		if err != nil {
+			return err
+		}
+
+		// Points held back by guardrails are no longer owed
+		if err := clearTaskFlags(tx, task.ID); err != nil {
+			return err
+		}
+
+		task.Completed = false
+		task.CompletedAt = nil
+		task.StepPoints = 0
+		task.WithheldPoints = 0
+		if err := tx.Save(task).Error; err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+
+		if task.Steps, err = orderedSteps(tx, task.ID); err != nil {
+			return err
+		}
+		if _, err := syncStepPoints(tx, config, period, task); err != nil {
+			return err
+		}
+		lost = revoked - task.StepPoints
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return task, lost, nil
+}
+
+// DeleteTask removes a task and its steps and renumbers the rest.
+// Points the task earned are taken back.
+func (s *Store) DeleteTask(period *FocusPeriod, position int) (*Task, error) {
+	var task *Task
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		task, err = findTask(tx, period.ID, position)
+		if err != nil {
+			return err
+		}
+
+		// Removed goals are gone for good so they don't linger in leaderboard task counts
+		if err := tx.Unscoped().Where("task_id = ?", task.ID).Delete(&TaskStep{}).Error; err != nil {
+			return fmt.Errorf("failed to delete steps: %w", err)
+		}
+		if err := tx.Unscoped().Where("task_id = ?", task.ID).Delete(&FlaggedCompletion{}).Error; err != nil {
+			return fmt.Errorf("failed to delete flagged completions: %w", err)
+		}
+		if err := tx.Unscoped().Delete(task).Error; err != nil {
+			return fmt.Errorf("failed to delete task: %w", err)
+		}
+
+		if _, err := revokeTaskPoints(tx, period, task); err != nil {
+			return err
+		}
+
+		tasks, err := orderedTasks(tx, period.ID)
+		if err != nil {
+			return err
+		}
+		return renumberTasks(tx, tasks)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// MoveTask moves a task to a new position, shifting the tasks in between
+func (s *Store) MoveTask(focusPeriodID uint, from, to int) (*Task, error) {
+	var moved Task
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		tasks, err := orderedTasks(tx, focusPeriodID)
+		if err != nil {
+			return err
+		}
+
+		if from < 1 || from > len(tasks) {
+			return fmt.Errorf("task #%d not found", from)
+		}
+		if to < 1 || to > len(tasks) {
+			return fmt.Errorf("position must be between 1 and %d", len(tasks))
+		}
+
+		moved = tasks[from-1]
+		tasks = append(tasks[:from-1], tasks[from:]...)
+		tasks = append(tasks[:to-1], append([]Task{moved}, tasks[to-1:]...)...)
+
+		if err := renumberTasks(tx, tasks); err != nil {
+			return err
+		}
+		moved.Position = to
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &moved, nil
+}
+
+// revokeTaskPoints takes back the points a task has earned and returns how many were taken.
+// A completion from an archived season is left alone, since its points were reset with that season.
+func revokeTaskPoints(tx *gorm.DB, period *FocusPeriod, task *Task) (int, error) {
+	points := task.CreditedPoints()
+	if points == 0 {
+		return 0, nil
+	}
+	if task.Completed && task.CompletedAt != nil {
+		since, err := currentSeasonStart(tx, period.GuildID)
+		if err != nil {
+			return 0, err
+		}
+		if task.CompletedAt.Before(since) {
+			return 0, nil
+		}
+	}
+	source := PointsSource{Type: PointsSourceTaskRevoked, ID: task.ID, Note: task.Title}
+	if err := addMemberPoints(tx, period.UserID, period.GuildID, -points, source); err != nil {
+		return 0, err
+	}
+	if err := addSprintPoints(tx, period.UserID, period.ID, -points, period.GuildID, period.StartDate, period.EndDate); err != nil {
+		return 0, err
+	}
+	return points, nil
+}
+
+// GetPreviousFocusPeriod returns the user's most recently ended focus period in a guild, or nil if there is none
+func (s *Store) GetPreviousFocusPeriod(userID uint, guildID string) (*FocusPeriod, error) {
+	var period FocusPeriod
+	result := s.db.Preload("Tasks", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).Where("user_id = ? AND guild_id = ? AND end_date <= ?", userID, guildID, time.Now()).
+		Order("end_date DESC").
+		First(&period)
+
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch previous focus period: %w", result.Error)
+	}
+
+	return &period, nil
+}
+
+// CarryOverTasks copies the unfinished tasks of one focus period, with their steps, to the end of another.
+// Points already credited for checked steps move with each task: they are taken back from the old
+// period and credited to the new one, so they count once, against the period the goal finishes in.
+// Tasks already carried into the target period are skipped, so repeating a carry-over is harmless.
+func (s *Store) CarryOverTasks(fromPeriodID, toPeriodID uint) ([]Task, error) {
+	var from FocusPeriod
+	if err := s.db.First(&from, fromPeriodID).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch focus period: %w", err)
+	}
+	config, err := s.GetGuildConfig(from.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var carried []Task
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var to FocusPeriod
+		if err := tx.First(&to, toPeriodID).Error; err != nil {
+			return fmt.Errorf("failed to fetch focus period: %w", err)
+		}
+
+		var pending []Task
+		if err := tx.Preload("Steps", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).Where("focus_period_id = ? AND completed = ?", fromPeriodID, false).Order("position ASC").Find(&pending).Error; err != nil {
+			return fmt.Errorf("failed to fetch unfinished tasks: %w", err)
+		}
+
+		var alreadyCarried []uint
+		if err := tx.Model(&Task{}).Where("focus_period_id = ? AND carried_from_id IS NOT NULL", toPeriodID).Pluck("carried_from_id", &alreadyCarried).Error; err != nil {
+			return fmt.Errorf("failed to fetch carried tasks: %w", err)
+		}
+		skip := make(map[uint]bool, len(alreadyCarried))
+		for _, id := range alreadyCarried {
+			skip[id] = true
+		}
+
+		var maxPosition int
+		tx.Model(&Task{}).Where("focus_period_id = ?", toPeriodID).Select("COALESCE(MAX(position), 0)").Scan(&maxPosition)
+
+		for _, task := range pending {
+			if skip[task.ID] {
+				continue
+			}
+			credited := task.StepPoints
+			if _, err := setStepPoints(tx, config, &from, &task, 0); err != nil {
+				return err
+			}
+
+			maxPosition++
+			sourceID := task.ID
+			copied := Task{
+				FocusPeriodID: toPeriodID,
+				Title:         task.Title,
+				Description:   task.Description,
+				Position:      maxPosition,
+				Points:        task.Points,
+				DueDate:       task.DueDate,
+				CarriedFromID: &sourceID,
+				Rationale:     task.Rationale,
+			}
+			for _, step := range task.Steps {
+				copied.Steps = append(copied.Steps, TaskStep{
+					Title:       step.Title,
+					Position:    step.Position,
+					Completed:   step.Completed,
+					CompletedAt: step.CompletedAt,
+				})
+			}
+			if err := tx.Create(&copied).Error; err != nil {
+				return fmt.Errorf("failed to carry over task: %w", err)
+			}
+			if _, err := setStepPoints(tx, config, &to, &copied, credited); err != nil {
+				return err
+			}
+			carried = append(carried, copied)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return carried, nil
+}
+
+// GetPendingTasksDueBefore returns the unfinished tasks in a guild's active focus periods that are due before a time,
+// with their focus period and its user loaded
+func (s *Store) GetPendingTasksDueBefore(guildID string, now, before time.Time) ([]Task, error) {
+	var tasks []Task
+	result := s.db.Preload("FocusPeriod.User").
+		Joins("JOIN focus_periods ON focus_periods.id = tasks.focus_period_id AND focus_periods.deleted_at IS NULL").
+		Where("focus_periods.guild_id = ? AND focus_periods.start_date <= ? AND focus_periods.end_date >= ?", guildID, now.Local(), now.Local()).
+		Where("tasks.completed = ? AND tasks.due_date IS NOT NULL AND tasks.due_date < ?", false, before.Local()).
+		Order("tasks.due_date ASC, tasks.position ASC").
+		Find(&tasks)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch due tasks: %w", result.Error)
+	}
+
+	return tasks, nil
+}
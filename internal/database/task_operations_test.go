@@ -0,0 +1,111 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+)
+
+func taskTitles(t *testing.T, store *Store, periodID uint) []string {
+	t.Helper()
+	tasks, err := store.GetTasksByFocusPeriod(periodID)
+	if err != nil {
+		t.Fatalf("Failed to fetch tasks: %v", err)
+	}
+	var titles []string
+	for idx, task := range tasks {
+		if task.Position != idx+1 {
+			t.Errorf("Expected %q at position %d, got %d", task.Title, idx+1, task.Position)
+		}
+		titles = append(titles, task.Title)
+	}
+	return titles
+}
+
+func TestTaskEditingKeepsPositionsAndPoints(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	period, _ := store.CreateFocusPeriod(user.ID, guildID)
+
+	for _, title := range []string{"Landing page", "Pricing", "Launch post", "Onboarding"} {
+		store.AddTask(period.ID, title, "", estimator.Estimate{Points: 5}, nil)
+	}
+
+	if _, err := store.MoveTask(period.ID, 4, 1); err != nil {
+		t.Fatalf("Failed to move task: %v", err)
+	}
+	if got := taskTitles(t, store, period.ID); got[0] != "Onboarding" || got[3] != "Launch post" {
+		t.Errorf("Unexpected order after move: %v", got)
+	}
+	if _, err := store.MoveTask(period.ID, 1, 9); err == nil {
+		t.Error("Expected moving past the end to be rejected")
+	}
+
+	store.CompleteTask(period.ID, 2)
+	store.AddPointsToUser(user.ID, period.ID, 5, guildID, period.StartDate, period.EndDate)
+	if _, err := store.UpdateTask(period, 2, "Landing page v2", estimator.Estimate{Points: 6}); err == nil {
+		t.Error("Expected editing a completed task to be rejected")
+	}
+
+	// Reopening takes back the completion points
+	if _, _, err := store.ReopenTask(period, 2); err != nil {
+		t.Fatalf("Failed to reopen task: %v", err)
+	}
+	if _, _, err := store.ReopenTask(period, 2); err == nil {
+		t.Error("Expected reopening a pending task to be rejected")
+	}
+	member, _ := store.GetGuildMember(user.ID, guildID)
+	sprint, _ := store.GetOrCreateSprintPoints(period.ID, user.ID, guildID, period.StartDate, period.EndDate)
+	if member.TotalPoints != 0 || sprint.Points != 0 {
+		t.Errorf("Expected points to be taken back, got total %d and sprint %d", member.TotalPoints, sprint.Points)
+	}
+
+	// Removing a completed task also takes back its points and renumbers the rest
+	store.CompleteTask(period.ID, 1)
+	store.AddPointsToUser(user.ID, period.ID, 5, guildID, period.StartDate, period.EndDate)
+	if _, err := store.DeleteTask(period, 1); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+	if got := taskTitles(t, store, period.ID); len(got) != 3 || got[0] != "Landing page" {
+		t.Errorf("Unexpected tasks after delete: %v", got)
+	}
+	member, _ = store.GetGuildMember(user.ID, guildID)
+	if member.TotalPoints != 0 {
+		t.Errorf("Expected points for the removed task to be taken back, got %d", member.TotalPoints)
+	}
+}
+
+func TestCarryOverTasks(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+
+	old := FocusPeriod{UserID: user.ID, GuildID: guildID, StartDate: time.Now().AddDate(0, 0, -20), EndDate: time.Now().AddDate(0, 0, -6)}
+	store.db.Create(&old)
+	store.AddTask(old.ID, "Finished", "", estimator.Estimate{Points: 5}, nil)
+	store.AddTask(old.ID, "Unfinished", "", estimator.Estimate{Points: 7}, nil)
+	store.CompleteTask(old.ID, 1)
+
+	current, _ := store.CreateFocusPeriod(user.ID, guildID)
+	store.AddTask(current.ID, "New goal", "", estimator.Estimate{Points: 3}, nil)
+
+	previous, err := store.GetPreviousFocusPeriod(user.ID, guildID)
+	if err != nil || previous == nil || previous.ID != old.ID {
+		t.Fatalf("Expected the ended period as previous, got %+v (%v)", previous, err)
+	}
+
+	carried, err := store.CarryOverTasks(old.ID, current.ID)
+	if err != nil {
+		t.Fatalf("Failed to carry over: %v", err)
+	}
+	if len(carried) != 1 || carried[0].Title != "Unfinished" || carried[0].Points != 7 || carried[0].Position != 2 {
+		t.Errorf("Expected the unfinished task appended with its points, got %+v", carried)
+	}
+
+	// Carrying over again doesn't duplicate goals
+	if carried, _ := store.CarryOverTasks(old.ID, current.ID); len(carried) != 0 {
+		t.Errorf("Expected nothing to carry over twice, got %d", len(carried))
+	}
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	_ "time/tzdata" // Embed the zone database so timezones work on hosts without one
+)
+
+// LoadTimezone parses an IANA timezone name such as "Asia/Singapore"
+func LoadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "local") {
+		return nil, fmt.Errorf("a timezone name such as Europe/Berlin is required")
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+
+	return loc, nil
+}
+
+// StartOfDay returns midnight of t's calendar day in t's location.
+// Convert the result with Local() before using it in a query: SQLite stores
+// timestamps as text, so bounds must share the stored values' offset.
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// calendarDaysBetween counts the calendar days from a to b using each time's own date.
+// Comparing dates rather than durations keeps the count correct across DST changes.
+func calendarDaysBetween(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	start := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	end := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// resolveLocation picks the user's timezone, then the guild default
+func resolveLocation(userTimezone string, guildLocation *time.Location) *time.Location {
+	if userTimezone != "" {
+		if loc, err := time.LoadLocation(userTimezone); err == nil {
+			return loc
+		}
+	}
+	return guildLocation
+}
+
+// GuildLocation returns the guild's default timezone, or server local time if none is set
+func (s *Store) GuildLocation(guildID string) *time.Location {
+	var config GuildConfig
+	result := s.db.Select("timezone").Where("guild_id = ?", guildID).Limit(1).Find(&config)
+	if result.Error != nil || config.Timezone == "" {
+		return time.Local
+	}
+
+	loc, err := time.LoadLocation(config.Timezone)
+	if err != nil {
+		return time.Local
+	}
+
+	return loc
+}
+
+// UserLocation returns the timezone used for a user's days and reminders in a guild.
+// The user's own timezone wins, then the guild default, then server local time.
+func (s *Store) UserLocation(userID uint, guildID string) *time.Location {
+	var user User
+	s.db.Select("timezone").Where("id = ?", userID).Limit(1).Find(&user)
+	return resolveLocation(user.Timezone, s.GuildLocation(guildID))
+}
+
+// UpdateUserTimezone sets a user's timezone; an empty name falls back to the guild default
+func (s *Store) UpdateUserTimezone(userID uint, name string) error {
+	if name != "" {
+		loc, err := LoadTimezone(name)
+		if err != nil {
+			return err
+		}
+		name = loc.String()
+	}
+
+	if err := s.db.Model(&User{}).Where("id = ?", userID).Update("timezone", name).Error; err != nil {
+		return fmt.Errorf("failed to update timezone: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateGuildTimezone sets the default timezone for a guild's members
+func (s *Store) UpdateGuildTimezone(guildID, name string) error {
+	loc, err := LoadTimezone(name)
+	if err != nil {
+		return err
+	}
+
+	config, err := s.GetOrCreateGuildConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	config.Timezone = loc.String()
+	if err := s.db.Save(config).Error; err != nil {
+		return fmt.Errorf("failed to update guild timezone: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	if _, err := LoadTimezone("Asia/Singapore"); err != nil {
+		t.Errorf("Expected Asia/Singapore to load, got %v", err)
+	}
+
+	for _, name := range []string{"", "Local", "Mars/Olympus_Mons"} {
+		if _, err := LoadTimezone(name); err == nil {
+			t.Errorf("Expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestUserLocationFallsBackToGuildDefault(t *testing.T) {
+	store := setupTestDB(t)
+	guildID := "test-guild-123"
+
+	user, err := store.GetOrCreateUser("user-1", guildID, "alice")
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	if loc := store.UserLocation(user.ID, guildID); loc != time.Local {
+		t.Errorf("Expected server local time without any timezone, got %s", loc)
+	}
+
+	if err := store.UpdateGuildTimezone(guildID, "Europe/Berlin"); err != nil {
+		t.Fatalf("Failed to set guild timezone: %v", err)
+	}
+	if loc := store.UserLocation(user.ID, guildID); loc.String() != "Europe/Berlin" {
+		t.Errorf("Expected guild default Europe/Berlin, got %s", loc)
+	}
+
+	if err := store.UpdateUserTimezone(user.ID, "Asia/Tokyo"); err != nil {
+		t.Fatalf("Failed to set user timezone: %v", err)
+	}
+	if loc := store.UserLocation(user.ID, guildID); loc.String() != "Asia/Tokyo" {
+		t.Errorf("Expected user timezone Asia/Tokyo, got %s", loc)
+	}
+
+	// Clearing the user's timezone restores the guild default
+	if err := store.UpdateUserTimezone(user.ID, ""); err != nil {
+		t.Fatalf("Failed to clear user timezone: %v", err)
+	}
+	if loc := store.UserLocation(user.ID, guildID); loc.String() != "Europe/Berlin" {
+		t.Errorf("Expected guild default after clearing, got %s", loc)
+	}
+
+	if err := store.UpdateUserTimezone(user.ID, "Nowhere/Special"); err == nil {
+		t.Error("Expected an unknown timezone to be rejected")
+	}
+}
+
+func TestCreateStandupCountsDaysInUserTimezone(t *testing.T) {
+	store := setupTestDB(t)
+	if err := store.db.AutoMigrate(&Standup{}, &UserStreak{}); err != nil {
+		t.Fatalf("Failed to migrate standups: %v", err)
+	}
+	guildID := "test-guild-123"
+
+	user, _ := store.GetOrCreateUser("user-1", guildID, "alice")
+	if err := store.UpdateUserTimezone(user.ID, "Pacific/Kiritimati"); err != nil {
+		t.Fatalf("Failed to set user timezone: %v", err)
+	}
+	loc := store.UserLocation(user.ID, guildID)
+
+	// Early yesterday in UTC+14 can be two days back on the server clock
+	lastStandup := StartOfDay(time.Now().In(loc)).AddDate(0, 0, -1).Add(time.Hour).Local()
+	streak := UserStreak{
+		UserID:          user.ID,
+		GuildID:         guildID,
+		CurrentStreak:   4,
+		LongestStreak:   4,
+		TotalStandups:   4,
+		LastStandupDate: &lastStandup,
+	}
+	if err := store.db.Create(&streak).Error; err != nil {
+		t.Fatalf("Failed to seed streak: %v", err)
+	}
+	if err := store.db.Create(&Standup{UserID: user.ID, GuildID: guildID, Date: lastStandup}).Error; err != nil {
+		t.Fatalf("Failed to seed standup: %v", err)
+	}
+
+	posted, err := store.HasPostedStandupToday(user.ID, guildID)
+	if err != nil || posted {
+		t.Fatalf("Expected no standup today yet, got posted=%v err=%v", posted, err)
+	}
+
+	_, updated, _, err := store.CreateStandup(user.ID, guildID, "Shipping", "", "")
+	if err != nil {
+		t.Fatalf("Failed to create standup: %v", err)
+	}
+	if updated.CurrentStreak != 5 {
+		t.Errorf("Expected streak to continue to 5, got %d", updated.CurrentStreak)
+	}
+
+	if _, _, _, err := store.CreateStandup(user.ID, guildID, "Again", "", ""); err == nil {
+		t.Error("Expected a second standup on the same local day to be rejected")
+	}
+}
+
+func TestFocusPeriodDayNumberAtUsesCalendarDays(t *testing.T) {
+	tokyo, _ := LoadTimezone("Asia/Tokyo")
+	start := time.Date(2026, 3, 10, 0, 0, 0, 0, tokyo)
+	period := FocusPeriod{StartDate: start, EndDate: start.AddDate(0, 0, DefaultFocusPeriodDays)}
+
+	tests := []struct {
+		now  time.Time
+		want int
+	}{
+		{start.Add(-time.Minute), 0},
+		{start.Add(23 * time.Hour), 1},
+		{time.Date(2026, 3, 12, 9, 0, 0, 0, tokyo), 3},
+		{time.Date(2026, 4, 30, 9, 0, 0, 0, tokyo), 14},
+	}
+
+	for _, tt := range tests {
+		if got := period.DayNumberAt(tt.now); got != tt.want {
+			t.Errorf("DayNumberAt(%v) = %d, want %d", tt.now, got, tt.want)
+		}
+	}
+}
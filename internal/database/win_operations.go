@@ -6,7 +6,7 @@ import (
 )
 
 // CreateWin creates a new win entry
-func CreateWin(userID uint, guildID, message, category string) (*Win, error) {
+func (s *Store) CreateWin(userID uint, guildID, message, category string) (*Win, error) {
 	// Validate category
 	validCategories := map[string]bool{
 		WinCategoryRevenue:   true,
@@ -29,24 +29,21 @@ func CreateWin(userID uint, guildID, message, category string) (*Win, error) {
 		Category: category,
 	}
 
-	if err := DB.Create(win).Error; err != nil {
+	if err := s.db.Create(win).Error; err != nil {
 		return nil, fmt.Errorf("failed to create win: %w", err)
 	}
 
 	// Award 2 points for sharing a win
-	var user User
-	if err := DB.Where("id = ?", userID).First(&user).Error; err != nil {
+	if err := addMemberPoints(s.db, userID, guildID, 2, PointsSource{Type: PointsSourceWin, ID: win.ID}); err != nil {
 		return win, nil // Win created but points not awarded - not critical
 	}
-	user.TotalPoints += 2
-	DB.Save(&user)
 
 	return win, nil
 }
 
 // UpdateWinMessageID updates the Discord message ID for a win
-func UpdateWinMessageID(winID uint, messageID string) error {
-	result := DB.Model(&Win{}).Where("id = ?", winID).Update("message_id", messageID)
+func (s *Store) UpdateWinMessageID(winID uint, messageID string) error {
+	result := s.db.Model(&Win{}).Where("id = ?", winID).Update("message_id", messageID)
 	if result.Error != nil {
 		return fmt.Errorf("failed to update win message ID: %w", result.Error)
 	}
@@ -54,11 +51,11 @@ func UpdateWinMessageID(winID uint, messageID string) error {
 }
 
 // GetRecentWins gets recent wins for a guild
-func GetRecentWins(guildID string, days int) ([]Win, error) {
+func (s *Store) GetRecentWins(guildID string, days int) ([]Win, error) {
 	var wins []Win
 	since := time.Now().AddDate(0, 0, -days)
 
-	result := DB.Preload("User").
+	result := s.db.Preload("User").
 		Where("guild_id = ? AND created_at >= ?", guildID, since).
 		Order("created_at DESC").
 		Find(&wins)
@@ -86,18 +83,18 @@ type WinSharerStats struct {
 }
 
 // GetWinStats gets win statistics for a guild
-func GetWinStats(guildID string) (*WinStats, error) {
+func (s *Store) GetWinStats(guildID string) (*WinStats, error) {
 	stats := &WinStats{
 		WinsByCategory: make(map[string]int),
 	}
 
 	// Total wins
 	var totalWins int64
-	DB.Model(&Win{}).Where("guild_id = ?", guildID).Count(&totalWins)
+	s.db.Model(&Win{}).Where("guild_id = ?", guildID).Count(&totalWins)
 	stats.TotalWins = int(totalWins)
 
 	// Wins by category
-	rows, err := DB.Model(&Win{}).
+	rows, err := s.db.Model(&Win{}).
 		Select("category, COUNT(*) as count").
 		Where("guild_id = ?", guildID).
 		Group("category").
@@ -119,16 +116,17 @@ func GetWinStats(guildID string) (*WinStats, error) {
 	// Recent wins (last 7 days)
 	since := time.Now().AddDate(0, 0, -7)
 	var recentWins int64
-	DB.Model(&Win{}).Where("guild_id = ? AND created_at >= ?", guildID, since).Count(&recentWins)
+	s.db.Model(&Win{}).Where("guild_id = ? AND created_at >= ?", guildID, since).Count(&recentWins)
 	stats.RecentWinsCount = int(recentWins)
 
 	// Top sharers
-	topRows, err := DB.Raw(`
-		SELECT u.discord_id, u.username, COUNT(w.id) as win_count
+	topRows, err := s.db.Raw(`
+		SELECT u.discord_id, gm.username, COUNT(w.id) as win_count
 		FROM wins w
 		JOIN users u ON u.id = w.user_id
+		JOIN guild_members gm ON gm.user_id = w.user_id AND gm.guild_id = w.guild_id
 		WHERE w.guild_id = ?
-		GROUP BY w.user_id
+		GROUP BY w.user_id, u.discord_id, gm.username
 		ORDER BY win_count DESC
 		LIMIT 5
 	`, guildID).Rows()
@@ -149,8 +147,8 @@ func GetWinStats(guildID string) (*WinStats, error) {
 }
 
 // GetWinsChannel gets the wins channel for a guild
-func GetWinsChannel(guildID string) (string, error) {
-	config, err := GetOrCreateGuildConfig(guildID)
+func (s *Store) GetWinsChannel(guildID string) (string, error) {
+	config, err := s.GetOrCreateGuildConfig(guildID)
 	if err != nil {
 		return "", err
 	}
@@ -158,29 +156,29 @@ func GetWinsChannel(guildID string) (string, error) {
 }
 
 // UpdateWinsChannel updates the wins channel for a guild
-func UpdateWinsChannel(guildID, channelID string) error {
-	config, err := GetOrCreateGuildConfig(guildID)
+func (s *Store) UpdateWinsChannel(guildID, channelID string) error {
+	config, err := s.GetOrCreateGuildConfig(guildID)
 	if err != nil {
 		return err
 	}
 
 	config.WinsChannel = channelID
-	if err := DB.Save(config).Error; err != nil {
+	if err := s.db.Save(config).Error; err != nil {
 		return fmt.Errorf("failed to update wins channel: %w", err)
 	}
 
 	return nil
 }
 
-// GetMonthlyTopWins gets top wins for the previous month
-func GetMonthlyTopWins(guildID string, limit int) ([]Win, error) {
-	now := time.Now()
+// GetMonthlyTopWins gets top wins for the previous month in the guild's timezone
+func (s *Store) GetMonthlyTopWins(guildID string, limit int) ([]Win, error) {
+	now := time.Now().In(s.GuildLocation(guildID))
 	// Get first day of previous month
-	firstOfThisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-	firstOfLastMonth := firstOfThisMonth.AddDate(0, -1, 0)
+	firstOfThisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Local()
+	firstOfLastMonth := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, now.Location()).Local()
 
 	var wins []Win
-	result := DB.Preload("User").
+	result := s.db.Preload("User").
 		Where("guild_id = ? AND created_at >= ? AND created_at < ?", guildID, firstOfLastMonth, firstOfThisMonth).
 		Order("created_at DESC").
 		Limit(limit).
@@ -194,9 +192,9 @@ func GetMonthlyTopWins(guildID string, limit int) ([]Win, error) {
 }
 
 // GetUserWinCount gets the total number of wins for a user
-func GetUserWinCount(userID uint, guildID string) (int64, error) {
+func (s *Store) GetUserWinCount(userID uint, guildID string) (int64, error) {
 	var count int64
-	result := DB.Model(&Win{}).Where("user_id = ? AND guild_id = ?", userID, guildID).Count(&count)
+	result := s.db.Model(&Win{}).Where("user_id = ? AND guild_id = ?", userID, guildID).Count(&count)
 	if result.Error != nil {
 		return 0, fmt.Errorf("failed to count wins: %w", result.Error)
 	}
@@ -0,0 +1,217 @@
+package discordtest
+
+import (
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Option is a slash command option passed to Invocation.Command
+type Option = *discordgo.ApplicationCommandInteractionDataOption
+
+// Invocation describes who runs a synthetic slash command and where
+type Invocation struct {
+	GuildID   string
+	ChannelID string
+	User      *discordgo.User
+	Roles     []string
+}
+
+// Command builds an InteractionCreate for the named slash command.
+// User, channel and role options are moved into the interaction's resolved data
+// the same way Discord delivers them.
+func (inv Invocation) Command(name string, options ...Option) *discordgo.InteractionCreate {
+	resolved := &discordgo.ApplicationCommandInteractionDataResolved{
+		Users:    make(map[string]*discordgo.User),
+		Channels: make(map[string]*discordgo.Channel),
+		Roles:    make(map[string]*discordgo.Role),
+	}
+	resolveOptions(options, resolved)
+
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			ID:        "interaction-" + name,
+			Type:      discordgo.InteractionApplicationCommand,
+			GuildID:   inv.GuildID,
+			ChannelID: inv.ChannelID,
+			Member: &discordgo.Member{
+				User:  inv.User,
+				Roles: inv.Roles,
+			},
+			Data: discordgo.ApplicationCommandInteractionData{
+				ID:       "command-" + name,
+				Name:     name,
+				Options:  options,
+				Resolved: resolved,
+			},
+		},
+	}
+}
+
+// Component builds an InteractionCreate for a click on a button or select menu
+func (inv Invocation) Component(customID string, values ...string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			ID:        "interaction-" + customID,
+			Type:      discordgo.InteractionMessageComponent,
+			GuildID:   inv.GuildID,
+			ChannelID: inv.ChannelID,
+			Member: &discordgo.Member{
+				User:  inv.User,
+				Roles: inv.Roles,
+			},
+			Data: discordgo.MessageComponentInteractionData{
+				CustomID: customID,
+				Values:   values,
+			},
+		},
+	}
+}
+
+// ModalSubmit builds an InteractionCreate for a submitted modal.
+// Each value fills the text input with the same custom ID, one per row as Discord sends them.
+func (inv Invocation) ModalSubmit(customID string, values map[string]string) *discordgo.InteractionCreate {
+	inputIDs := make([]string, 0, len(values))
+	for inputID := range values {
+		inputIDs = append(inputIDs, inputID)
+	}
+	sort.Strings(inputIDs)
+
+	rows := make([]discordgo.MessageComponent, 0, len(inputIDs))
+	for _, inputID := range inputIDs {
+		rows = append(rows, &discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				&discordgo.TextInput{CustomID: inputID, Value: values[inputID]},
+			},
+		})
+	}
+
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			ID:        "interaction-" + customID,
+			Type:      discordgo.InteractionModalSubmit,
+			GuildID:   inv.GuildID,
+			ChannelID: inv.ChannelID,
+			Member: &discordgo.Member{
+				User:  inv.User,
+				Roles: inv.Roles,
+			},
+			Data: discordgo.ModalSubmitInteractionData{
+				CustomID:   customID,
+				Components: rows,
+			},
+		},
+	}
+}
+
+// Message builds a MessageCreate for a message the user posts in the invocation's channel
+func (inv Invocation) Message(content string) *discordgo.MessageCreate {
+	return &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			ID:        "message-from-" + inv.User.ID,
+			ChannelID: inv.ChannelID,
+			GuildID:   inv.GuildID,
+			Author:    inv.User,
+			Content:   content,
+		},
+	}
+}
+
+// resolveOptions replaces user, channel and role values with their IDs and records them as resolved
+func resolveOptions(options []Option, resolved *discordgo.ApplicationCommandInteractionDataResolved) {
+	for _, opt := range options {
+		switch value := opt.Value.(type) {
+		case *discordgo.User:
+			resolved.Users[value.ID] = value
+			opt.Value = value.ID
+		case *discordgo.Channel:
+			resolved.Channels[value.ID] = value
+			opt.Value = value.ID
+		case *discordgo.Role:
+			resolved.Roles[value.ID] = value
+			opt.Value = value.ID
+		}
+		resolveOptions(opt.Options, resolved)
+	}
+}
+
+// SubCommand builds a subcommand option
+func SubCommand(name string, options ...Option) Option {
+	return &discordgo.ApplicationCommandInteractionDataOption{
+		Name:    name,
+		Type:    discordgo.ApplicationCommandOptionSubCommand,
+		Options: options,
+	}
+}
+
+// SubCommandGroup builds a subcommand group option
+func SubCommandGroup(name string, options ...Option) Option {
+	return &discordgo.ApplicationCommandInteractionDataOption{
+		Name:    name,
+		Type:    discordgo.ApplicationCommandOptionSubCommandGroup,
+		Options: options,
+	}
+}
+
+// String builds a string option
+func String(name, value string) Option {
+	return &discordgo.ApplicationCommandInteractionDataOption{
+		Name:  name,
+		Type:  discordgo.ApplicationCommandOptionString,
+		Value: value,
+	}
+}
+
+// Int builds an integer option. Discord delivers integers as JSON numbers.
+func Int(name string, value int) Option {
+	return &discordgo.ApplicationCommandInteractionDataOption{
+		Name:  name,
+		Type:  discordgo.ApplicationCommandOptionInteger,
+		Value: float64(value),
+	}
+}
+
+// Number builds a number option
+func Number(name string, value float64) Option {
+	return &discordgo.ApplicationCommandInteractionDataOption{
+		Name:  name,
+		Type:  discordgo.ApplicationCommandOptionNumber,
+		Value: value,
+	}
+}
+
+// Bool builds a boolean option
+func Bool(name string, value bool) Option {
+	return &discordgo.ApplicationCommandInteractionDataOption{
+		Name:  name,
+		Type:  discordgo.ApplicationCommandOptionBoolean,
+		Value: value,
+	}
+}
+
+// User builds a user option
+func User(name string, user *discordgo.User) Option {
+	return &discordgo.ApplicationCommandInteractionDataOption{
+		Name:  name,
+		Type:  discordgo.ApplicationCommandOptionUser,
+		Value: user,
+	}
+}
+
+// Channel builds a channel option
+func Channel(name string, channel *discordgo.Channel) Option {
+	return &discordgo.ApplicationCommandInteractionDataOption{
+		Name:  name,
+		Type:  discordgo.ApplicationCommandOptionChannel,
+		Value: channel,
+	}
+}
+
+// Role builds a role option
+func Role(name string, role *discordgo.Role) Option {
+	return &discordgo.ApplicationCommandInteractionDataOption{
+		Name:  name,
+		Type:  discordgo.ApplicationCommandOptionRole,
+		Value: role,
+	}
+}
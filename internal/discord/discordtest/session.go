@@ -0,0 +1,301 @@
+// Package discordtest provides an in-memory Discord session and interaction
+// builders for testing command handlers, the scheduler and the voter.
+package discordtest
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Response is a recorded interaction response
+type Response struct {
+	Interaction *discordgo.Interaction
+	Response    *discordgo.InteractionResponse
+}
+
+// Edit is a recorded edit of an interaction's original response
+type Edit struct {
+	Interaction *discordgo.Interaction
+	Edit        *discordgo.WebhookEdit
+}
+
+// Message is a recorded message sent to a channel
+type Message struct {
+	ID        string
+	ChannelID string
+	Content   string
+	Embed     *discordgo.MessageEmbed
+}
+
+// Reaction is a recorded reaction added to a message
+type Reaction struct {
+	ChannelID string
+	MessageID string
+	Emoji     string
+}
+
+// Session is a fake discord.Session that records everything sent through it.
+// It is safe for concurrent use since some handlers notify users from goroutines.
+type Session struct {
+	mu sync.Mutex
+
+	responses []Response
+	edits     []Edit
+	followups []*discordgo.WebhookParams
+	messages  []Message
+	reactions []Reaction
+	created   []*discordgo.Channel
+	channels  map[string]*discordgo.Channel
+	guilds    map[string]*discordgo.Guild
+	closedDMs map[string]bool
+	nextID    int
+}
+
+var _ discord.Session = (*Session)(nil)
+
+// NewSession creates an empty fake session
+func NewSession() *Session {
+	return &Session{
+		channels:  make(map[string]*discordgo.Channel),
+		guilds:    make(map[string]*discordgo.Guild),
+		closedDMs: make(map[string]bool),
+	}
+}
+
+// AddGuild makes a guild (and its roles) known to the session
+func (f *Session) AddGuild(guild *discordgo.Guild) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.guilds[guild.ID] = guild
+}
+
+// AddChannel makes an existing channel known to the session
+func (f *Session) AddChannel(channel *discordgo.Channel) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.channels[channel.ID] = channel
+}
+
+// CloseDMs makes sending DMs to the user fail, like a user who only accepts DMs from friends
+func (f *Session) CloseDMs(userID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closedDMs[userID] = true
+}
+
+// Responses returns every interaction response in the order they were sent
+func (f *Session) Responses() []Response {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Response(nil), f.responses...)
+}
+
+// LastResponse returns the most recent interaction response, or nil if there is none
+func (f *Session) LastResponse() *discordgo.InteractionResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.responses) == 0 {
+		return nil
+	}
+	return f.responses[len(f.responses)-1].Response
+}
+
+// EditFor returns the latest edit of an interaction's original response, or nil if it was never edited
+func (f *Session) EditFor(interaction *discordgo.Interaction) *discordgo.WebhookEdit {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for idx := len(f.edits) - 1; idx >= 0; idx-- {
+		if f.edits[idx].Interaction == interaction {
+			return f.edits[idx].Edit
+		}
+	}
+	return nil
+}
+
+// Followups returns every followup message in the order they were sent
+func (f *Session) Followups() []*discordgo.WebhookParams {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*discordgo.WebhookParams(nil), f.followups...)
+}
+
+// Messages returns every message sent to a channel
+func (f *Session) Messages() []Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Message(nil), f.messages...)
+}
+
+// MessagesIn returns the messages sent to a single channel
+func (f *Session) MessagesIn(channelID string) []Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []Message
+	for _, msg := range f.messages {
+		if msg.ChannelID == channelID {
+			out = append(out, msg)
+		}
+	}
+	return out
+}
+
+// DirectMessages returns the messages sent to a user's DM channel
+func (f *Session) DirectMessages(userID string) []Message {
+	return f.MessagesIn(dmChannelID(userID))
+}
+
+// Reactions returns every reaction added by the bot
+func (f *Session) Reactions() []Reaction {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Reaction(nil), f.reactions...)
+}
+
+// CreatedChannels returns the guild channels and threads created through the session
+func (f *Session) CreatedChannels() []*discordgo.Channel {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*discordgo.Channel(nil), f.created...)
+}
+
+// InteractionRespond records an interaction response
+func (f *Session) InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, options ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses = append(f.responses, Response{Interaction: interaction, Response: resp})
+	return nil
+}
+
+// InteractionResponseEdit records an edit of the original interaction response
+func (f *Session) InteractionResponseEdit(interaction *discordgo.Interaction, newresp *discordgo.WebhookEdit, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.edits = append(f.edits, Edit{Interaction: interaction, Edit: newresp})
+	message := &discordgo.Message{ID: f.newID("original"), ChannelID: interaction.ChannelID}
+	if newresp.Embeds != nil {
+		message.Embeds = *newresp.Embeds
+	}
+	return message, nil
+}
+
+// FollowupMessageCreate records a followup message
+func (f *Session) FollowupMessageCreate(interaction *discordgo.Interaction, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.followups = append(f.followups, data)
+	return &discordgo.Message{ID: f.newID("followup"), ChannelID: interaction.ChannelID, Content: data.Content, Embeds: data.Embeds}, nil
+}
+
+// ChannelMessageSendEmbed records an embed sent to a channel
+func (f *Session) ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if channel, ok := f.channels[channelID]; ok && channel.Type == discordgo.ChannelTypeDM && f.closedDMs[channel.Recipients[0].ID] {
+		return nil, fmt.Errorf("HTTP 403 Forbidden, {\"message\": \"Cannot send messages to this user\", \"code\": 50007}")
+	}
+	msg := Message{ID: f.newID("message"), ChannelID: channelID, Embed: embed}
+	f.messages = append(f.messages, msg)
+	return &discordgo.Message{ID: msg.ID, ChannelID: channelID, Embeds: []*discordgo.MessageEmbed{embed}}, nil
+}
+
+// MessageReactionAdd records a reaction
+func (f *Session) MessageReactionAdd(channelID, messageID, emojiID string, options ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reactions = append(f.reactions, Reaction{ChannelID: channelID, MessageID: messageID, Emoji: emojiID})
+	return nil
+}
+
+// Channel returns a channel previously added or created
+func (f *Session) Channel(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	channel, ok := f.channels[channelID]
+	if !ok {
+		return nil, fmt.Errorf("unknown channel %s", channelID)
+	}
+	return channel, nil
+}
+
+// UserChannelCreate returns a DM channel for the user
+func (f *Session) UserChannelCreate(recipientID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	channel := &discordgo.Channel{
+		ID:         dmChannelID(recipientID),
+		Type:       discordgo.ChannelTypeDM,
+		Recipients: []*discordgo.User{{ID: recipientID}},
+	}
+	f.channels[channel.ID] = channel
+	return channel, nil
+}
+
+// GuildChannelCreateComplex records a new guild channel
+func (f *Session) GuildChannelCreateComplex(guildID string, data discordgo.GuildChannelCreateData, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	channel := &discordgo.Channel{
+		ID:                   f.newID("channel"),
+		GuildID:              guildID,
+		Name:                 data.Name,
+		Type:                 data.Type,
+		Topic:                data.Topic,
+		ParentID:             data.ParentID,
+		PermissionOverwrites: data.PermissionOverwrites,
+	}
+	f.channels[channel.ID] = channel
+	f.created = append(f.created, channel)
+	return channel, nil
+}
+
+// ThreadStartComplex records a new thread under a channel
+func (f *Session) ThreadStartComplex(channelID string, data *discordgo.ThreadStart, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	channel := &discordgo.Channel{
+		ID:       f.newID("thread"),
+		Name:     data.Name,
+		Type:     data.Type,
+		ParentID: channelID,
+	}
+	if parent, ok := f.channels[channelID]; ok {
+		channel.GuildID = parent.GuildID
+	}
+	f.channels[channel.ID] = channel
+	f.created = append(f.created, channel)
+	return channel, nil
+}
+
+// Guild returns a guild previously added with AddGuild
+func (f *Session) Guild(guildID string, options ...discordgo.RequestOption) (*discordgo.Guild, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	guild, ok := f.guilds[guildID]
+	if !ok {
+		return nil, fmt.Errorf("unknown guild %s", guildID)
+	}
+	return guild, nil
+}
+
+// GuildRoles returns the roles of a guild previously added with AddGuild
+func (f *Session) GuildRoles(guildID string, options ...discordgo.RequestOption) ([]*discordgo.Role, error) {
+	guild, err := f.Guild(guildID)
+	if err != nil {
+		return nil, err
+	}
+	return guild.Roles, nil
+}
+
+// newID returns a unique snowflake-like ID. Callers must hold f.mu.
+func (f *Session) newID(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s-%d", prefix, f.nextID)
+}
+
+// dmChannelID is the ID of the fake DM channel for a user
+func dmChannelID(userID string) string {
+	return "dm-" + userID
+}
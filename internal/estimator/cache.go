@@ -0,0 +1,66 @@
+package estimator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strings"
+)
+
+// Cache stores estimates so the same goal isn't scored twice.
+// *database.Store implements it.
+type Cache interface {
+	GetPointEstimate(key string) (estimate Estimate, ok bool, err error)
+	SavePointEstimate(key string, estimate Estimate) error
+}
+
+// CacheKey identifies a goal by its normalized title and description, so
+// differences in case, spacing and trailing punctuation share an estimate
+func CacheKey(title, description string) string {
+	sum := sha256.Sum256([]byte(normalize(title) + "\x00" + normalize(description)))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalize lowercases text, collapses whitespace and trims surrounding punctuation
+func normalize(text string) string {
+	text = strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	return strings.Trim(text, ".!?,;: ")
+}
+
+// cached answers repeated goals from a Cache before asking the next estimator
+type cached struct {
+	next  Estimator
+	cache Cache
+}
+
+// Cached wraps an estimator so estimates are saved to and served from cache
+func Cached(next Estimator, cache Cache) Estimator {
+	return &cached{next: next, cache: cache}
+}
+
+// Name identifies the wrapped estimator
+func (c *cached) Name() string {
+	return c.next.Name()
+}
+
+// Estimate returns a cached estimate when there is one, otherwise asks the next estimator and saves its answer
+func (c *cached) Estimate(ctx context.Context, title, description string) (Estimate, error) {
+	key := CacheKey(title, description)
+	estimate, ok, err := c.cache.GetPointEstimate(key)
+	if err != nil {
+		log.Printf("Error reading point estimate cache: %v", err)
+	} else if ok {
+		return estimate, nil
+	}
+
+	estimate, err = c.next.Estimate(ctx, title, description)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	if err := c.cache.SavePointEstimate(key, estimate); err != nil {
+		log.Printf("Error saving point estimate: %v", err)
+	}
+	return estimate, nil
+}
@@ -0,0 +1,117 @@
+// Package estimator scores how much effort a Focus Period goal takes.
+package estimator
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// Point bounds for an estimate
+const (
+	MinPoints     = 1
+	MaxPoints     = 10
+	DefaultPoints = 5
+)
+
+// Providers that can be selected in Config
+const (
+	ProviderOpenAI           = "openai"
+	ProviderOpenAICompatible = "openai-compatible"
+	ProviderHeuristic        = "heuristic"
+)
+
+// Rationale explains an estimate along the three things it weighs.
+// Each part is a short phrase; any may be empty if the estimator gave no reason.
+type Rationale struct {
+	Time       string
+	Complexity string
+	Impact     string
+}
+
+// IsEmpty reports whether no reasons were given
+func (r Rationale) IsEmpty() bool {
+	return r.Time == "" && r.Complexity == "" && r.Impact == ""
+}
+
+// Estimate is the points a goal is worth and why
+type Estimate struct {
+	Points int
+	Rationale
+}
+
+// Estimator scores a goal from 1 to 10 based on its title and description
+type Estimator interface {
+	// Estimate returns the points a goal is worth and the reasons for them
+	Estimate(ctx context.Context, title, description string) (Estimate, error)
+	// Name identifies the estimator in logs
+	Name() string
+}
+
+// Config selects and configures an estimator
+type Config struct {
+	// Provider is one of the Provider constants. Empty picks OpenAI when an API key is set
+	// and the heuristic otherwise.
+	Provider string
+	// APIKey authenticates with OpenAI or an OpenAI-compatible server
+	APIKey string
+	// BaseURL is the API root of an OpenAI-compatible server
+	BaseURL string
+	// Model overrides DefaultOpenAIModel
+	Model string
+	// Cache, when set, keeps model estimates so repeated goals aren't sent again
+	Cache Cache
+	// Limits bound calls to the model
+	Limits Limits
+}
+
+// New creates the estimator selected by cfg.
+// Model-backed estimators are served from cfg.Cache first, called within cfg.Limits,
+// and fall back to the heuristic when the model fails or a guild is over its limits.
+func New(cfg Config) (Estimator, error) {
+	provider := cfg.Provider
+	if provider == "" {
+		provider = ProviderHeuristic
+		if cfg.APIKey != "" {
+			provider = ProviderOpenAI
+		}
+	}
+
+	var model Estimator
+	switch provider {
+	case ProviderOpenAI:
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("the %s estimator needs an API key", provider)
+		}
+		model = NewOpenAI(cfg.APIKey, "", cfg.Model)
+	case ProviderOpenAICompatible:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("the %s estimator needs a base URL", provider)
+		}
+		model = NewOpenAI(cfg.APIKey, cfg.BaseURL, cfg.Model)
+	case ProviderHeuristic:
+		log.Printf("Estimating goal points with %s", ProviderHeuristic)
+		return Heuristic{}, nil
+	default:
+		return nil, fmt.Errorf("unknown estimator provider %q (expected %s, %s or %s)", provider, ProviderOpenAI, ProviderOpenAICompatible, ProviderHeuristic)
+	}
+
+	estimator := Guarded(model, cfg.Limits)
+	if cfg.Cache != nil {
+		estimator = Cached(estimator, cfg.Cache)
+	}
+
+	log.Printf("Estimating goal points with %s", model.Name())
+	return WithFallback(estimator, Heuristic{}), nil
+}
+
+// clampPoints keeps an estimate within MinPoints and MaxPoints
+func clampPoints(points int) int {
+	if points < MinPoints {
+		return MinPoints
+	}
+	if points > MaxPoints {
+		return MaxPoints
+	}
+	return points
+}
@@ -0,0 +1,79 @@
+package estimator
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		want    string
+		wantErr bool
+	}{
+		{"no key falls back to heuristic", Config{}, "heuristic", false},
+		{"key picks OpenAI", Config{APIKey: "test-key"}, "openai (gpt-4o-mini)", false},
+		{"explicit heuristic ignores key", Config{Provider: ProviderHeuristic, APIKey: "test-key"}, "heuristic", false},
+		{"compatible server", Config{Provider: ProviderOpenAICompatible, BaseURL: "http://localhost:8080/v1", Model: "qwen2.5"}, "openai-compatible (qwen2.5)", false},
+		{"OpenAI without key", Config{Provider: ProviderOpenAI}, "", true},
+		{"compatible without base URL", Config{Provider: ProviderOpenAICompatible}, "", true},
+		{"unknown provider", Config{Provider: "magic"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimator, err := New(tt.cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %s", estimator.Name())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if estimator.Name() != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, estimator.Name())
+			}
+		})
+	}
+}
+
+func TestHeuristicEstimate(t *testing.T) {
+	tests := []struct {
+		title       string
+		description string
+		expected    int
+	}{
+		{"Reply to an email", "", 1},
+		{"Write a blog draft", "", 3},
+		{"Launch the pricing page", "", 7},
+		{"Build and launch the MVP", "", 9},
+		{"Interview five customers", "Find them through the waitlist, book calls and write up what they say about onboarding", 5},
+	}
+
+	for _, tt := range tests {
+		estimate, err := Heuristic{}.Estimate(context.Background(), tt.title, tt.description)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if estimate.Points != tt.expected {
+			t.Errorf("Expected %q to score %d, got %d", tt.title, tt.expected, estimate.Points)
+		}
+		if estimate.Time == "" || estimate.Complexity == "" || estimate.Impact == "" {
+			t.Errorf("Expected %q to be explained, got %+v", tt.title, estimate.Rationale)
+		}
+
+		// Estimates are deterministic
+		again, _ := Heuristic{}.Estimate(context.Background(), tt.title, tt.description)
+		if again != estimate {
+			t.Errorf("Expected %q to score the same twice, got %+v and %+v", tt.title, estimate, again)
+		}
+	}
+
+	estimate, _ := Heuristic{}.Estimate(context.Background(), "Build and launch the MVP", "")
+	if estimate.Time != "Sounds like days of work (build, launch, mvp)" {
+		t.Errorf("Expected the time rationale to name the heavy words, got %q", estimate.Time)
+	}
+}
@@ -0,0 +1,200 @@
+package estimator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a guild has used up its estimates for now
+var ErrRateLimited = errors.New("point estimate limit reached")
+
+// Limits bound how often and how long an estimator is called.
+// Zero values switch the corresponding limit off.
+type Limits struct {
+	// Timeout caps each attempt
+	Timeout time.Duration
+	// Retries is how many more attempts follow a failed one
+	Retries int
+	// Backoff is the wait before the first retry; it doubles for each retry after that
+	Backoff time.Duration
+	// GuildPerMinute caps estimates per guild in any one-minute window
+	GuildPerMinute int
+	// GuildPerDay caps estimates per guild each UTC day
+	GuildPerDay int
+}
+
+type guildKey struct{}
+
+// WithGuild records the guild an estimate is for, so per-guild limits can apply
+func WithGuild(ctx context.Context, guildID string) context.Context {
+	return context.WithValue(ctx, guildKey{}, guildID)
+}
+
+// guildFrom returns the guild recorded by WithGuild, or "" if there is none
+func guildFrom(ctx context.Context) string {
+	guildID, _ := ctx.Value(guildKey{}).(string)
+	return guildID
+}
+
+// guildUsage tracks one guild's recent estimates
+type guildUsage struct {
+	recent   []time.Time // Estimates in the last minute
+	day      string
+	dayCount int
+}
+
+// guarded applies Limits to an estimator
+type guarded struct {
+	next   Estimator
+	limits Limits
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+
+	mu     sync.Mutex
+	guilds map[string]*guildUsage
+}
+
+// Guarded wraps an estimator with per-attempt timeouts, retries with backoff and per-guild limits.
+// A guild over its limits gets ErrRateLimited without the estimator being called.
+func Guarded(next Estimator, limits Limits) Estimator {
+	return &guarded{
+		next:   next,
+		limits: limits,
+		now:    time.Now,
+		sleep:  sleepContext,
+		guilds: make(map[string]*guildUsage),
+	}
+}
+
+// Name identifies the wrapped estimator
+func (g *guarded) Name() string {
+	return g.next.Name()
+}
+
+// Estimate calls the wrapped estimator within the limits
+func (g *guarded) Estimate(ctx context.Context, title, description string) (Estimate, error) {
+	if !g.allow(guildFrom(ctx)) {
+		return Estimate{}, ErrRateLimited
+	}
+
+	var lastErr error
+	backoff := g.limits.Backoff
+	for attempt := 0; attempt <= g.limits.Retries; attempt++ {
+		if attempt > 0 {
+			if err := g.sleep(ctx, backoff); err != nil {
+				return Estimate{}, err
+			}
+			backoff *= 2
+		}
+
+		estimate, err := g.attempt(ctx, title, description)
+		if err == nil {
+			return estimate, nil
+		}
+		lastErr = err
+
+		// Give up once the caller stops waiting
+		if ctx.Err() != nil {
+			return Estimate{}, ctx.Err()
+		}
+		log.Printf("Point estimate attempt %d/%d with %s failed: %v", attempt+1, g.limits.Retries+1, g.next.Name(), err)
+	}
+
+	return Estimate{}, fmt.Errorf("point estimate failed after %d attempts: %w", g.limits.Retries+1, lastErr)
+}
+
+// attempt makes one call to the wrapped estimator under the per-attempt timeout
+func (g *guarded) attempt(ctx context.Context, title, description string) (Estimate, error) {
+	if g.limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.limits.Timeout)
+		defer cancel()
+	}
+	return g.next.Estimate(ctx, title, description)
+}
+
+// allow records an estimate for a guild, reporting false if it would exceed the guild's limits
+func (g *guarded) allow(guildID string) bool {
+	if g.limits.GuildPerMinute <= 0 && g.limits.GuildPerDay <= 0 {
+		return true
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	usage, ok := g.guilds[guildID]
+	if !ok {
+		usage = &guildUsage{}
+		g.guilds[guildID] = usage
+	}
+
+	now := g.now()
+	cutoff := now.Add(-time.Minute)
+	recent := usage.recent[:0]
+	for _, at := range usage.recent {
+		if at.After(cutoff) {
+			recent = append(recent, at)
+		}
+	}
+	usage.recent = recent
+
+	day := now.UTC().Format("2006-01-02")
+	if usage.day != day {
+		usage.day = day
+		usage.dayCount = 0
+	}
+
+	if g.limits.GuildPerMinute > 0 && len(usage.recent) >= g.limits.GuildPerMinute {
+		return false
+	}
+	if g.limits.GuildPerDay > 0 && usage.dayCount >= g.limits.GuildPerDay {
+		return false
+	}
+
+	usage.recent = append(usage.recent, now)
+	usage.dayCount++
+	return true
+}
+
+// sleepContext waits for d, returning early with the context's error if it is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// withFallback answers from a second estimator when the first fails
+type withFallback struct {
+	primary  Estimator
+	fallback Estimator
+}
+
+// WithFallback wraps an estimator so failures, including rate limits, are answered by fallback instead
+func WithFallback(primary, fallback Estimator) Estimator {
+	return &withFallback{primary: primary, fallback: fallback}
+}
+
+// Name identifies the primary estimator
+func (f *withFallback) Name() string {
+	return f.primary.Name()
+}
+
+// Estimate asks the primary estimator, then the fallback if that fails
+func (f *withFallback) Estimate(ctx context.Context, title, description string) (Estimate, error) {
+	estimate, err := f.primary.Estimate(ctx, title, description)
+	if err == nil {
+		return estimate, nil
+	}
+
+	log.Printf("Falling back to %s for point estimate: %v", f.fallback.Name(), err)
+	return f.fallback.Estimate(ctx, title, description)
+}
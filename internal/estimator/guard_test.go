@@ -0,0 +1,171 @@
+package estimator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// stubEstimator returns queued results and counts its calls
+type stubEstimator struct {
+	results []error
+	points  int
+	calls   int
+	block   bool
+}
+
+func (e *stubEstimator) Name() string { return "stub" }
+
+func (e *stubEstimator) Estimate(ctx context.Context, title, description string) (Estimate, error) {
+	e.calls++
+	if e.block {
+		<-ctx.Done()
+		return Estimate{}, ctx.Err()
+	}
+	if len(e.results) > 0 {
+		err := e.results[0]
+		e.results = e.results[1:]
+		if err != nil {
+			return Estimate{}, err
+		}
+	}
+	return Estimate{Points: e.points, Rationale: Rationale{Time: "stubbed"}}, nil
+}
+
+// newTestGuard builds a guarded estimator on a fake clock that records backoff waits
+func newTestGuard(next Estimator, limits Limits, clock *time.Time, waits *[]time.Duration) *guarded {
+	g := Guarded(next, limits).(*guarded)
+	g.now = func() time.Time { return *clock }
+	g.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	return g
+}
+
+func TestGuardedRetriesWithBackoff(t *testing.T) {
+	clock := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	var waits []time.Duration
+	failure := errors.New("server error")
+	next := &stubEstimator{results: []error{failure, failure}, points: 7}
+	g := newTestGuard(next, Limits{Retries: 2, Backoff: time.Second}, &clock, &waits)
+
+	estimate, err := g.Estimate(context.Background(), "Ship it", "")
+	if err != nil {
+		t.Fatalf("Expected the third attempt to succeed, got %v", err)
+	}
+	if estimate.Points != 7 || next.calls != 3 {
+		t.Errorf("Expected 7 points after 3 calls, got %d after %d", estimate.Points, next.calls)
+	}
+	if len(waits) != 2 || waits[0] != time.Second || waits[1] != 2*time.Second {
+		t.Errorf("Expected backoff of 1s then 2s, got %v", waits)
+	}
+
+	next = &stubEstimator{results: []error{failure, failure, failure}}
+	g = newTestGuard(next, Limits{Retries: 2, Backoff: time.Second}, &clock, &waits)
+	if _, err := g.Estimate(context.Background(), "Ship it", ""); !errors.Is(err, failure) {
+		t.Errorf("Expected the last failure once retries run out, got %v", err)
+	}
+	if next.calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", next.calls)
+	}
+}
+
+func TestGuardedTimesOutEachAttempt(t *testing.T) {
+	next := &stubEstimator{block: true}
+	g := Guarded(next, Limits{Timeout: 10 * time.Millisecond, Retries: 1, Backoff: time.Millisecond})
+
+	_, err := g.Estimate(context.Background(), "Ship it", "")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+	}
+	if next.calls != 2 {
+		t.Errorf("Expected a timed-out attempt to be retried, got %d calls", next.calls)
+	}
+}
+
+func TestGuardedGuildLimits(t *testing.T) {
+	clock := time.Date(2026, 3, 2, 23, 58, 0, 0, time.UTC)
+	var waits []time.Duration
+	next := &stubEstimator{points: 4}
+	g := newTestGuard(next, Limits{GuildPerMinute: 2, GuildPerDay: 3}, &clock, &waits)
+	guildA := WithGuild(context.Background(), "guild-a")
+	guildB := WithGuild(context.Background(), "guild-b")
+
+	for n := 0; n < 2; n++ {
+		if _, err := g.Estimate(guildA, "Goal", ""); err != nil {
+			t.Fatalf("Estimate %d: unexpected error %v", n+1, err)
+		}
+	}
+	if _, err := g.Estimate(guildA, "Goal", ""); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected the per-minute limit, got %v", err)
+	}
+	if _, err := g.Estimate(guildB, "Goal", ""); err != nil {
+		t.Errorf("Expected other guilds to be unaffected, got %v", err)
+	}
+
+	// A minute later the window has room again, but the daily budget allows only one more
+	clock = clock.Add(61 * time.Second)
+	if _, err := g.Estimate(guildA, "Goal", ""); err != nil {
+		t.Errorf("Expected the window to reopen, got %v", err)
+	}
+	clock = clock.Add(10 * time.Second)
+	if _, err := g.Estimate(guildA, "Goal", ""); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected the daily budget to be spent, got %v", err)
+	}
+
+	// The budget resets at the start of the next UTC day
+	clock = time.Date(2026, 3, 3, 0, 1, 0, 0, time.UTC)
+	if _, err := g.Estimate(guildA, "Goal", ""); err != nil {
+		t.Errorf("Expected a fresh budget on a new day, got %v", err)
+	}
+	if next.calls != 5 {
+		t.Errorf("Expected limited estimates to skip the model, got %d calls", next.calls)
+	}
+}
+
+// memoryCache is an in-memory Cache
+type memoryCache map[string]Estimate
+
+func (c memoryCache) GetPointEstimate(key string) (Estimate, bool, error) {
+	estimate, ok := c[key]
+	return estimate, ok, nil
+}
+
+func (c memoryCache) SavePointEstimate(key string, estimate Estimate) error {
+	c[key] = estimate
+	return nil
+}
+
+func TestCachedNormalizesText(t *testing.T) {
+	next := &stubEstimator{points: 6}
+	estimator := Cached(next, memoryCache{})
+
+	for _, title := range []string{"Launch the landing page", "  launch THE landing   page!"} {
+		estimate, err := estimator.Estimate(context.Background(), title, "")
+		if err != nil || estimate.Points != 6 || estimate.Time != "stubbed" {
+			t.Fatalf("Expected 6 points with the stubbed rationale, got %+v (%v)", estimate, err)
+		}
+	}
+	if next.calls != 1 {
+		t.Errorf("Expected the reworded goal to be served from cache, got %d calls", next.calls)
+	}
+
+	if CacheKey("Launch", "") == CacheKey("Launch", "with a waitlist") {
+		t.Error("Expected the description to be part of the cache key")
+	}
+}
+
+func TestWithFallback(t *testing.T) {
+	primary := &stubEstimator{results: []error{ErrRateLimited}}
+	estimator := WithFallback(primary, Heuristic{})
+
+	estimate, err := estimator.Estimate(context.Background(), "Reply to an email", "")
+	if err != nil {
+		t.Fatalf("Expected the fallback to answer, got %v", err)
+	}
+	if estimate.Points != 1 {
+		t.Errorf("Expected the heuristic's 1 point, got %d", estimate.Points)
+	}
+}
@@ -0,0 +1,78 @@
+package estimator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Heuristic estimates points from keywords and length without calling a model.
+// The same goal always scores the same, so it suits offline runs and CI.
+type Heuristic struct{}
+
+// heavyWords mark goals that usually take days of work
+var heavyWords = map[string]bool{
+	"build": true, "launch": true, "ship": true, "implement": true, "migrate": true,
+	"redesign": true, "rewrite": true, "integrate": true, "integration": true, "hire": true,
+	"raise": true, "fundraise": true, "mvp": true, "platform": true, "architecture": true,
+	"refactor": true, "automate": true, "onboarding": true, "pricing": true, "campaign": true,
+}
+
+// lightWords mark goals that usually take an hour or two
+var lightWords = map[string]bool{
+	"email": true, "tweet": true, "post": true, "call": true, "fix": true,
+	"update": true, "review": true, "read": true, "reply": true, "schedule": true,
+	"book": true, "send": true, "check": true, "tweak": true, "typo": true,
+}
+
+// Name identifies the estimator in logs
+func (Heuristic) Name() string {
+	return ProviderHeuristic
+}
+
+// Estimate scores a goal from 1 to 10: a middling base score, raised for
+// goals that sound like big pieces of work or take many words to describe,
+// and lowered for quick chores
+func (Heuristic) Estimate(_ context.Context, title, description string) (Estimate, error) {
+	words := strings.FieldsFunc(strings.ToLower(title+" "+description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	points := 3
+	var heavy, light []string
+	for _, word := range words {
+		switch {
+		case heavyWords[word]:
+			heavy = append(heavy, word)
+		case lightWords[word]:
+			light = append(light, word)
+		}
+	}
+	points += min(len(heavy), 3) * 2
+	points -= min(len(light), 2)
+
+	var rationale Rationale
+	switch {
+	case len(heavy) > 0:
+		rationale.Time = fmt.Sprintf("Sounds like days of work (%s)", strings.Join(heavy, ", "))
+	case len(light) > 0:
+		rationale.Time = fmt.Sprintf("Sounds like a quick task (%s)", strings.Join(light, ", "))
+	default:
+		rationale.Time = "No strong signal on time"
+	}
+
+	switch {
+	case len(words) > 25:
+		points += 2
+		rationale.Complexity = "Long description, many moving parts"
+	case len(words) > 12:
+		points++
+		rationale.Complexity = "Some detail to work through"
+	default:
+		rationale.Complexity = "Short and self-contained"
+	}
+	rationale.Impact = "Not judged by the offline heuristic"
+
+	return Estimate{Points: clampPoints(points), Rationale: rationale}, nil
+}
@@ -0,0 +1,160 @@
+package estimator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	goopenai "github.com/sashabaranov/go-openai"
+)
+
+// DefaultOpenAIModel is the model used unless another is configured
+const DefaultOpenAIModel = goopenai.GPT4oMini
+
+// OpenAI estimates points with a chat completion model, either from OpenAI
+// or from any server exposing an OpenAI-compatible API
+type OpenAI struct {
+	client *goopenai.Client
+	model  string
+	name   string
+}
+
+// NewOpenAI creates an estimator for the OpenAI API.
+// baseURL may point at an OpenAI-compatible server such as a local model; leave it empty for OpenAI itself.
+func NewOpenAI(apiKey, baseURL, model string) *OpenAI {
+	config := goopenai.DefaultConfig(apiKey)
+	name := ProviderOpenAI
+	if baseURL != "" {
+		config.BaseURL = strings.TrimRight(baseURL, "/")
+		name = ProviderOpenAICompatible
+	}
+	if model == "" {
+		model = DefaultOpenAIModel
+	}
+
+	return &OpenAI{
+		client: goopenai.NewClientWithConfig(config),
+		model:  model,
+		name:   name,
+	}
+}
+
+// Name identifies the provider and model in logs
+func (o *OpenAI) Name() string {
+	return fmt.Sprintf("%s (%s)", o.name, o.model)
+}
+
+// Estimate asks the model to estimate task difficulty and assign points (1-10 scale) with its reasons
+func (o *OpenAI) Estimate(ctx context.Context, taskTitle, taskDescription string) (Estimate, error) {
+	// Build the prompt
+	prompt := fmt.Sprintf(`You are a task difficulty estimator for solo founders and entrepreneurs.
+Analyze the following task and assign a difficulty score from 1-10 based on:
+- Time investment required (1=<1hr, 5=1-2 days, 10=1+ weeks)
+- Complexity and skill required
+- Impact on business outcomes
+
+Task Title: %s
+Task Description: %s
+
+Respond with ONLY a JSON object, nothing else, in this form:
+{"points": <1-10>, "time": "<why, under 12 words>", "complexity": "<why, under 12 words>", "impact": "<why, under 12 words>"}`, taskTitle, taskDescription)
+
+	req := goopenai.ChatCompletionRequest{
+		Model: o.model,
+		Messages: []goopenai.ChatCompletionMessage{
+			{
+				Role:    goopenai.ChatMessageRoleUser,
+				Content: prompt,
+			},
+		},
+		Temperature: 0.3, // Lower temperature for more consistent results
+		MaxTokens:   150, // A score and three short reasons
+	}
+
+	resp, err := o.client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return Estimate{}, fmt.Errorf("%s API error: %w", o.name, err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return Estimate{}, fmt.Errorf("no response from %s", o.name)
+	}
+
+	content := strings.TrimSpace(resp.Choices[0].Message.Content)
+	estimate, err := parseEstimate(content)
+	if err != nil {
+		log.Printf("Failed to parse %s response '%s': %v", o.name, content, err)
+		// Fallback to default
+		return Estimate{Points: DefaultPoints}, nil
+	}
+
+	log.Printf("%s calculated %d points for task: %s", o.Name(), estimate.Points, taskTitle)
+	return estimate, nil
+}
+
+// maxReasonLength caps each part of a model's rationale so it fits in /focus list
+const maxReasonLength = 100
+
+// parseEstimate reads the model's JSON answer. Models that ignore the format
+// and answer with a bare number still get scored, just without reasons.
+func parseEstimate(content string) (Estimate, error) {
+	start, end := strings.Index(content, "{"), strings.LastIndex(content, "}")
+	if start >= 0 && end > start {
+		var answer struct {
+			Points     json.Number `json:"points"`
+			Time       string      `json:"time"`
+			Complexity string      `json:"complexity"`
+			Impact     string      `json:"impact"`
+		}
+		if err := json.Unmarshal([]byte(content[start:end+1]), &answer); err == nil {
+			if points, err := answer.Points.Float64(); err == nil {
+				return Estimate{
+					Points: clampPoints(int(math.Round(points))),
+					Rationale: Rationale{
+						Time:       shortenReason(answer.Time),
+						Complexity: shortenReason(answer.Complexity),
+						Impact:     shortenReason(answer.Impact),
+					},
+				}, nil
+			}
+		}
+	}
+
+	points, err := extractPoints(content)
+	if err != nil {
+		return Estimate{}, err
+	}
+	return Estimate{Points: clampPoints(points)}, nil
+}
+
+// shortenReason tidies one part of a rationale and cuts it to maxReasonLength
+func shortenReason(reason string) string {
+	reason = strings.Join(strings.Fields(reason), " ")
+	if runes := []rune(reason); len(runes) > maxReasonLength {
+		reason = strings.TrimSpace(string(runes[:maxReasonLength-1])) + "…"
+	}
+	return reason
+}
+
+// extractPoints extracts a number from the model's response
+func extractPoints(content string) (int, error) {
+	// Try to extract any number from the response
+	re := regexp.MustCompile(`\d+`)
+	match := re.FindString(content)
+
+	if match == "" {
+		return 0, fmt.Errorf("no number found in response")
+	}
+
+	points, err := strconv.Atoi(match)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert to integer: %w", err)
+	}
+
+	return points, nil
+}
@@ -0,0 +1,107 @@
+package estimator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractPoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+		hasError bool
+	}{
+		{
+			name:     "Simple number",
+			input:    "5",
+			expected: 5,
+			hasError: false,
+		},
+		{
+			name:     "Number with text",
+			input:    "The difficulty is 7 out of 10",
+			expected: 7,
+			hasError: false,
+		},
+		{
+			name:     "Number at end",
+			input:    "Difficulty: 8",
+			expected: 8,
+			hasError: false,
+		},
+		{
+			name:     "Two-digit number",
+			input:    "10",
+			expected: 10,
+			hasError: false,
+		},
+		{
+			name:     "No number",
+			input:    "Very difficult",
+			expected: 0,
+			hasError: true,
+		},
+		{
+			name:     "Multiple numbers (takes first)",
+			input:    "Between 3 and 8",
+			expected: 3,
+			hasError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := extractPoints(tt.input)
+
+			if tt.hasError && err == nil {
+				t.Errorf("Expected error but got none")
+			}
+
+			if !tt.hasError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseEstimate(t *testing.T) {
+	estimate, err := parseEstimate("Sure! ```json\n{\"points\": 7, \"time\": \"Two  days of work\", \"complexity\": \"Needs payments integration\", \"impact\": \"Unlocks revenue\"}\n```")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := Estimate{Points: 7, Rationale: Rationale{Time: "Two days of work", Complexity: "Needs payments integration", Impact: "Unlocks revenue"}}
+	if estimate != want {
+		t.Errorf("Expected %+v, got %+v", want, estimate)
+	}
+
+	// Quoted and out-of-range scores are still read and clamped
+	if estimate, _ := parseEstimate(`{"points": "14", "time": "` + strings.Repeat("a", 150) + `"}`); estimate.Points != MaxPoints || len([]rune(estimate.Time)) != maxReasonLength {
+		t.Errorf("Expected a clamped score and shortened reason, got %d and %d runes", estimate.Points, len([]rune(estimate.Time)))
+	}
+
+	// A bare number is scored without reasons
+	estimate, err = parseEstimate("6")
+	if err != nil || estimate.Points != 6 || !estimate.IsEmpty() {
+		t.Errorf("Expected 6 points without reasons, got %+v (%v)", estimate, err)
+	}
+
+	if _, err := parseEstimate("Very difficult"); err == nil {
+		t.Error("Expected an error without a score")
+	}
+}
+
+func TestNewOpenAI(t *testing.T) {
+	if name := NewOpenAI("test-key", "", "").Name(); name != "openai (gpt-4o-mini)" {
+		t.Errorf("Expected the default OpenAI model, got %q", name)
+	}
+
+	local := NewOpenAI("", "http://localhost:11434/v1/", "llama3.1")
+	if name := local.Name(); name != "openai-compatible (llama3.1)" {
+		t.Errorf("Expected a compatible estimator for a base URL, got %q", name)
+	}
+}
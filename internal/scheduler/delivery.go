@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bwmarrin/discordgo"
+)
+
+// notificationRoute decides whether a reminder should go to a user now and how.
+// Reminders due during quiet hours are held back rather than claimed, so they
+// go out on the first check after quiet hours end.
+func (s *Scheduler) notificationRoute(feature database.ReminderFeature, userID uint, guildID, channelID string, local time.Time) (database.Delivery, bool) {
+	settings, err := s.store.GetNotificationSettings(userID, guildID)
+	if err != nil {
+		log.Printf("Error loading notification settings for user %d: %v", userID, err)
+		return "", false
+	}
+
+	delivery := settings.DeliveryFor(feature)
+	switch {
+	case delivery == database.DeliveryOff:
+		return delivery, false
+	case delivery == database.DeliveryChannel && channelID == "":
+		return delivery, false
+	case settings.InQuietHours(local.Hour()):
+		return delivery, false
+	}
+
+	return delivery, true
+}
+
+// deliver sends a reminder by DM or to the channel.
+// A DM that can't be delivered, e.g. because the user closed their DMs,
+// falls back to the channel.
+func (s *Scheduler) deliver(delivery database.Delivery, discordID, channelID string, embed *discordgo.MessageEmbed) error {
+	if delivery == database.DeliveryDM {
+		err := s.sendDM(discordID, embed)
+		if err == nil {
+			return nil
+		}
+		if channelID == "" {
+			return err
+		}
+		log.Printf("Could not DM user %s, falling back to channel %s: %v", discordID, channelID, err)
+	}
+
+	_, err := s.session.ChannelMessageSendEmbed(channelID, embed)
+	return err
+}
+
+// sendDM sends an embed to a user's DMs
+func (s *Scheduler) sendDM(discordID string, embed *discordgo.MessageEmbed) error {
+	channel, err := s.session.UserChannelCreate(discordID)
+	if err != nil {
+		return fmt.Errorf("failed to open DM: %w", err)
+	}
+
+	if _, err := s.session.ChannelMessageSendEmbed(channel.ID, embed); err != nil {
+		return fmt.Errorf("failed to send DM: %w", err)
+	}
+
+	return nil
+}
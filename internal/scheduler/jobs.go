@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Job is a named piece of recurring work.
+// Run must only return an error when nothing was delivered, because a failed
+// run is retried on the next check within the same period.
+type Job struct {
+	Name        string
+	Description string
+	Schedule    Schedule
+	Run         func(now time.Time) error
+}
+
+// Schedule maps a point in time to the period it belongs to and when that period's run is due
+type Schedule interface {
+	Period(now time.Time) (key string, due time.Time)
+	String() string
+}
+
+// hourlySchedule runs once per clock hour
+type hourlySchedule struct{}
+
+// Hourly returns a schedule that is due at the start of every hour.
+// It suits jobs that deliver in each member's own timezone and claim
+// every delivery individually.
+func Hourly() Schedule {
+	return hourlySchedule{}
+}
+
+func (hourlySchedule) Period(now time.Time) (string, time.Time) {
+	return now.Format("2006-01-02T15"), now.Truncate(time.Hour)
+}
+
+func (hourlySchedule) String() string {
+	return "hourly"
+}
+
+// dailySchedule runs once per calendar day from a fixed hour
+type dailySchedule struct {
+	hour int
+}
+
+// Daily returns a schedule that is due every day from the given hour
+func Daily(hour int) Schedule {
+	return dailySchedule{hour: hour}
+}
+
+func (d dailySchedule) Period(now time.Time) (string, time.Time) {
+	year, month, day := now.Date()
+	return now.Format("2006-01-02"), time.Date(year, month, day, d.hour, 0, 0, 0, now.Location())
+}
+
+func (d dailySchedule) String() string {
+	return fmt.Sprintf("daily at %02d:00", d.hour)
+}
+
+// monthlySchedule runs once per calendar month from a given day and hour
+type monthlySchedule struct {
+	day   func(now time.Time) int
+	hour  int
+	label string
+}
+
+// Monthly returns a schedule that is due from the given day of each month
+func Monthly(day, hour int) Schedule {
+	return monthlySchedule{
+		day:   func(time.Time) int { return day },
+		hour:  hour,
+		label: fmt.Sprintf("monthly on day %d at %02d:00", day, hour),
+	}
+}
+
+// MonthlyBeforeEnd returns a schedule that is due a number of days before each month ends
+func MonthlyBeforeEnd(days, hour int) Schedule {
+	return monthlySchedule{
+		day:   func(now time.Time) int { return daysInCurrentMonth(now) - days },
+		hour:  hour,
+		label: fmt.Sprintf("monthly %d days before month end at %02d:00", days, hour),
+	}
+}
+
+func (m monthlySchedule) Period(now time.Time) (string, time.Time) {
+	year, month, _ := now.Date()
+	return now.Format("2006-01"), time.Date(year, month, m.day(now), m.hour, 0, 0, 0, now.Location())
+}
+
+func (m monthlySchedule) String() string {
+	return m.label
+}
+
+// registerJobs records every job in the database so admins can see them
+func (s *Scheduler) registerJobs() {
+	for _, job := range s.jobs {
+		if err := s.store.RegisterScheduledJob(job.Name, job.Description, job.Schedule.String()); err != nil {
+			log.Printf("Error registering job %s: %v", job.Name, err)
+		}
+	}
+}
+
+// runDueJobs runs every job whose current period is due and not yet delivered.
+// Because only the current period is considered, a job missed during downtime
+// runs once as soon as the scheduler is back.
+func (s *Scheduler) runDueJobs(now time.Time) {
+	for _, job := range s.jobs {
+		period, due := job.Schedule.Period(now)
+		if now.Before(due) {
+			continue
+		}
+
+		claimed, err := s.store.ClaimScheduledJob(job.Name, period, now)
+		if err != nil {
+			log.Printf("Error claiming job %s: %v", job.Name, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		log.Printf("Running job %s for period %s", job.Name, period)
+		runErr := job.Run(now)
+		if runErr != nil {
+			log.Printf("Job %s failed: %v", job.Name, runErr)
+		}
+
+		if err := s.store.FinishScheduledJob(job.Name, period, time.Now(), runErr); err != nil {
+			log.Printf("Error recording job %s: %v", job.Name, err)
+		}
+	}
+}
+
+// claimLocal claims a delivery for one recipient once their local clock reaches
+// hour. The period is local formatted with layout, so a "2006-01-02" layout
+// delivers at most once per local day and "2006-01" once per local month.
+func (s *Scheduler) claimLocal(kind string, userID uint, guildID string, local time.Time, hour int, layout string) bool {
+	if local.Hour() < hour {
+		return false
+	}
+
+	claimed, err := s.store.ClaimReminder(kind, userID, guildID, local.Format(layout))
+	if err != nil {
+		log.Printf("Error claiming %s for user %d in guild %s: %v", kind, userID, guildID, err)
+		return false
+	}
+
+	return claimed
+}
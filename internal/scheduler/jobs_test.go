@@ -0,0 +1,482 @@
+package scheduler
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord/discordtest"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/estimator"
+)
+
+func newTestScheduler(t *testing.T, jobs ...Job) *Scheduler {
+	t.Helper()
+
+	store, err := database.OpenInMemory()
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	s := New(discordtest.NewSession(), store, "channel-reminders", nil)
+	s.jobs = jobs
+	s.registerJobs()
+	return s
+}
+
+func TestSchedulePeriods(t *testing.T) {
+	now := time.Date(2026, 3, 15, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		schedule Schedule
+		key      string
+		due      time.Time
+	}{
+		{Daily(9), "2026-03-15", time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)},
+		{Monthly(1, 10), "2026-03", time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{MonthlyBeforeEnd(7, 9), "2026-03", time.Date(2026, 3, 24, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		key, due := tt.schedule.Period(now)
+		if key != tt.key || !due.Equal(tt.due) {
+			t.Errorf("%s: expected %s due %v, got %s due %v", tt.schedule, tt.key, tt.due, key, due)
+		}
+	}
+}
+
+func TestRunDueJobsAtMostOncePerPeriod(t *testing.T) {
+	runs := 0
+	s := newTestScheduler(t, Job{
+		Name:     "daily",
+		Schedule: Daily(9),
+		Run: func(time.Time) error {
+			runs++
+			return nil
+		},
+	})
+
+	day := time.Date(2026, 3, 15, 0, 0, 0, 0, time.Local)
+
+	// Not due before 9:00
+	s.runDueJobs(day.Add(8 * time.Hour))
+	if runs != 0 {
+		t.Fatalf("Expected no runs before the due hour, got %d", runs)
+	}
+
+	// Caught up after a restart at 14:00, then never repeated that day
+	s.runDueJobs(day.Add(14 * time.Hour))
+	s.runDueJobs(day.Add(14*time.Hour + 30*time.Minute))
+	if runs != 1 {
+		t.Fatalf("Expected exactly one run for the day, got %d", runs)
+	}
+
+	// A second scheduler sharing the database cannot claim the same period
+	claimed, err := s.store.ClaimScheduledJob("daily", "2026-03-15", time.Now())
+	if err != nil || claimed {
+		t.Errorf("Expected period to be claimed already, got claimed=%v err=%v", claimed, err)
+	}
+
+	// The next day runs again
+	s.runDueJobs(day.Add(33 * time.Hour))
+	if runs != 2 {
+		t.Errorf("Expected a run on the next day, got %d runs", runs)
+	}
+
+	jobs, _ := s.store.GetScheduledJobs()
+	if len(jobs) != 1 || jobs[0].LastRunAt == nil || jobs[0].LastPeriod != "2026-03-16" {
+		t.Errorf("Expected recorded run for 2026-03-16, got %+v", jobs)
+	}
+}
+
+func TestRunDueJobsRetriesFailedRun(t *testing.T) {
+	attempts := 0
+	s := newTestScheduler(t, Job{
+		Name:     "flaky",
+		Schedule: Daily(9),
+		Run: func(time.Time) error {
+			attempts++
+			if attempts == 1 {
+				return errors.New("database unavailable")
+			}
+			return nil
+		},
+	})
+
+	now := time.Date(2026, 3, 15, 9, 5, 0, 0, time.Local)
+	s.runDueJobs(now)
+
+	jobs, _ := s.store.GetScheduledJobs()
+	if jobs[0].LastError != "database unavailable" || jobs[0].LastRunAt != nil {
+		t.Fatalf("Expected failure to be recorded, got %+v", jobs[0])
+	}
+
+	s.runDueJobs(now.Add(15 * time.Minute))
+	s.runDueJobs(now.Add(30 * time.Minute))
+	if attempts != 2 {
+		t.Errorf("Expected one retry after the failure, got %d attempts", attempts)
+	}
+
+	jobs, _ = s.store.GetScheduledJobs()
+	if jobs[0].LastError != "" || jobs[0].LastRunAt == nil {
+		t.Errorf("Expected successful retry to clear the error, got %+v", jobs[0])
+	}
+}
+
+// seedDayThreeFocusPeriod gives a Tokyo-based user a period on its third local day
+// and returns the start of that day in Tokyo
+func seedDayThreeFocusPeriod(t *testing.T, s *Scheduler) time.Time {
+	t.Helper()
+
+	user, _ := s.store.GetOrCreateUser("user-1", "guild-1", "alice")
+	if err := s.store.UpdateUserTimezone(user.ID, "Asia/Tokyo"); err != nil {
+		t.Fatalf("Failed to set timezone: %v", err)
+	}
+
+	// Move the period back so today is day 3 in Tokyo
+	period, _ := s.store.CreateFocusPeriod(user.ID, "guild-1")
+	s.store.DB().Model(period).Updates(map[string]interface{}{
+		"start_date": period.StartDate.AddDate(0, 0, -2),
+		"end_date":   period.EndDate.AddDate(0, 0, -2),
+	})
+	s.store.AddTask(period.ID, "Launch the beta", "", estimator.Estimate{Points: 5}, nil)
+
+	tokyo, _ := database.LoadTimezone("Asia/Tokyo")
+	return database.StartOfDay(time.Now().In(tokyo))
+}
+
+func TestFocusRemindersFollowUserTimezone(t *testing.T) {
+	s := newTestScheduler(t)
+	session := s.session.(*discordtest.Session)
+	morning := seedDayThreeFocusPeriod(t, s)
+
+	s.checkDailyReminders(morning.Add(8 * time.Hour))
+	if messages := session.MessagesIn("channel-reminders"); len(messages) != 0 {
+		t.Fatalf("Expected no reminder before 09:00 Tokyo time, got %d", len(messages))
+	}
+
+	s.checkDailyReminders(morning.Add(9 * time.Hour))
+	s.checkDailyReminders(morning.Add(10 * time.Hour))
+	messages := session.MessagesIn("channel-reminders")
+	if len(messages) != 1 {
+		t.Fatalf("Expected exactly one reminder for the day, got %d", len(messages))
+	}
+	if messages[0].Embed.Title != "Focus Period Reminder - Day 3" {
+		t.Errorf("Expected a day 3 reminder, got %q", messages[0].Embed.Title)
+	}
+}
+
+func TestRemindersUseGuildSettings(t *testing.T) {
+	s := newTestScheduler(t)
+	s.reminderChannel = ""
+	session := s.session.(*discordtest.Session)
+	morning := seedDayThreeFocusPeriod(t, s)
+
+	// Without a guild channel or fallback nothing is sent
+	s.checkDailyReminders(morning.Add(9 * time.Hour))
+	if messages := session.Messages(); len(messages) != 0 {
+		t.Fatalf("Expected no reminders without a channel, got %d", len(messages))
+	}
+
+	s.store.UpdateReminderChannel("guild-1", "channel-guild")
+	s.store.UpdateReminderHour("guild-1", 7)
+	s.store.SetReminderEnabled("guild-1", database.ReminderFeatureFocus, false)
+
+	s.checkDailyReminders(morning.Add(7 * time.Hour))
+	if messages := session.Messages(); len(messages) != 0 {
+		t.Fatalf("Expected no reminders while focus reminders are off, got %d", len(messages))
+	}
+
+	s.store.SetReminderEnabled("guild-1", database.ReminderFeatureFocus, true)
+	s.checkDailyReminders(morning.Add(7 * time.Hour))
+	if messages := session.MessagesIn("channel-guild"); len(messages) != 1 {
+		t.Errorf("Expected the reminder in the guild's channel at 07:00, got %d", len(messages))
+	}
+}
+
+func TestRemindersFollowNotificationPreferences(t *testing.T) {
+	s := newTestScheduler(t)
+	session := s.session.(*discordtest.Session)
+	morning := seedDayThreeFocusPeriod(t, s)
+	user, _ := s.store.GetOrCreateUser("user-1", "guild-1", "alice")
+
+	// Quiet hours hold the reminder back without using up the day's delivery
+	s.store.SetNotificationDelivery(user.ID, "guild-1", database.ReminderFeatureFocus, database.DeliveryDM)
+	s.store.SetQuietHours(user.ID, "guild-1", 6, 10)
+	s.checkDailyReminders(morning.Add(9 * time.Hour))
+	if messages := session.Messages(); len(messages) != 0 {
+		t.Fatalf("Expected no reminders during quiet hours, got %d", len(messages))
+	}
+
+	s.checkDailyReminders(morning.Add(10 * time.Hour))
+	if dms := session.DirectMessages("user-1"); len(dms) != 1 {
+		t.Fatalf("Expected the reminder by DM after quiet hours, got %d", len(dms))
+	}
+	if messages := session.MessagesIn("channel-reminders"); len(messages) != 0 {
+		t.Errorf("Expected nothing in the channel, got %d", len(messages))
+	}
+}
+
+func TestDMRemindersFallBackToChannel(t *testing.T) {
+	s := newTestScheduler(t)
+	session := s.session.(*discordtest.Session)
+	morning := seedDayThreeFocusPeriod(t, s)
+	user, _ := s.store.GetOrCreateUser("user-1", "guild-1", "alice")
+
+	s.store.SetNotificationDelivery(user.ID, "guild-1", database.ReminderFeatureFocus, database.DeliveryDM)
+	session.CloseDMs("user-1")
+
+	s.checkDailyReminders(morning.Add(9 * time.Hour))
+	if messages := session.MessagesIn("channel-reminders"); len(messages) != 1 {
+		t.Errorf("Expected the reminder in the channel when DMs are closed, got %d", len(messages))
+	}
+}
+
+func TestFocusRemindersFollowGuildCadence(t *testing.T) {
+	s := newTestScheduler(t)
+	session := s.session.(*discordtest.Session)
+
+	// On a 7-day period the default reminder points land on days 2, 4, 5, 6 and 7
+	s.store.UpdateFocusPeriodLength("guild-1", 7)
+	morning := seedDayThreeFocusPeriod(t, s)
+
+	s.checkDailyReminders(morning.Add(9 * time.Hour))
+	if messages := session.Messages(); len(messages) != 0 {
+		t.Fatalf("Expected no reminder on day 3 of a 7-day period, got %d", len(messages))
+	}
+
+	// Reminding at 40% moves a reminder onto day 3
+	s.store.UpdateFocusReminderPoints("guild-1", []int{40})
+	s.checkDailyReminders(morning.Add(9 * time.Hour))
+	messages := session.MessagesIn("channel-reminders")
+	if len(messages) != 1 {
+		t.Fatalf("Expected a reminder on day 3, got %d", len(messages))
+	}
+	if messages[0].Embed.Title != "Focus Period Reminder - Day 3" {
+		t.Errorf("Expected a day 3 reminder, got %q", messages[0].Embed.Title)
+	}
+}
+
+func TestCohortSprintRecapPostedOnce(t *testing.T) {
+	s := newTestScheduler(t)
+	session := s.session.(*discordtest.Session)
+
+	s.store.UpdateLeaderboardChannel("guild-1", "channel-leaderboard")
+	s.store.SetCohortSprints("guild-1", true)
+	sprint, err := s.store.CreateCohortSprint("guild-1", "Winter Sprint", time.Now().AddDate(0, 0, -7), 7)
+	if err != nil {
+		t.Fatalf("Failed to create sprint: %v", err)
+	}
+
+	// Two members finished the sprint together
+	for _, id := range []string{"user-1", "user-2"} {
+		user, _ := s.store.GetOrCreateUser(id, "guild-1", id)
+		period := database.FocusPeriod{UserID: user.ID, GuildID: "guild-1", StartDate: sprint.StartDate, EndDate: sprint.EndDate, SprintID: &sprint.ID}
+		s.store.DB().Create(&period)
+		s.store.AddPointsToUser(user.ID, period.ID, 10, "guild-1", period.StartDate, period.EndDate)
+	}
+
+	evening := database.StartOfDay(time.Now()).Add(23 * time.Hour)
+	s.checkEndedFocusPeriods(evening)
+	s.checkEndedFocusPeriods(evening)
+
+	messages := session.MessagesIn("channel-leaderboard")
+	if len(messages) != 1 {
+		t.Fatalf("Expected one recap for the cohort, got %d", len(messages))
+	}
+	if messages[0].Embed.Title != "🏁 Winter Sprint Recap" {
+		t.Errorf("Expected the cohort recap, got %q", messages[0].Embed.Title)
+	}
+	if messages[0].Embed.Fields[1].Value != "2" {
+		t.Errorf("Expected 2 participants, got %q", messages[0].Embed.Fields[1].Value)
+	}
+}
+
+func TestDueGoalReminderSentDayBefore(t *testing.T) {
+	s := newTestScheduler(t)
+	session := s.session.(*discordtest.Session)
+	morning := seedDayThreeFocusPeriod(t, s)
+
+	user, _ := s.store.GetOrCreateUser("user-1", "guild-1", "alice")
+	period, _ := s.store.GetCurrentFocusPeriod(user.ID, "guild-1")
+	dueTomorrow := morning.AddDate(0, 0, 1).Local()
+	dueLater := morning.AddDate(0, 0, 2).Local()
+	s.store.AddTask(period.ID, "Send the investor update", "", estimator.Estimate{Points: 3}, &dueTomorrow)
+	s.store.AddTask(period.ID, "Record the demo", "", estimator.Estimate{Points: 3}, &dueLater)
+
+	s.checkDueGoalReminders(morning.Add(8 * time.Hour))
+	if messages := session.MessagesIn("channel-reminders"); len(messages) != 0 {
+		t.Fatalf("Expected no reminder before the reminder hour, got %d", len(messages))
+	}
+
+	s.checkDueGoalReminders(morning.Add(9 * time.Hour))
+	s.checkDueGoalReminders(morning.Add(10 * time.Hour))
+	messages := session.MessagesIn("channel-reminders")
+	if len(messages) != 1 {
+		t.Fatalf("Expected one due reminder, got %d", len(messages))
+	}
+	description := messages[0].Embed.Description
+	if !strings.Contains(description, "Send the investor update") || strings.Contains(description, "Record the demo") {
+		t.Errorf("Expected only the goal due tomorrow, got %q", description)
+	}
+}
+
+func TestStandupThreadOpenedOncePerDay(t *testing.T) {
+	s := newTestScheduler(t)
+	session := s.session.(*discordtest.Session)
+
+	s.store.UpdateStandupThreadChannel("guild-1", "channel-standups")
+	s.store.UpdateReminderHour("guild-1", 8)
+
+	morning := time.Date(2026, 3, 16, 0, 0, 0, 0, time.Local)
+	s.openStandupThreads(morning.Add(7 * time.Hour))
+	if created := session.CreatedChannels(); len(created) != 0 {
+		t.Fatalf("Expected no thread before the reminder time, got %d", len(created))
+	}
+
+	s.openStandupThreads(morning.Add(8 * time.Hour))
+	s.openStandupThreads(morning.Add(9 * time.Hour))
+	created := session.CreatedChannels()
+	if len(created) != 1 {
+		t.Fatalf("Expected one standup thread for the day, got %d", len(created))
+	}
+	if created[0].ParentID != "channel-standups" || created[0].Name != "Standup · Mon Mar 16" {
+		t.Errorf("Expected the day's thread in the standup channel, got %+v", created[0])
+	}
+
+	thread, err := s.store.GetStandupThread(created[0].ID)
+	if err != nil || thread == nil || thread.Day != "2026-03-16" {
+		t.Fatalf("Expected the thread to be saved for 2026-03-16, got %+v (%v)", thread, err)
+	}
+	if messages := session.MessagesIn(created[0].ID); len(messages) != 1 || messages[0].Embed.Title != "☀️ Daily Standup" {
+		t.Errorf("Expected the standup prompt in the thread, got %+v", messages)
+	}
+}
+
+func TestStandupRemindersHonorRestDaysAndFreezes(t *testing.T) {
+	s := newTestScheduler(t)
+	session := s.session.(*discordtest.Session)
+	morning := seedDayThreeFocusPeriod(t, s)
+
+	user, _ := s.store.GetOrCreateUser("user-1", "guild-1", "alice")
+	threeDaysAgo := morning.AddDate(0, 0, -3).Add(10 * time.Hour)
+	streak := database.UserStreak{UserID: user.ID, GuildID: "guild-1", CurrentStreak: 5, FreezeTokens: 1, LastStandupDate: &threeDaysAgo}
+	s.store.DB().Create(&streak)
+
+	// Two missed days and one freeze: the streak is already lost
+	s.checkStandupReminders(morning.Add(9 * time.Hour))
+	if messages := session.Messages(); len(messages) != 0 {
+		t.Fatalf("Expected no reminder for a lost streak, got %d", len(messages))
+	}
+
+	// Posting today still saves the streak with the freeze
+	twoDaysAgo := morning.AddDate(0, 0, -2).Add(10 * time.Hour)
+	s.store.DB().Model(&streak).Update("last_standup_date", twoDaysAgo)
+
+	s.store.UpdateStandupRestDays("guild-1", []time.Weekday{morning.Weekday()})
+	s.checkStandupReminders(morning.Add(9 * time.Hour))
+	if messages := session.Messages(); len(messages) != 0 {
+		t.Fatalf("Expected no reminder on a rest day, got %d", len(messages))
+	}
+
+	s.store.UpdateStandupRestDays("guild-1", nil)
+	s.checkStandupReminders(morning.Add(9 * time.Hour))
+	messages := session.MessagesIn("channel-reminders")
+	if len(messages) != 1 || messages[0].Embed.Title != "Standup Reminder" {
+		t.Fatalf("Expected a standup reminder, got %+v", messages)
+	}
+	if messages[0].Embed.Fields[1].Value != "🧊 1" {
+		t.Errorf("Expected the freeze count in the reminder, got %q", messages[0].Embed.Fields[1].Value)
+	}
+}
+
+func TestBlockerDigestPostedMondays(t *testing.T) {
+	s := newTestScheduler(t)
+	session := s.session.(*discordtest.Session)
+
+	user, _ := s.store.GetOrCreateUser("user-1", "guild-1", "alice")
+	if _, _, _, err := s.store.CreateStandup(user.ID, "guild-1", "Pricing page", "", "Stripe webhooks keep failing"); err != nil {
+		t.Fatalf("Failed to create standup: %v", err)
+	}
+
+	monday := time.Date(2026, 3, 16, 0, 0, 0, 0, time.Local)
+	s.postBlockerDigests(monday.AddDate(0, 0, 1).Add(10 * time.Hour))
+	s.postBlockerDigests(monday.Add(8 * time.Hour))
+	if messages := session.Messages(); len(messages) != 0 {
+		t.Fatalf("Expected no digest before Monday's reminder time, got %d", len(messages))
+	}
+
+	// Without a help channel the digest goes to the reminder channel
+	s.postBlockerDigests(monday.Add(9 * time.Hour))
+	s.postBlockerDigests(monday.Add(10 * time.Hour))
+	messages := session.MessagesIn("channel-reminders")
+	if len(messages) != 1 || messages[0].Embed.Title != "🚧 Weekly Blocker Digest" {
+		t.Fatalf("Expected one weekly digest, got %+v", messages)
+	}
+	if !strings.Contains(messages[0].Embed.Description, "<@user-1> · Stripe webhooks keep failing") {
+		t.Errorf("Expected the open blocker listed, got %q", messages[0].Embed.Description)
+	}
+
+	s.store.UpdateHelpChannel("guild-1", "channel-help")
+	s.postBlockerDigests(monday.AddDate(0, 0, 7).Add(9 * time.Hour))
+	if messages := session.MessagesIn("channel-help"); len(messages) != 1 {
+		t.Errorf("Expected next week's digest in the help channel, got %d", len(messages))
+	}
+}
+
+func TestStandupDigestPostedMondays(t *testing.T) {
+	s := newTestScheduler(t)
+	session := s.session.(*discordtest.Session)
+
+	alice, _ := s.store.GetOrCreateUser("user-1", "guild-1", "alice")
+	bob, _ := s.store.GetOrCreateUser("user-2", "guild-1", "bob")
+	carol, _ := s.store.GetOrCreateUser("user-3", "guild-1", "carol")
+	if _, err := s.store.CreateBuddyRequest(alice.ID, bob.ID, "guild-1"); err != nil {
+		t.Fatalf("Failed to request buddy: %v", err)
+	}
+	if _, err := s.store.AcceptBuddyRequest(alice.ID, bob.ID, "guild-1"); err != nil {
+		t.Fatalf("Failed to accept buddy: %v", err)
+	}
+	if _, _, _, err := s.store.CreateStandup(alice.ID, "guild-1", "Pricing page", "Shipped billing", "Stripe webhooks"); err != nil {
+		t.Fatalf("Failed to create standup: %v", err)
+	}
+	if _, _, _, err := s.store.CreateStandup(carol.ID, "guild-1", "Landing page", "", ""); err != nil {
+		t.Fatalf("Failed to create standup: %v", err)
+	}
+
+	// The digest covers the week before the coming Monday, which includes today
+	monday := database.StartOfDay(time.Now())
+	for monday.Weekday() != time.Monday || !monday.After(time.Now()) {
+		monday = monday.AddDate(0, 0, 1)
+	}
+	s.postStandupDigests(monday.Add(8 * time.Hour))
+	s.postStandupDigests(monday.AddDate(0, 0, 1).Add(10 * time.Hour))
+	if messages := session.Messages(); len(messages) != 0 {
+		t.Fatalf("Expected no digest before Monday's reminder time, got %d", len(messages))
+	}
+
+	s.store.UpdateStandupDigestChannel("guild-1", "channel-digest")
+	s.postStandupDigests(monday.Add(9 * time.Hour))
+	s.postStandupDigests(monday.Add(10 * time.Hour))
+	messages := session.MessagesIn("channel-digest")
+	if len(messages) != 3 {
+		t.Fatalf("Expected the participation digest and one per member, got %+v", messages)
+	}
+	if messages[0].Embed.Title != "📊 Weekly Standup Digest" || !strings.Contains(messages[0].Embed.Description, "2 of 2 members posted") {
+		t.Errorf("Expected the participation digest first, got %+v", messages[0].Embed)
+	}
+	if messages[1].Embed.Title != "📝 alice's Week" || !strings.Contains(messages[1].Embed.Description, "**Accomplished**\n• ") ||
+		!strings.Contains(messages[1].Embed.Description, "Stripe webhooks") {
+		t.Errorf("Expected alice's standups listed, got %+v", messages[1].Embed)
+	}
+
+	dms := session.DirectMessages("user-2")
+	if len(dms) != 1 || dms[0].Embed.Title != "📝 alice's Week" {
+		t.Errorf("Expected bob to be DM'd his buddy's digest, got %+v", dms)
+	}
+	if dms := session.DirectMessages("user-1"); len(dms) != 0 {
+		t.Errorf("Expected no digest for bob, who posted nothing, got %+v", dms)
+	}
+}
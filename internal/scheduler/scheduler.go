@@ -1,40 +1,76 @@
 package scheduler
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/summary"
 	"github.com/bwmarrin/discordgo"
 )
 
 // Scheduler handles periodic tasks like reminders
 type Scheduler struct {
-	session         *discordgo.Session
-	reminderChannel string // Channel ID to send reminders to
+	session         discord.Session
+	store           *database.Store
+	reminderChannel string // Fallback channel ID for guilds without their own reminder channel
+	summarizer      summary.Summarizer
+	jobs            []Job
 	stopChan        chan struct{}
 	ticker          *time.Ticker
 }
 
-// New creates a new Scheduler instance
-func New(session *discordgo.Session, reminderChannelID string) *Scheduler {
-	return &Scheduler{
+// New creates a new Scheduler instance.
+// reminderChannelID may be empty when every guild configures its own channel,
+// and a nil summarizer lists weekly standups without summarizing them.
+func New(session discord.Session, store *database.Store, reminderChannelID string, summarizer summary.Summarizer) *Scheduler {
+	if summarizer == nil {
+		summarizer = summary.Plain{}
+	}
+	s := &Scheduler{
 		session:         session,
+		store:           store,
 		reminderChannel: reminderChannelID,
+		summarizer:      summarizer,
 		stopChan:        make(chan struct{}),
 	}
+	s.jobs = s.defaultJobs()
+	return s
+}
+
+// defaultJobs lists every recurring job in the order they run
+func (s *Scheduler) defaultJobs() []Job {
+	return []Job{
+		{Name: "focus-reminders", Description: "Focus Period check-ins on reminder days at each guild's reminder time", Schedule: Hourly(), Run: s.checkDailyReminders},
+		{Name: "goal-due-reminders", Description: "Remind members the day before a goal is due at each guild's reminder time", Schedule: Hourly(), Run: s.checkDueGoalReminders},
+		{Name: "insufficient-tasks", Description: "Nudge users with fewer than the minimum goals at each guild's reminder time", Schedule: Hourly(), Run: s.checkInsufficientTasks},
+		{Name: "sprint-leaderboards", Description: "Post leaderboards for ended Focus Periods and cohort sprints from each guild's reminder time", Schedule: Hourly(), Run: s.checkEndedFocusPeriods},
+		{Name: "standup-reminders", Description: "Remind streak holders to post a standup at each guild's reminder time", Schedule: Hourly(), Run: s.checkStandupReminders},
+		{Name: "standup-threads", Description: "Open each guild's daily standup thread at its reminder time", Schedule: Hourly(), Run: s.openStandupThreads},
+		{Name: "blocker-digest", Description: "Post unresolved blockers on Mondays at each guild's reminder time", Schedule: Hourly(), Run: s.postBlockerDigests},
+		{Name: "standup-digest", Description: "Post last week's standups and participation on Mondays at each guild's reminder time", Schedule: Hourly(), Run: s.postStandupDigests},
+		{Name: "challenge-reminders", Description: "DM challenge participants about deadlines at each guild's reminder time", Schedule: Hourly(), Run: s.checkChallengeReminders},
+		{Name: "expired-challenges", Description: "Fail expired challenges and clean up buddy requests", Schedule: Daily(database.DefaultReminderHour), Run: s.checkExpiredChallenges},
+		{Name: "mrr-update-reminders", Description: "Ask founders to update their MRR in the last week of their month", Schedule: Hourly(), Run: s.sendMRRUpdateReminders},
+		{Name: "monthly-wins-summary", Description: "Post last month's wins summary on the 1st at each guild's reminder time", Schedule: Hourly(), Run: s.postMonthlyWinsSummary},
+		{Name: "monthly-mrr-showcase", Description: "Post the monthly MRR showcase on the 1st at each guild's reminder time", Schedule: Hourly(), Run: s.postMonthlyMRRShowcase},
+	}
 }
 
-// Start begins the scheduler, checking every hour for reminders to send
+// Start begins the scheduler, checking every 15 minutes for due jobs
 func (s *Scheduler) Start() {
 	log.Println("Starting reminder scheduler...")
 
-	// Check every hour
-	s.ticker = time.NewTicker(1 * time.Hour)
+	s.registerJobs()
+	s.ticker = time.NewTicker(15 * time.Minute)
 
 	go func() {
-		// Run immediately on start
+		// Run immediately on start to catch up on anything missed while offline
 		s.runChecks()
 
 		for {
@@ -60,117 +96,115 @@ func (s *Scheduler) Stop() {
 	close(s.stopChan)
 }
 
-// runChecks runs all scheduled checks
+// runChecks runs all jobs that are due
 func (s *Scheduler) runChecks() {
 	log.Println("Running scheduled checks...")
+	s.runDueJobs(time.Now())
+}
 
-	now := time.Now()
-	hour := now.Hour()
-
-	// Only run main checks once per day (between 9:00-10:00 AM)
-	if hour == 9 {
-		s.checkDailyReminders()
-		s.checkInsufficientTasks()
-		s.checkEndedFocusPeriods()
-		s.checkStandupReminders()
-		s.checkChallengeReminders()
-		s.checkExpiredChallenges()
-
-		// MRR update reminder - 7 days before month end
-		daysInMonth := daysInCurrentMonth(now)
-		if now.Day() == daysInMonth-7 {
-			s.sendMRRUpdateReminders()
-		}
+// guildConfig loads a guild's reminder settings, or nil if they can't be read
+func (s *Scheduler) guildConfig(guildID string) *database.GuildConfig {
+	config, err := s.store.GetGuildConfig(guildID)
+	if err != nil {
+		log.Printf("Error loading config for guild %s: %v", guildID, err)
+		return nil
 	}
+	return config
+}
 
-	// Monthly wins summary - first of the month at 10 AM
-	if now.Day() == 1 && hour == 10 {
-		s.postMonthlyWinsSummary()
-		s.postMonthlyMRRShowcase()
+// reminderChannelFor returns the guild's reminder channel, falling back to the environment default
+func (s *Scheduler) reminderChannelFor(config *database.GuildConfig) string {
+	if config.ReminderChannel != "" {
+		return config.ReminderChannel
 	}
+	return s.reminderChannel
 }
 
-// checkDailyReminders sends reminders on specific days (3, 7, 10, 12, 13)
-func (s *Scheduler) checkDailyReminders() {
-	if s.reminderChannel == "" {
-		log.Println("No reminder channel configured, skipping daily reminders")
-		return
+// reminderTarget returns a guild's settings and channel for a feature's reminders.
+// ok is false when the feature is switched off. The channel may be empty, in
+// which case only members who chose DMs are reminded.
+func (s *Scheduler) reminderTarget(guildID string, feature database.ReminderFeature) (config *database.GuildConfig, channelID string, ok bool) {
+	config = s.guildConfig(guildID)
+	if config == nil || !config.ReminderEnabled(feature) {
+		return nil, "", false
 	}
 
-	guildIDs, err := database.GetAllGuildsWithActivePeriods()
+	return config, s.reminderChannelFor(config), true
+}
+
+// checkDailyReminders sends reminders on each guild's reminder days.
+// Days and delivery times follow each member's timezone.
+func (s *Scheduler) checkDailyReminders(now time.Time) error {
+	guildIDs, err := s.store.GetAllGuildsWithActivePeriods()
 	if err != nil {
-		log.Printf("Error fetching guilds: %v", err)
-		return
+		return fmt.Errorf("failed to fetch guilds: %w", err)
 	}
 
 	for _, guildID := range guildIDs {
-		s.sendDailyRemindersForGuild(guildID)
+		config, channelID, ok := s.reminderTarget(guildID, database.ReminderFeatureFocus)
+		if !ok {
+			continue
+		}
+		s.sendDailyRemindersForGuild(guildID, channelID, config.ReminderHour, now)
 	}
+
+	return nil
 }
 
 // sendDailyRemindersForGuild sends reminders to users in a specific guild
-func (s *Scheduler) sendDailyRemindersForGuild(guildID string) {
-	periods, err := database.GetUsersWithActiveFocusPeriods(guildID)
+func (s *Scheduler) sendDailyRemindersForGuild(guildID, channelID string, hour int, now time.Time) {
+	periods, err := s.store.GetFocusPeriodsForReminder(guildID, now)
 	if err != nil {
 		log.Printf("Error fetching focus periods for guild %s: %v", guildID, err)
 		return
 	}
 
 	for _, period := range periods {
-		dayNumber := period.DayNumber()
+		local := now.In(s.store.UserLocation(period.UserID, guildID))
 
-		// Check if today is a reminder day
-		isReminderDay := false
-		for _, rd := range database.ReminderDays {
-			if dayNumber == rd {
-				isReminderDay = true
-				break
-			}
-		}
-
-		if !isReminderDay {
+		delivery, ok := s.notificationRoute(database.ReminderFeatureFocus, period.UserID, guildID, channelID, local)
+		if !ok {
 			continue
 		}
 
-		// Only remind if they have pending tasks
-		if period.PendingTaskCount() == 0 {
+		if !s.claimLocal("focus-reminder", period.UserID, guildID, local, hour, "2006-01-02") {
 			continue
 		}
 
-		s.sendReminderMessage(&period)
+		s.sendReminderMessage(delivery, channelID, &period, period.DayNumberAt(local))
 	}
 }
 
-// sendReminderMessage sends a reminder to the configured channel
-func (s *Scheduler) sendReminderMessage(period *database.FocusPeriod) {
-	if s.reminderChannel == "" {
-		return
-	}
-
-	dayNumber := period.DayNumber()
+// sendReminderMessage sends a Focus Period reminder by the user's chosen delivery
+func (s *Scheduler) sendReminderMessage(delivery database.Delivery, channelID string, period *database.FocusPeriod, dayNumber int) {
 	daysRemaining := period.DaysRemaining()
 	pendingCount := period.PendingTaskCount()
 	completedCount := period.CompletedTaskCount()
 
-	// Build urgency message based on day
+	// Build urgency message based on how far through the period we are
+	length := period.LengthDays()
+	daysLeft := length - dayNumber
 	var urgencyMessage string
 	var color int
 
 	switch {
-	case dayNumber == 13:
+	case daysLeft <= 0:
+		urgencyMessage = "**Final day!** Wrap up what you can today!"
+		color = 0xFF0000 // Red
+	case daysLeft == 1:
 		urgencyMessage = "**Final day tomorrow!** Time for a last push!"
 		color = 0xFF0000 // Red
-	case dayNumber == 12:
+	case daysLeft == 2:
 		urgencyMessage = "**Only 2 days left!** Let's finish strong!"
 		color = 0xFF6B6B // Light red
-	case dayNumber == 10:
-		urgencyMessage = "**4 days remaining.** Great time to make progress!"
-		color = 0xFFA500 // Orange
-	case dayNumber == 7:
+	case dayNumber == (length+1)/2:
 		urgencyMessage = "**Halfway through!** How are those goals coming along?"
 		color = 0x5865F2 // Blurple
-	case dayNumber == 3:
-		urgencyMessage = "**Day 3 check-in.** Building momentum!"
+	case daysLeft <= length/3:
+		urgencyMessage = fmt.Sprintf("**%d days remaining.** Great time to make progress!", daysLeft)
+		color = 0xFFA500 // Orange
+	case dayNumber < (length+1)/2:
+		urgencyMessage = fmt.Sprintf("**Day %d check-in.** Building momentum!", dayNumber)
 		color = 0x00FF00 // Green
 	default:
 		urgencyMessage = "Keep pushing towards your goals!"
@@ -198,52 +232,149 @@ func (s *Scheduler) sendReminderMessage(period *database.FocusPeriod) {
 		},
 	}
 
-	_, err := s.session.ChannelMessageSendEmbed(s.reminderChannel, embed)
+	err := s.deliver(delivery, period.User.DiscordID, channelID, embed)
 	if err != nil {
-		log.Printf("Error sending reminder to channel %s: %v", s.reminderChannel, err)
+		log.Printf("Error sending reminder to user %s: %v", period.User.DiscordID, err)
 	} else {
 		log.Printf("Sent Day %d reminder to user %s", dayNumber, period.User.DiscordID)
 	}
 }
 
-// checkInsufficientTasks reminds users who have less than 3 tasks
-func (s *Scheduler) checkInsufficientTasks() {
-	if s.reminderChannel == "" {
-		log.Println("No reminder channel configured, skipping insufficient tasks check")
-		return
+// checkDueGoalReminders reminds members about unfinished goals due the next day in their timezone
+func (s *Scheduler) checkDueGoalReminders(now time.Time) error {
+	guildIDs, err := s.store.GetAllGuildsWithActivePeriods()
+	if err != nil {
+		return fmt.Errorf("failed to fetch guilds: %w", err)
 	}
 
-	guildIDs, err := database.GetAllGuildsWithActivePeriods()
+	for _, guildID := range guildIDs {
+		config, channelID, ok := s.reminderTarget(guildID, database.ReminderFeatureFocus)
+		if !ok {
+			continue
+		}
+
+		// Two days covers tomorrow in every timezone
+		tasks, err := s.store.GetPendingTasksDueBefore(guildID, now, now.Add(48*time.Hour))
+		if err != nil {
+			log.Printf("Error fetching due goals for guild %s: %v", guildID, err)
+			continue
+		}
+
+		var userIDs []uint
+		byUser := make(map[uint][]database.Task)
+		for _, task := range tasks {
+			userID := task.FocusPeriod.UserID
+			if _, seen := byUser[userID]; !seen {
+				userIDs = append(userIDs, userID)
+			}
+			byUser[userID] = append(byUser[userID], task)
+		}
+
+		for _, userID := range userIDs {
+			loc := s.store.UserLocation(userID, guildID)
+			local := now.In(loc)
+			tomorrow := database.StartOfDay(local).AddDate(0, 0, 1).Format("2006-01-02")
+
+			var dueTomorrow []database.Task
+			for _, task := range byUser[userID] {
+				if task.DueDate.In(loc).Format("2006-01-02") == tomorrow {
+					dueTomorrow = append(dueTomorrow, task)
+				}
+			}
+			if len(dueTomorrow) == 0 {
+				continue
+			}
+
+			delivery, ok := s.notificationRoute(database.ReminderFeatureFocus, userID, guildID, channelID, local)
+			if !ok {
+				continue
+			}
+
+			if !s.claimLocal("goal-due", userID, guildID, local, config.ReminderHour, "2006-01-02") {
+				continue
+			}
+
+			s.sendDueGoalReminder(delivery, channelID, &dueTomorrow[0].FocusPeriod.User, dueTomorrow)
+		}
+	}
+
+	return nil
+}
+
+// sendDueGoalReminder lists a member's goals that are due tomorrow
+func (s *Scheduler) sendDueGoalReminder(delivery database.Delivery, channelID string, user *database.User, tasks []database.Task) {
+	var goals strings.Builder
+	for _, task := range tasks {
+		goals.WriteString(fmt.Sprintf("**#%d:** %s\n", task.Position, task.Title))
+	}
+
+	title := "Goal Due Tomorrow"
+	if len(tasks) > 1 {
+		title = "Goals Due Tomorrow"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: fmt.Sprintf("<@%s>, these goals are due tomorrow:\n\n%s", user.DiscordID, goals.String()),
+		Color:       0xFFA500, // Orange
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Use /focus complete <#> to mark done",
+		},
+	}
+
+	err := s.deliver(delivery, user.DiscordID, channelID, embed)
 	if err != nil {
-		log.Printf("Error fetching guilds: %v", err)
-		return
+		log.Printf("Error sending due goal reminder: %v", err)
+	} else {
+		log.Printf("Sent due goal reminder to user %s", user.DiscordID)
+	}
+}
+
+// checkInsufficientTasks reminds users who have less than 3 tasks
+func (s *Scheduler) checkInsufficientTasks(now time.Time) error {
+	guildIDs, err := s.store.GetAllGuildsWithActivePeriods()
+	if err != nil {
+		return fmt.Errorf("failed to fetch guilds: %w", err)
 	}
 
 	for _, guildID := range guildIDs {
-		periods, err := database.GetUsersWithInsufficientTasks(guildID)
+		config, channelID, ok := s.reminderTarget(guildID, database.ReminderFeatureFocus)
+		if !ok {
+			continue
+		}
+
+		periods, err := s.store.GetUsersWithInsufficientTasks(guildID)
 		if err != nil {
 			log.Printf("Error fetching insufficient tasks for guild %s: %v", guildID, err)
 			continue
 		}
 
 		for _, period := range periods {
-			// Only remind once per period (on day 2 or 3)
-			dayNumber := period.DayNumber()
+			// Only remind early in the period (on day 2 or 3)
+			local := now.In(s.store.UserLocation(period.UserID, guildID))
+			dayNumber := period.DayNumberAt(local)
 			if dayNumber != 2 && dayNumber != 3 {
 				continue
 			}
 
-			s.sendInsufficientTasksReminder(&period)
+			delivery, ok := s.notificationRoute(database.ReminderFeatureFocus, period.UserID, guildID, channelID, local)
+			if !ok {
+				continue
+			}
+
+			if !s.claimLocal("insufficient-tasks", period.UserID, guildID, local, config.ReminderHour, "2006-01-02") {
+				continue
+			}
+
+			s.sendInsufficientTasksReminder(delivery, channelID, &period)
 		}
 	}
+
+	return nil
 }
 
 // sendInsufficientTasksReminder reminds a user to add more tasks
-func (s *Scheduler) sendInsufficientTasksReminder(period *database.FocusPeriod) {
-	if s.reminderChannel == "" {
-		return
-	}
-
+func (s *Scheduler) sendInsufficientTasksReminder(delivery database.Delivery, channelID string, period *database.FocusPeriod) {
 	taskCount := len(period.Tasks)
 	needed := database.MinimumTasksRequired - taskCount
 
@@ -268,7 +399,7 @@ func (s *Scheduler) sendInsufficientTasksReminder(period *database.FocusPeriod)
 		},
 	}
 
-	_, err := s.session.ChannelMessageSendEmbed(s.reminderChannel, embed)
+	err := s.deliver(delivery, period.User.DiscordID, channelID, embed)
 	if err != nil {
 		log.Printf("Error sending insufficient tasks reminder: %v", err)
 	} else {
@@ -277,24 +408,30 @@ func (s *Scheduler) sendInsufficientTasksReminder(period *database.FocusPeriod)
 }
 
 // checkEndedFocusPeriods checks for ended focus periods and posts leaderboards
-func (s *Scheduler) checkEndedFocusPeriods() {
+func (s *Scheduler) checkEndedFocusPeriods(now time.Time) error {
 	log.Println("Checking for ended focus periods...")
 
-	guildIDs, err := database.GetAllGuildsWithActivePeriods()
+	guildIDs, err := s.store.GetGuildsWithEndedSprints()
 	if err != nil {
-		log.Printf("Error fetching guilds: %v", err)
-		return
+		return fmt.Errorf("failed to fetch guilds: %w", err)
 	}
 
 	for _, guildID := range guildIDs {
+		// Leaderboards go out from the guild's reminder time
+		config := s.guildConfig(guildID)
+		if config == nil || now.In(s.store.GuildLocation(guildID)).Hour() < config.ReminderHour {
+			continue
+		}
 		s.checkEndedPeriodsForGuild(guildID)
 	}
+
+	return nil
 }
 
 // checkEndedPeriodsForGuild checks and posts leaderboards for a specific guild
 func (s *Scheduler) checkEndedPeriodsForGuild(guildID string) {
 	// Get leaderboard channel for this guild
-	leaderboardChannel, err := database.GetLeaderboardChannel(guildID)
+	leaderboardChannel, err := s.store.GetLeaderboardChannel(guildID)
 	if err != nil {
 		log.Printf("Error getting leaderboard channel for guild %s: %v", guildID, err)
 		return
@@ -305,8 +442,11 @@ func (s *Scheduler) checkEndedPeriodsForGuild(guildID string) {
 		return
 	}
 
+	// Cohort sprints get one leaderboard and recap for the whole cohort
+	s.postCohortRecaps(guildID, leaderboardChannel)
+
 	// Get periods that ended but haven't had leaderboard posted
-	periods, err := database.GetEndedPeriodsNeedingLeaderboard(guildID)
+	periods, err := s.store.GetEndedPeriodsNeedingLeaderboard(guildID)
 	if err != nil {
 		log.Printf("Error fetching ended periods for guild %s: %v", guildID, err)
 		return
@@ -318,20 +458,25 @@ func (s *Scheduler) checkEndedPeriodsForGuild(guildID string) {
 
 	log.Printf("Found %d ended periods needing leaderboard for guild %s", len(periods), guildID)
 
-	// Post sprint leaderboard for this period
-	s.postSprintLeaderboard(guildID, leaderboardChannel)
+	// Post the sprint leaderboard for the periods that just ended
+	s.postSprintLeaderboard(guildID, leaderboardChannel, periods)
 
 	// Mark all periods as posted
 	for _, period := range periods {
-		if err := database.MarkLeaderboardPosted(period.ID); err != nil {
+		if err := s.store.MarkLeaderboardPosted(period.ID); err != nil {
 			log.Printf("Error marking leaderboard posted for period %d: %v", period.ID, err)
 		}
 	}
 }
 
-// postSprintLeaderboard posts the sprint leaderboard to a channel
-func (s *Scheduler) postSprintLeaderboard(guildID, channelID string) {
-	entries, err := database.GetSprintLeaderboard(guildID, 10)
+// postSprintLeaderboard posts the leaderboard for ended focus periods to a channel
+func (s *Scheduler) postSprintLeaderboard(guildID, channelID string, periods []database.FocusPeriod) {
+	periodIDs := make([]uint, 0, len(periods))
+	for _, period := range periods {
+		periodIDs = append(periodIDs, period.ID)
+	}
+
+	entries, err := s.store.GetSprintLeaderboardForPeriods(guildID, periodIDs, 10)
 	if err != nil {
 		log.Printf("Error fetching sprint leaderboard: %v", err)
 		return
@@ -378,6 +523,104 @@ func (s *Scheduler) postSprintLeaderboard(guildID, channelID string) {
 	}
 }
 
+// postCohortRecaps posts the leaderboard and recap for each of a guild's ended cohort sprints
+func (s *Scheduler) postCohortRecaps(guildID, channelID string) {
+	sprints, err := s.store.GetEndedCohortSprintsNeedingRecap(guildID)
+	if err != nil {
+		log.Printf("Error fetching ended cohort sprints for guild %s: %v", guildID, err)
+		return
+	}
+
+	for _, sprint := range sprints {
+		claimed, err := s.store.ClaimCohortSprintRecap(sprint.ID)
+		if err != nil {
+			log.Printf("Error claiming recap for cohort sprint %d: %v", sprint.ID, err)
+		}
+		if !claimed {
+			continue
+		}
+
+		s.postCohortRecap(guildID, channelID, &sprint)
+	}
+}
+
+// postCohortRecap posts a cohort sprint's final leaderboard and totals to a channel
+func (s *Scheduler) postCohortRecap(guildID, channelID string, sprint *database.CohortSprint) {
+	recap, err := s.store.GetCohortSprintRecap(sprint.ID)
+	if err != nil {
+		log.Printf("Error fetching recap for cohort sprint %d: %v", sprint.ID, err)
+		return
+	}
+
+	entries, err := s.store.GetCohortSprintLeaderboard(guildID, sprint.ID, 10)
+	if err != nil {
+		log.Printf("Error fetching leaderboard for cohort sprint %d: %v", sprint.ID, err)
+		return
+	}
+
+	description := fmt.Sprintf("**%s is complete!**\n\n", sprint.Name)
+	if len(entries) == 0 {
+		description += "No one earned points this sprint. There's always the next one!"
+	} else {
+		description += "Here are the top performers from the sprint:\n\n"
+	}
+	for _, entry := range entries {
+		medal := ""
+		switch entry.Rank {
+		case 1:
+			medal = "🥇"
+		case 2:
+			medal = "🥈"
+		case 3:
+			medal = "🥉"
+		default:
+			medal = fmt.Sprintf("`#%d`", entry.Rank)
+		}
+
+		description += fmt.Sprintf("%s **%s** - %d points (%d tasks)\n",
+			medal, entry.Username, entry.Points, entry.TasksCount)
+	}
+
+	loc := s.store.GuildLocation(guildID)
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("🏁 %s Recap", sprint.Name),
+		Description: description,
+		Color:       0xFFD700, // Gold
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Sprint",
+				Value:  fmt.Sprintf("%s - %s", sprint.StartDate.In(loc).Format("Jan 2"), sprint.EndDate.In(loc).AddDate(0, 0, -1).Format("Jan 2, 2006")),
+				Inline: true,
+			},
+			{
+				Name:   "Participants",
+				Value:  fmt.Sprintf("%d", recap.Participants),
+				Inline: true,
+			},
+			{
+				Name:   "Goals",
+				Value:  fmt.Sprintf("✅ %d of %d completed", recap.GoalsCompleted, recap.GoalsSet),
+				Inline: true,
+			},
+			{
+				Name:   "Points Earned",
+				Value:  fmt.Sprintf("%d", recap.Points),
+				Inline: true,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Congratulations to everyone in the cohort! Join the next sprint with /focus start.",
+		},
+	}
+
+	_, err = s.session.ChannelMessageSendEmbed(channelID, embed)
+	if err != nil {
+		log.Printf("Error posting cohort sprint recap to channel %s: %v", channelID, err)
+	} else {
+		log.Printf("Posted recap for cohort sprint %d to channel %s", sprint.ID, channelID)
+	}
+}
+
 // SendManualReminder allows sending a manual reminder (for testing)
 func (s *Scheduler) SendManualReminder(userDiscordID string, message string) error {
 	if s.reminderChannel == "" {
@@ -394,44 +637,57 @@ func (s *Scheduler) SendManualReminder(userDiscordID string, message string) err
 	return err
 }
 
-// checkStandupReminders reminds users who haven't posted a standup today
-func (s *Scheduler) checkStandupReminders() {
-	if s.reminderChannel == "" {
-		log.Println("No reminder channel configured, skipping standup reminders")
-		return
-	}
-
-	guildIDs, err := database.GetAllGuildsWithActivePeriods()
+// checkStandupReminders reminds users who haven't posted a standup today.
+// Nobody is reminded on the guild's rest days, or once missed days have cost them their streak.
+func (s *Scheduler) checkStandupReminders(now time.Time) error {
+	guildIDs, err := s.store.GetAllGuildsWithActivePeriods()
 	if err != nil {
-		log.Printf("Error fetching guilds for standup reminders: %v", err)
-		return
+		return fmt.Errorf("failed to fetch guilds for standup reminders: %w", err)
 	}
 
 	for _, guildID := range guildIDs {
-		users, err := database.GetUsersWithoutStandupToday(guildID)
+		config, channelID, ok := s.reminderTarget(guildID, database.ReminderFeatureStandup)
+		if !ok {
+			continue
+		}
+
+		users, err := s.store.GetUsersWithoutStandupToday(guildID)
 		if err != nil {
 			log.Printf("Error fetching users without standup for guild %s: %v", guildID, err)
 			continue
 		}
+		restDays := config.RestDays()
 
 		for _, user := range users {
 			// Get user's streak info
-			streak, _ := database.GetUserStreak(user.ID, guildID)
+			streak, _ := s.store.GetUserStreak(user.ID, guildID)
 
 			// Only remind if they have a streak going (don't spam new users)
-			if streak != nil && streak.CurrentStreak > 0 {
-				s.sendStandupReminder(&user, streak)
+			if streak == nil || streak.CurrentStreak == 0 {
+				continue
+			}
+
+			local := now.In(s.store.UserLocation(user.ID, guildID))
+			if restDays[local.Weekday()] || streak.MissedDays(local, restDays) > streak.FreezeTokens {
+				continue
+			}
+
+			delivery, ok := s.notificationRoute(database.ReminderFeatureStandup, user.ID, guildID, channelID, local)
+			if !ok {
+				continue
+			}
+
+			if s.claimLocal("standup-reminder", user.ID, guildID, local, config.ReminderHour, "2006-01-02") {
+				s.sendStandupReminder(delivery, channelID, &user, streak)
 			}
 		}
 	}
+
+	return nil
 }
 
 // sendStandupReminder sends a standup reminder to a user
-func (s *Scheduler) sendStandupReminder(user *database.User, streak *database.UserStreak) {
-	if s.reminderChannel == "" {
-		return
-	}
-
+func (s *Scheduler) sendStandupReminder(delivery database.Delivery, channelID string, user *database.User, streak *database.UserStreak) {
 	embed := &discordgo.MessageEmbed{
 		Title:       "Standup Reminder",
 		Description: fmt.Sprintf("<@%s>, don't forget to post your daily standup!", user.DiscordID),
@@ -442,13 +698,18 @@ func (s *Scheduler) sendStandupReminder(user *database.User, streak *database.Us
 				Value:  fmt.Sprintf("%d days - don't break it!", streak.CurrentStreak),
 				Inline: true,
 			},
+			{
+				Name:   "Streak Freezes",
+				Value:  fmt.Sprintf("🧊 %d", streak.FreezeTokens),
+				Inline: true,
+			},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
 			Text: "Use /standup post to check in",
 		},
 	}
 
-	_, err := s.session.ChannelMessageSendEmbed(s.reminderChannel, embed)
+	err := s.deliver(delivery, user.DiscordID, channelID, embed)
 	if err != nil {
 		log.Printf("Error sending standup reminder: %v", err)
 	} else {
@@ -456,35 +717,321 @@ func (s *Scheduler) sendStandupReminder(user *database.User, streak *database.Us
 	}
 }
 
-// checkChallengeReminders sends reminders for active challenges
-func (s *Scheduler) checkChallengeReminders() {
-	guildIDs, err := database.GetAllGuildsWithActivePeriods()
+// openStandupThreads opens the day's standup thread in guilds that use them, once the guild's reminder time is reached
+func (s *Scheduler) openStandupThreads(now time.Time) error {
+	configs, err := s.store.GetGuildsWithStandupThreads()
+	if err != nil {
+		return err
+	}
+
+	for _, config := range configs {
+		local := now.In(s.store.GuildLocation(config.GuildID))
+		if !s.claimLocal("standup-thread", 0, config.GuildID, local, config.ReminderHour, "2006-01-02") {
+			continue
+		}
+		s.openStandupThread(&config, local)
+	}
+
+	return nil
+}
+
+// openStandupThread starts a guild's standup thread for a day and prompts members to reply in it
+func (s *Scheduler) openStandupThread(config *database.GuildConfig, local time.Time) {
+	thread, err := s.session.ThreadStartComplex(config.StandupThreadChannel, &discordgo.ThreadStart{
+		Name:                fmt.Sprintf("Standup · %s", local.Format("Mon Jan 2")),
+		AutoArchiveDuration: 1440,
+		Type:                discordgo.ChannelTypeGuildPublicThread,
+	})
 	if err != nil {
-		log.Printf("Error fetching guilds for challenge reminders: %v", err)
+		log.Printf("Error opening standup thread in guild %s: %v", config.GuildID, err)
 		return
 	}
 
+	if _, err := s.store.SaveStandupThread(config.GuildID, config.StandupThreadChannel, thread.ID, local.Format("2006-01-02")); err != nil {
+		log.Printf("Error saving standup thread for guild %s: %v", config.GuildID, err)
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "☀️ Daily Standup",
+		Description: "Reply in this thread to check in for today. Your reply counts toward your streak just like `/standup post`.",
+		Color:       0x5865F2, // Blurple
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Format",
+				Value: "**Accomplished:** what you got done\n**Working on:** what's next today\n**Blockers:** anything in your way\n\nStart lines with these headings so your reply is counted. Only *Working on* is required, and a reply without headings is just conversation.",
+			},
+		},
+	}
+	if _, err := s.session.ChannelMessageSendEmbed(thread.ID, embed); err != nil {
+		log.Printf("Error posting standup prompt in guild %s: %v", config.GuildID, err)
+	}
+}
+
+// blockerDigestLimit is how many blockers the weekly digest lists
+const blockerDigestLimit = 15
+
+// postBlockerDigests posts each guild's unresolved blockers on Monday, to the help channel or else the reminder channel
+func (s *Scheduler) postBlockerDigests(now time.Time) error {
+	guildIDs, err := s.store.GetGuildsWithOpenBlockers()
+	if err != nil {
+		return err
+	}
+
 	for _, guildID := range guildIDs {
-		challenges, err := database.GetChallengesNeedingReminder(guildID)
+		config := s.guildConfig(guildID)
+		if config == nil {
+			continue
+		}
+		channelID := config.HelpChannel
+		if channelID == "" {
+			channelID = s.reminderChannelFor(config)
+		}
+		local := now.In(s.store.GuildLocation(guildID))
+		if channelID == "" || local.Weekday() != time.Monday {
+			continue
+		}
+		if !s.claimLocal("blocker-digest", 0, guildID, local, config.ReminderHour, "2006-01-02") {
+			continue
+		}
+
+		blockers, err := s.store.GetOpenBlockers(guildID)
+		if err != nil {
+			log.Printf("Error fetching open blockers for guild %s: %v", guildID, err)
+			continue
+		}
+		s.postBlockerDigest(channelID, blockers, now)
+	}
+
+	return nil
+}
+
+// postBlockerDigest lists a guild's unresolved blockers, oldest first
+func (s *Scheduler) postBlockerDigest(channelID string, blockers []database.Blocker, now time.Time) {
+	if len(blockers) == 0 {
+		return
+	}
+
+	var description strings.Builder
+	description.WriteString(fmt.Sprintf("**%d** blockers are still open. Can you help with one?\n\n", len(blockers)))
+	for idx, blocker := range blockers {
+		if idx >= blockerDigestLimit {
+			description.WriteString(fmt.Sprintf("\n*...and %d more - see /blocker list*", len(blockers)-blockerDigestLimit))
+			break
+		}
+		days := max(int(now.Sub(blocker.CreatedAt).Hours()/24), 0)
+		description.WriteString(fmt.Sprintf("`#%d` <@%s> · %s · open %d days\n", blocker.ID, blocker.User.DiscordID, truncateString(blocker.Description, 80), days))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🚧 Weekly Blocker Digest",
+		Description: description.String(),
+		Color:       0xFFA500, // Orange
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Helped someone? They can credit you with /blocker resolve",
+		},
+	}
+
+	if _, err := s.session.ChannelMessageSendEmbed(channelID, embed); err != nil {
+		log.Printf("Error posting blocker digest: %v", err)
+	}
+}
+
+// standupDigestTopPosters is how many members the participation digest names as most consistent
+const standupDigestTopPosters = 5
+
+// postStandupDigests posts each guild's standups from the week before on Monday, to the digest
+// channel or else the reminder channel, and DMs each member's digest to their buddies
+func (s *Scheduler) postStandupDigests(now time.Time) error {
+	guildIDs, err := s.store.GetGuildsWithStandupsSince(now.AddDate(0, 0, -8))
+	if err != nil {
+		return err
+	}
+
+	for _, guildID := range guildIDs {
+		config := s.guildConfig(guildID)
+		if config == nil {
+			continue
+		}
+		channelID := config.StandupDigestChannel
+		if channelID == "" {
+			channelID = s.reminderChannelFor(config)
+		}
+		local := now.In(s.store.GuildLocation(guildID))
+		if local.Weekday() != time.Monday {
+			continue
+		}
+		if !s.claimLocal("standup-digest", 0, guildID, local, config.ReminderHour, "2006-01-02") {
+			continue
+		}
+
+		end := database.StartOfDay(local)
+		week, err := s.store.GetStandupWeek(guildID, end.AddDate(0, 0, -7), end)
+		if err != nil {
+			log.Printf("Error fetching standups for guild %s: %v", guildID, err)
+			continue
+		}
+		if len(week.Standups) == 0 {
+			continue
+		}
+		s.postStandupDigest(guildID, channelID, week, end.AddDate(0, 0, -7), local)
+	}
+
+	return nil
+}
+
+// postStandupDigest posts a guild's participation for the week, then each member's digest
+func (s *Scheduler) postStandupDigest(guildID, channelID string, week *database.StandupWeek, start, local time.Time) {
+	dates := fmt.Sprintf("%s – %s", start.Format("Jan 2"), start.AddDate(0, 0, 6).Format("Jan 2"))
+
+	// Group each member's standups, keeping them in the order they were fetched
+	var members [][]database.Standup
+	for _, standup := range week.Standups {
+		if n := len(members); n > 0 && members[n-1][0].UserID == standup.UserID {
+			members[n-1] = append(members[n-1], standup)
+			continue
+		}
+		members = append(members, []database.Standup{standup})
+	}
+
+	if channelID != "" {
+		s.postParticipationDigest(channelID, week, members, dates)
+	}
+
+	for _, standups := range members {
+		user := standups[0].User
+		entries := make([]summary.Entry, 0, len(standups))
+		for _, standup := range standups {
+			entries = append(entries, summary.Entry{
+				Date:         standup.Date.In(local.Location()),
+				Accomplished: standup.Accomplished,
+				WorkingOn:    standup.WorkingOn,
+				Blockers:     standup.Blockers,
+			})
+		}
+
+		text, err := s.summarizer.Summarize(context.Background(), user.Username, entries)
+		if err != nil {
+			log.Printf("Error summarizing standups for user %s: %v", user.DiscordID, err)
+			continue
+		}
+
+		embed := &discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("📝 %s's Week", user.Username),
+			Description: fmt.Sprintf("<@%s> posted **%d** of %d standups\n\n%s", user.DiscordID, len(standups), week.ActiveDays, text),
+			Color:       0x5865F2, // Blurple
+			Footer: &discordgo.MessageEmbedFooter{
+				Text: "Weekly Standup Digest · " + dates,
+			},
+		}
+
+		if channelID != "" {
+			if _, err := s.session.ChannelMessageSendEmbed(channelID, embed); err != nil {
+				log.Printf("Error posting standup digest for user %s: %v", user.DiscordID, err)
+			}
+		}
+		s.sendDigestToBuddies(&user, guildID, embed)
+	}
+}
+
+// postParticipationDigest posts how many of a guild's expected standups were posted and who posted most
+func (s *Scheduler) postParticipationDigest(channelID string, week *database.StandupWeek, members [][]database.Standup, dates string) {
+	ranked := make([][]database.Standup, len(members))
+	copy(ranked, members)
+	sort.SliceStable(ranked, func(a, b int) bool {
+		return len(ranked[a]) > len(ranked[b])
+	})
+
+	var consistent strings.Builder
+	for idx, standups := range ranked {
+		if idx >= standupDigestTopPosters {
+			break
+		}
+		consistent.WriteString(fmt.Sprintf("**%d.** <@%s> · %d standups\n", idx+1, standups[0].User.DiscordID, len(standups)))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: "📊 Weekly Standup Digest",
+		Description: fmt.Sprintf("**%.0f%%** participation · %d of %d members posted · %d standups over %d days",
+			week.Participation()*100, week.Posters, week.Members, len(week.Standups), week.ActiveDays),
+		Color: 0x5865F2, // Blurple
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Most Consistent",
+				Value: consistent.String(),
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: dates,
+		},
+	}
+
+	if _, err := s.session.ChannelMessageSendEmbed(channelID, embed); err != nil {
+		log.Printf("Error posting standup participation digest: %v", err)
+	}
+}
+
+// sendDigestToBuddies DMs a member's weekly digest to buddies who get their notifications,
+// except those who switched standup notifications off
+func (s *Scheduler) sendDigestToBuddies(user *database.User, guildID string, embed *discordgo.MessageEmbed) {
+	buddies, err := s.store.GetBuddiesWithNotifications(user.ID, guildID)
+	if err != nil {
+		log.Printf("Error fetching buddies for user %s: %v", user.DiscordID, err)
+		return
+	}
+
+	for _, buddy := range buddies {
+		settings, err := s.store.GetNotificationSettings(buddy.ID, guildID)
+		if err != nil {
+			log.Printf("Error loading notification settings for user %d: %v", buddy.ID, err)
+			continue
+		}
+		if settings.DeliveryFor(database.ReminderFeatureStandup) == database.DeliveryOff {
+			continue
+		}
+		if err := s.sendDM(buddy.DiscordID, embed); err != nil {
+			log.Printf("Error sending standup digest to buddy %s: %v", buddy.DiscordID, err)
+		}
+	}
+}
+
+// checkChallengeReminders sends reminders for active challenges.
+// Each participant is reminded in the morning of their own timezone.
+func (s *Scheduler) checkChallengeReminders(now time.Time) error {
+	guildIDs, err := s.store.GetAllGuildsWithActivePeriods()
+	if err != nil {
+		return fmt.Errorf("failed to fetch guilds for challenge reminders: %w", err)
+	}
+
+	for _, guildID := range guildIDs {
+		config := s.guildConfig(guildID)
+		if config == nil || !config.ChallengeRemindersEnabled {
+			continue
+		}
+
+		challenges, err := s.store.GetChallengesNeedingReminder(guildID)
 		if err != nil {
 			log.Printf("Error fetching challenges for guild %s: %v", guildID, err)
 			continue
 		}
 
 		for _, challenge := range challenges {
-			daysLeft := int(time.Until(challenge.EndDate).Hours() / 24)
+			daysLeft := int(challenge.EndDate.Sub(now).Hours() / 24)
 
 			// Send reminders at key points: 1 day, 3 days, 7 days left
 			if daysLeft == 1 || daysLeft == 3 || daysLeft == 7 {
-				s.sendChallengeReminder(&challenge, daysLeft)
+				s.sendChallengeReminder(&challenge, daysLeft, config.ReminderHour, s.reminderChannelFor(config), now)
 			}
 		}
 	}
+
+	return nil
 }
 
-// sendChallengeReminder sends a challenge reminder to participants
-func (s *Scheduler) sendChallengeReminder(challenge *database.Challenge, daysLeft int) {
-	_, participants, err := database.GetChallengeWithParticipants(challenge.ID)
+// sendChallengeReminder sends a challenge reminder to participants.
+// Participants get a DM unless they chose the reminder channel instead.
+func (s *Scheduler) sendChallengeReminder(challenge *database.Challenge, daysLeft, hour int, channelID string, now time.Time) {
+	_, participants, err := s.store.GetChallengeWithParticipants(challenge.ID)
 	if err != nil {
 		return
 	}
@@ -504,14 +1051,20 @@ func (s *Scheduler) sendChallengeReminder(challenge *database.Challenge, daysLef
 			continue
 		}
 
-		channel, err := s.session.UserChannelCreate(participant.User.DiscordID)
-		if err != nil {
+		local := now.In(s.store.UserLocation(participant.UserID, challenge.GuildID))
+		delivery, ok := s.notificationRoute(database.ReminderFeatureChallenge, participant.UserID, challenge.GuildID, channelID, local)
+		if !ok {
+			continue
+		}
+
+		kind := fmt.Sprintf("challenge-reminder-%d", challenge.ID)
+		if !s.claimLocal(kind, participant.UserID, challenge.GuildID, local, hour, "2006-01-02") {
 			continue
 		}
 
 		embed := &discordgo.MessageEmbed{
 			Title:       fmt.Sprintf("Challenge Reminder - #%d", challenge.ID),
-			Description: fmt.Sprintf("%s\n\n**Goal:** %s", urgency, challenge.Title),
+			Description: fmt.Sprintf("<@%s> %s\n\n**Goal:** %s", participant.User.DiscordID, urgency, challenge.Title),
 			Color:       color,
 			Fields: []*discordgo.MessageEmbedField{
 				{
@@ -530,42 +1083,56 @@ func (s *Scheduler) sendChallengeReminder(challenge *database.Challenge, daysLef
 			},
 		}
 
-		s.session.ChannelMessageSendEmbed(channel.ID, embed)
+		if err := s.deliver(delivery, participant.User.DiscordID, channelID, embed); err != nil {
+			log.Printf("Error sending challenge reminder to user %s: %v", participant.User.DiscordID, err)
+		}
 	}
 }
 
 // checkExpiredChallenges marks expired challenges as failed
-func (s *Scheduler) checkExpiredChallenges() {
-	err := database.CheckAndFailExpiredChallenges()
+func (s *Scheduler) checkExpiredChallenges(now time.Time) error {
+	err := s.store.CheckAndFailExpiredChallenges()
 	if err != nil {
-		log.Printf("Error checking expired challenges: %v", err)
+		return fmt.Errorf("failed to check expired challenges: %w", err)
 	}
 
 	// Also cleanup expired buddy requests
-	database.CleanupExpiredRequests()
+	if err := s.store.CleanupExpiredRequests(); err != nil {
+		log.Printf("Error cleaning up expired buddy requests: %v", err)
+	}
+
+	return nil
 }
 
-// postMonthlyWinsSummary posts a summary of last month's wins
-func (s *Scheduler) postMonthlyWinsSummary() {
-	guildIDs, err := database.GetAllGuildsWithActivePeriods()
+// postMonthlyWinsSummary posts a summary of last month's wins once the guild's month has turned
+func (s *Scheduler) postMonthlyWinsSummary(now time.Time) error {
+	guildIDs, err := s.store.GetAllGuildsWithActivePeriods()
 	if err != nil {
-		log.Printf("Error fetching guilds for monthly wins: %v", err)
-		return
+		return fmt.Errorf("failed to fetch guilds for monthly wins: %w", err)
 	}
 
 	for _, guildID := range guildIDs {
-		winsChannel, err := database.GetWinsChannel(guildID)
+		winsChannel, err := s.store.GetWinsChannel(guildID)
 		if err != nil || winsChannel == "" {
 			continue
 		}
 
-		wins, err := database.GetMonthlyTopWins(guildID, 10)
+		config := s.guildConfig(guildID)
+		local := now.In(s.store.GuildLocation(guildID))
+		if config == nil || !monthlyPostDue(local, config.ReminderHour) {
+			continue
+		}
+
+		wins, err := s.store.GetMonthlyTopWins(guildID, 10)
 		if err != nil || len(wins) == 0 {
 			continue
 		}
 
-		now := time.Now()
-		lastMonth := now.AddDate(0, -1, 0)
+		if !s.claimLocal("monthly-wins-summary", 0, guildID, local, 0, "2006-01") {
+			continue
+		}
+
+		lastMonth := time.Date(local.Year(), local.Month()-1, 1, 0, 0, 0, 0, local.Location())
 		monthName := lastMonth.Format("January 2006")
 
 		description := fmt.Sprintf("Here are the highlights from **%s**:\n\n", monthName)
@@ -593,6 +1160,8 @@ func (s *Scheduler) postMonthlyWinsSummary() {
 			log.Printf("Posted monthly wins summary to channel %s", winsChannel)
 		}
 	}
+
+	return nil
 }
 
 // truncateString truncates a string to maxLen characters
@@ -603,6 +1172,12 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// monthlyPostDue reports whether a guild's monthly post is due at its local time,
+// which is from the reminder hour on the 1st for the rest of the month
+func monthlyPostDue(local time.Time, hour int) bool {
+	return local.Day() > 1 || local.Hour() >= hour
+}
+
 // daysInCurrentMonth returns the number of days in the current month
 func daysInCurrentMonth(t time.Time) int {
 	// Get the first day of next month, then subtract one day
@@ -613,30 +1188,38 @@ func daysInCurrentMonth(t time.Time) int {
 }
 
 // postMonthlyMRRShowcase posts the monthly MRR leaderboard to configured channels
-func (s *Scheduler) postMonthlyMRRShowcase() {
-	guildIDs, err := database.GetAllGuildsWithMRRChannel()
+func (s *Scheduler) postMonthlyMRRShowcase(now time.Time) error {
+	guildIDs, err := s.store.GetAllGuildsWithMRRChannel()
 	if err != nil {
-		log.Printf("Error fetching guilds with MRR channel: %v", err)
-		return
+		return fmt.Errorf("failed to fetch guilds with MRR channel: %w", err)
 	}
 
 	for _, guildID := range guildIDs {
-		mrrChannel, err := database.GetMRRChannel(guildID)
+		mrrChannel, err := s.store.GetMRRChannel(guildID)
 		if err != nil || mrrChannel == "" {
 			continue
 		}
 
-		entries, err := database.GetPublicMRRWithGrowth(guildID)
+		config := s.guildConfig(guildID)
+		local := now.In(s.store.GuildLocation(guildID))
+		if config == nil || !monthlyPostDue(local, config.ReminderHour) {
+			continue
+		}
+
+		entries, err := s.store.GetPublicMRRWithGrowth(guildID)
 		if err != nil || len(entries) == 0 {
 			continue
 		}
 
+		if !s.claimLocal("monthly-mrr-showcase", 0, guildID, local, 0, "2006-01") {
+			continue
+		}
+
 		// Get month name for title
-		now := time.Now()
-		monthName := now.Format("January 2006")
+		monthName := local.Format("January 2006")
 
 		// Calculate total community MRR
-		totalMRR, _ := database.GetTotalCommunityMRR(guildID)
+		totalMRR, _ := s.store.GetTotalCommunityMRR(guildID)
 
 		// Build leaderboard description
 		description := fmt.Sprintf("Here's our community's MRR progress for **%s**:\n\n", monthName)
@@ -698,29 +1281,49 @@ func (s *Scheduler) postMonthlyMRRShowcase() {
 			log.Printf("Posted monthly MRR showcase to channel %s", mrrChannel)
 		}
 	}
+
+	return nil
 }
 
 // sendMRRUpdateReminders sends reminders to users with configured project channels
-func (s *Scheduler) sendMRRUpdateReminders() {
-	guildIDs, err := database.GetAllGuildsWithMRRChannel()
+// during the last week of the month in each user's timezone
+func (s *Scheduler) sendMRRUpdateReminders(now time.Time) error {
+	guildIDs, err := s.store.GetAllGuildsWithMRRChannel()
 	if err != nil {
-		log.Printf("Error fetching guilds for MRR reminders: %v", err)
-		return
+		return fmt.Errorf("failed to fetch guilds for MRR reminders: %w", err)
 	}
 
 	for _, guildID := range guildIDs {
-		settings, err := database.GetUsersWithProjectChannels(guildID)
+		config := s.guildConfig(guildID)
+		if config == nil || !config.MRRRemindersEnabled {
+			continue
+		}
+
+		settings, err := s.store.GetUsersWithProjectChannels(guildID)
 		if err != nil {
 			log.Printf("Error fetching users with project channels for guild %s: %v", guildID, err)
 			continue
 		}
 
-		now := time.Now()
-		daysUntilMonthEnd := daysInCurrentMonth(now) - now.Day()
-
 		for _, setting := range settings {
+			local := now.In(s.store.UserLocation(setting.UserID, guildID))
+			daysUntilMonthEnd := daysInCurrentMonth(local) - local.Day()
+			if daysUntilMonthEnd > 7 {
+				continue
+			}
+
+			// Channel delivery means the founder's own project channel
+			delivery, ok := s.notificationRoute(database.ReminderFeatureMRR, setting.UserID, guildID, setting.ProjectChannelID, local)
+			if !ok {
+				continue
+			}
+
+			if !s.claimLocal("mrr-update-reminder", setting.UserID, guildID, local, config.ReminderHour, "2006-01") {
+				continue
+			}
+
 			// Get user's current MRR
-			latestMRR, _ := database.GetLatestMRR(setting.UserID, guildID)
+			latestMRR, _ := s.store.GetLatestMRR(setting.UserID, guildID)
 
 			var currentMRRStr string
 			if latestMRR != nil {
@@ -751,14 +1354,16 @@ func (s *Scheduler) sendMRRUpdateReminders() {
 				},
 			}
 
-			_, err := s.session.ChannelMessageSendEmbed(setting.ProjectChannelID, embed)
+			err := s.deliver(delivery, setting.User.DiscordID, setting.ProjectChannelID, embed)
 			if err != nil {
-				log.Printf("Error sending MRR reminder to channel %s: %v", setting.ProjectChannelID, err)
+				log.Printf("Error sending MRR reminder to user %s: %v", setting.User.DiscordID, err)
 			} else {
-				log.Printf("Sent MRR update reminder to user %s in channel %s", setting.User.DiscordID, setting.ProjectChannelID)
+				log.Printf("Sent MRR update reminder to user %s", setting.User.DiscordID)
 			}
 		}
 	}
+
+	return nil
 }
 
 // getCurrencySymbol returns the symbol for a currency code (scheduler local copy)
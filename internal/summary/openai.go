@@ -0,0 +1,95 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	goopenai "github.com/sashabaranov/go-openai"
+)
+
+// DefaultOpenAIModel is the model used unless another is configured
+const DefaultOpenAIModel = goopenai.GPT4oMini
+
+// OpenAI summarizes standups with a chat completion model, either from OpenAI
+// or from any server exposing an OpenAI-compatible API
+type OpenAI struct {
+	client  *goopenai.Client
+	model   string
+	name    string
+	timeout time.Duration
+}
+
+// NewOpenAI creates a summarizer for the OpenAI API.
+// baseURL may point at an OpenAI-compatible server such as a local model; leave it empty for OpenAI itself.
+func NewOpenAI(apiKey, baseURL, model string, timeout time.Duration) *OpenAI {
+	config := goopenai.DefaultConfig(apiKey)
+	name := ProviderOpenAI
+	if baseURL != "" {
+		config.BaseURL = strings.TrimRight(baseURL, "/")
+		name = ProviderOpenAICompatible
+	}
+	if model == "" {
+		model = DefaultOpenAIModel
+	}
+
+	return &OpenAI{
+		client:  goopenai.NewClientWithConfig(config),
+		model:   model,
+		name:    name,
+		timeout: timeout,
+	}
+}
+
+// Name identifies the provider and model in logs
+func (o *OpenAI) Name() string {
+	return fmt.Sprintf("%s (%s)", o.name, o.model)
+}
+
+// Summarize asks the model for a few bullet points on a member's week
+func (o *OpenAI) Summarize(ctx context.Context, name string, entries []Entry) (string, error) {
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	req := goopenai.ChatCompletionRequest{
+		Model: o.model,
+		Messages: []goopenai.ChatCompletionMessage{
+			{
+				Role:    goopenai.ChatMessageRoleUser,
+				Content: summaryPrompt(name, entries),
+			},
+		},
+		Temperature: 0.3,
+		MaxTokens:   300, // A handful of short bullets
+	}
+
+	resp, err := o.client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("%s API error: %w", o.name, err)
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("no response from %s", o.name)
+	}
+
+	return truncate(strings.TrimSpace(resp.Choices[0].Message.Content)), nil
+}
+
+// summaryPrompt asks for a digest of a member's standups
+func summaryPrompt(name string, entries []Entry) string {
+	var standups strings.Builder
+	for _, entry := range entries {
+		standups.WriteString(fmt.Sprintf("%s\nAccomplished: %s\nWorking on: %s\nBlockers: %s\n\n",
+			entry.Date.Format("Monday Jan 2"), entry.Accomplished, entry.WorkingOn, entry.Blockers))
+	}
+
+	return fmt.Sprintf(`You write weekly digests for a community of solo founders.
+Summarize %s's standups from this week in 3 to 5 short bullet points starting with "• ".
+Cover what they shipped, what they are focused on and anything still blocking them.
+Use only what the standups say and keep each bullet under 20 words.
+
+%s`, name, strings.TrimSpace(standups.String()))
+}
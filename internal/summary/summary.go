@@ -0,0 +1,145 @@
+// Package summary condenses a member's week of standups into a short digest.
+package summary
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// Providers that can be selected in Config
+const (
+	ProviderOpenAI           = "openai"
+	ProviderOpenAICompatible = "openai-compatible"
+	ProviderPlain            = "plain"
+)
+
+// MaxLength caps a summary so it fits in a Discord embed alongside its title and fields
+const MaxLength = 2000
+
+// Entry is one standup in a member's week
+type Entry struct {
+	Date         time.Time
+	Accomplished string
+	WorkingOn    string
+	Blockers     string
+}
+
+// Summarizer condenses a week of standups into a short digest
+type Summarizer interface {
+	// Summarize returns a digest of a member's standups, oldest first
+	Summarize(ctx context.Context, name string, entries []Entry) (string, error)
+	// Name identifies the summarizer in logs
+	Name() string
+}
+
+// Config selects and configures a summarizer
+type Config struct {
+	// Provider is one of the Provider constants. Empty picks ProviderPlain,
+	// so standups are only sent to a model when a server owner opts in.
+	Provider string
+	// APIKey authenticates with OpenAI or an OpenAI-compatible server
+	APIKey string
+	// BaseURL is the API root of an OpenAI-compatible server
+	BaseURL string
+	// Model overrides DefaultOpenAIModel
+	Model string
+	// Timeout caps each call to the model; zero leaves it to the caller's context
+	Timeout time.Duration
+}
+
+// New creates the summarizer selected by cfg.
+// Model-backed summarizers fall back to plain concatenation when the model fails.
+func New(cfg Config) (Summarizer, error) {
+	var model Summarizer
+	switch cfg.Provider {
+	case "", ProviderPlain:
+		return Plain{}, nil
+	case ProviderOpenAI:
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("the %s summarizer needs an API key", cfg.Provider)
+		}
+		model = NewOpenAI(cfg.APIKey, "", cfg.Model, cfg.Timeout)
+	case ProviderOpenAICompatible:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("the %s summarizer needs a base URL", cfg.Provider)
+		}
+		model = NewOpenAI(cfg.APIKey, cfg.BaseURL, cfg.Model, cfg.Timeout)
+	default:
+		return nil, fmt.Errorf("unknown summary provider %q (expected %s, %s or %s)", cfg.Provider, ProviderOpenAI, ProviderOpenAICompatible, ProviderPlain)
+	}
+
+	log.Printf("Summarizing standup digests with %s", model.Name())
+	return WithFallback(model, Plain{}), nil
+}
+
+// Plain lists a week's standups section by section without calling a model
+type Plain struct{}
+
+// Name identifies the summarizer in logs
+func (Plain) Name() string {
+	return ProviderPlain
+}
+
+// Summarize lists what was accomplished, worked on and blocking, one bullet per day
+func (Plain) Summarize(_ context.Context, _ string, entries []Entry) (string, error) {
+	var text strings.Builder
+	section := func(title string, value func(Entry) string) {
+		var lines []string
+		for _, entry := range entries {
+			if v := strings.TrimSpace(value(entry)); v != "" {
+				lines = append(lines, fmt.Sprintf("• %s: %s", entry.Date.Format("Mon"), strings.Join(strings.Fields(v), " ")))
+			}
+		}
+		if len(lines) == 0 {
+			return
+		}
+		if text.Len() > 0 {
+			text.WriteString("\n")
+		}
+		text.WriteString(fmt.Sprintf("**%s**\n%s\n", title, strings.Join(lines, "\n")))
+	}
+
+	section("Accomplished", func(e Entry) string { return e.Accomplished })
+	section("Working on", func(e Entry) string { return e.WorkingOn })
+	section("Blockers", func(e Entry) string { return e.Blockers })
+
+	return truncate(strings.TrimSpace(text.String())), nil
+}
+
+// truncate cuts a summary to MaxLength
+func truncate(text string) string {
+	if runes := []rune(text); len(runes) > MaxLength {
+		return strings.TrimSpace(string(runes[:MaxLength-1])) + "…"
+	}
+	return text
+}
+
+// withFallback answers from a second summarizer when the first fails
+type withFallback struct {
+	primary  Summarizer
+	fallback Summarizer
+}
+
+// WithFallback wraps a summarizer so failures are answered by fallback instead
+func WithFallback(primary, fallback Summarizer) Summarizer {
+	return &withFallback{primary: primary, fallback: fallback}
+}
+
+// Name identifies the primary summarizer
+func (f *withFallback) Name() string {
+	return f.primary.Name()
+}
+
+// Summarize asks the primary summarizer, then the fallback if that fails
+func (f *withFallback) Summarize(ctx context.Context, name string, entries []Entry) (string, error) {
+	text, err := f.primary.Summarize(ctx, name, entries)
+	if err == nil && strings.TrimSpace(text) != "" {
+		return text, nil
+	}
+
+	log.Printf("Falling back to %s for standup digest: %v", f.fallback.Name(), err)
+	return f.fallback.Summarize(ctx, name, entries)
+}
@@ -0,0 +1,58 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// failing is a summarizer that always errors
+type failing struct{}
+
+func (failing) Name() string { return "failing" }
+
+func (failing) Summarize(context.Context, string, []Entry) (string, error) {
+	return "", errors.New("model unavailable")
+}
+
+func TestPlainSummary(t *testing.T) {
+	monday := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{Date: monday, WorkingOn: "Pricing page", Accomplished: "Shipped billing"},
+		{Date: monday.AddDate(0, 0, 2), WorkingOn: "Onboarding\nemails", Blockers: "Stripe webhooks"},
+	}
+
+	text, err := Plain{}.Summarize(context.Background(), "alice", entries)
+	if err != nil {
+		t.Fatalf("Failed to summarize: %v", err)
+	}
+	want := "**Accomplished**\n• Mon: Shipped billing\n\n**Working on**\n• Mon: Pricing page\n• Wed: Onboarding emails\n\n**Blockers**\n• Wed: Stripe webhooks"
+	if text != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, text)
+	}
+}
+
+func TestSummaryFallsBackToPlain(t *testing.T) {
+	entries := []Entry{{Date: time.Now(), WorkingOn: "Pricing page"}}
+
+	text, err := WithFallback(failing{}, Plain{}).Summarize(context.Background(), "alice", entries)
+	if err != nil || text != "**Working on**\n• "+entries[0].Date.Format("Mon")+": Pricing page" {
+		t.Errorf("Expected the plain summary, got %q (%v)", text, err)
+	}
+}
+
+func TestNewSummarizer(t *testing.T) {
+	if summarizer, err := New(Config{}); err != nil || summarizer.Name() != ProviderPlain {
+		t.Errorf("Expected plain summaries by default, got %v (%v)", summarizer, err)
+	}
+	if _, err := New(Config{Provider: ProviderOpenAI}); err == nil {
+		t.Error("Expected OpenAI summaries to need an API key")
+	}
+	if summarizer, err := New(Config{Provider: ProviderOpenAI, APIKey: "key"}); err != nil || summarizer.Name() != "openai (gpt-4o-mini)" {
+		t.Errorf("Expected an OpenAI summarizer, got %v (%v)", summarizer, err)
+	}
+	if _, err := New(Config{Provider: "bard"}); err == nil {
+		t.Error("Expected an unknown provider to be rejected")
+	}
+}
@@ -6,20 +6,23 @@ import (
 	"time"
 
 	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/database"
+	"github.com/bootstrap-hub/bootstrap-hub-bot/internal/discord"
 	"github.com/bwmarrin/discordgo"
 )
 
 // Voter handles scheduled checking and processing of expired resource votes
 type Voter struct {
-	session  *discordgo.Session
+	session  discord.Session
+	store    *database.Store
 	ticker   *time.Ticker
 	stopChan chan struct{}
 }
 
 // New creates a new Voter instance
-func New(session *discordgo.Session) *Voter {
+func New(session discord.Session, store *database.Store) *Voter {
 	return &Voter{
 		session:  session,
+		store:    store,
 		stopChan: make(chan struct{}),
 	}
 }
@@ -58,7 +61,7 @@ func (v *Voter) Stop() {
 
 // checkExpiredVotes processes all resources with expired voting periods
 func (v *Voter) checkExpiredVotes() {
-	resources, err := database.GetPendingResourcesWithExpiredVotes()
+	resources, err := v.store.GetPendingResourcesWithExpiredVotes()
 	if err != nil {
 		log.Printf("Error fetching expired votes: %v", err)
 		return
@@ -98,7 +101,7 @@ func (v *Voter) processVoteResult(resource database.PublicResource) {
 
 	// Update status in database
 	now := time.Now()
-	err := database.UpdateResourceStatus(resource.ID, status, now)
+	err := v.store.UpdateResourceStatus(resource.ID, status, now)
 	if err != nil {
 		log.Printf("Error updating resource status: %v", err)
 		return